@@ -3,46 +3,213 @@ package main
 import (
 	"context"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
 	"github.com/adityawiryaa/api/internal/config"
+	delivery "github.com/adityawiryaa/api/internal/delivery/http/agent"
+	"github.com/adityawiryaa/api/internal/repository/file"
 	"github.com/adityawiryaa/api/internal/repository/memory"
 	agentuc "github.com/adityawiryaa/api/internal/usecases/agent"
 	"github.com/adityawiryaa/api/pkg/backoff"
+	"github.com/adityawiryaa/api/pkg/bundlesign"
 	controllerclient "github.com/adityawiryaa/api/pkg/controller"
+	"github.com/adityawiryaa/api/pkg/hook"
 	workerclient "github.com/adityawiryaa/api/pkg/worker"
 )
 
 func main() {
-	cfg := config.LoadAgentConfig()
+	cfg, err := config.LoadAgentConfig(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
 	controllerClient := controllerclient.NewClient(cfg.ControllerURL, cfg.APIKey, cfg.RequestTimeout)
-	workerClient := workerclient.NewClient(cfg.WorkerURL, cfg.RequestTimeout)
+	controllerClient.SetCompression(cfg.Compression)
+
+	pool := memory.NewWorkerPool(func(url string) usecases.WorkerClient {
+		client := workerclient.NewClient(url, cfg.RequestTimeout)
+		client.SetCompression(cfg.Compression)
+		return client
+	}, cfg.WorkerTTL)
+	for _, url := range cfg.WorkerURLs {
+		pool.Add(url, valueobject.WorkerSourceStatic)
+	}
 
 	store := memory.NewConfigStore()
+	state := memory.NewAgentState()
+	workers := memory.NewWorkerStatusStore()
+	cache := file.NewConfigCache(cfg.CachePath)
+
+	var templates usecases.TemplateRenderer
+	if cfg.TemplatesFile != "" {
+		resources, err := file.LoadTemplateResources(cfg.TemplatesFile)
+		if err != nil {
+			log.Fatalf("failed to load templates: %v", err)
+		}
+		writer, err := file.NewTemplateWriter(resources, cfg.TemplateCommandTimeout)
+		if err != nil {
+			log.Fatalf("failed to load templates: %v", err)
+		}
+		templates = writer
+		log.Printf("rendering %d template(s) from %s", len(resources), cfg.TemplatesFile)
+	}
 
-	commandUC := agentuc.NewCommandUsecase(controllerClient, workerClient, store, backoff.DefaultConfig())
-	queryUC := agentuc.NewQueryUsecase(controllerClient, store)
+	var hooks usecases.HookRunner
+	if cfg.HooksFile != "" {
+		defs, err := file.LoadHookDefinitions(cfg.HooksFile)
+		if err != nil {
+			log.Fatalf("failed to load hooks: %v", err)
+		}
+		runner, err := hook.NewRunner(defs)
+		if err != nil {
+			log.Fatalf("failed to load hooks: %v", err)
+		}
+		hooks = runner
+		log.Printf("running %d hook(s) from %s", len(defs), cfg.HooksFile)
+	}
 
-	resp, err := commandUC.RegisterWithController(ctx, &entity.RegistrationRequest{
-		Hostname:  cfg.Hostname,
-		IPAddress: cfg.IPAddress,
-		Port:      cfg.Port,
-	})
+	var overrides usecases.OverrideSource
+	if cfg.OverridesFile != "" {
+		overrides = file.NewOverrideFile(cfg.OverridesFile)
+		log.Printf("watching local overrides in %s", cfg.OverridesFile)
+	}
+
+	var bundles usecases.BundleInbox
+	var verifier usecases.BundleVerifier
+	if cfg.BundleDir != "" {
+		v, err := bundlesign.LoadVerifier(cfg.BundlePublicKeyFile)
+		if err != nil {
+			log.Fatalf("failed to load bundle public key: %v", err)
+		}
+		bundles, verifier = file.NewBundleDir(cfg.BundleDir), v
+		log.Printf("watching %s for bundles signed with key %s", cfg.BundleDir, v.KeyID())
+	}
+	state.SetIdentity(entity.AgentIdentity{Hostname: cfg.Hostname, Labels: cfg.Labels})
+
+	var downstream repository.AgentRepositoryQuery
+	var relaySrv *http.Server
+	if cfg.RelayPort != 0 {
+		registry := memory.NewAgentRegistry()
+		downstream = registry
+		relaySrv = newRelayServer(cfg, store, registry)
+	}
+
+	commandUC := agentuc.NewCommandUsecase(controllerClient, pool, store, state, workers, cache, templates, hooks, overrides, bundles, verifier, downstream, backoff.DefaultConfig())
+	queryUC := agentuc.NewQueryUsecase(controllerClient, store, state, pool, workers)
+
+	handler := delivery.NewHandler(commandUC, queryUC)
+	router := delivery.SetupRouter(handler, cfg.Compression)
+
+	srv := &http.Server{
+		Addr:    ":" + strconv.Itoa(cfg.Port),
+		Handler: router,
+	}
+
+	go func() {
+		log.Printf("agent API listening on :%d", cfg.Port)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("agent API error: %v", err)
+		}
+	}()
+
+	if relaySrv != nil {
+		go func() {
+			if err := relaySrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("relay API error: %v", err)
+			}
+		}()
+	}
+
+	if err := commandUC.RefreshOverrides(ctx); err != nil {
+		log.Printf("overrides error: %v", err)
+	}
+
+	cached, err := commandUC.RestoreFromCache(ctx)
 	if err != nil {
-		log.Fatalf("failed to register: %v", err)
+		log.Printf("ignoring config cache: %v", err)
+	}
+	if cached != nil {
+		log.Printf("restored cached config version %d from %s", cached.Version, cfg.CachePath)
+		if err := commandUC.ApplyConfig(ctx); err != nil {
+			log.Printf("apply cached config error: %v", err)
+		}
+	}
+	if err := commandUC.ImportBundles(ctx); err != nil {
+		log.Printf("bundle import error: %v", err)
+	}
+
+	scheduler := backoff.NewScheduler(cfg.PollInterval, cfg.PollJitter, backoff.Config{
+		MaxInterval: cfg.PollMaxBackoff,
+		Multiplier:  2.0,
+		MaxRetries:  10,
+	})
+	if cfg.ControllerURL != "" {
+		go commandUC.MaintainRegistration(ctx, &entity.RegistrationRequest{
+			Hostname:  cfg.Hostname,
+			IPAddress: cfg.IPAddress,
+			Port:      cfg.Port,
+		})
+
+		log.Printf("starting config polling (interval: %s, jitter: %.0f%%)", cfg.PollInterval, cfg.PollJitter*100)
+		go queryUC.StartPolling(ctx, scheduler, commandUC.ApplyConfig, commandUC.ExecuteCommands)
+	} else {
+		log.Printf("no controller configured, applying config from bundles only")
 	}
-	log.Printf("registered as agent %s", resp.AgentID)
 
-	log.Printf("starting config polling (interval: %s)", cfg.PollInterval)
-	queryUC.StartPolling(ctx, cfg.PollInterval, commandUC.ForwardConfigToWorker)
+	log.Printf("starting worker reconciliation for %d worker(s) (interval: %s)", len(cfg.WorkerURLs), cfg.ReconcileInterval)
+	reconcileInterval := cfg.ReconcileInterval
+	reconcileCtx, stopReconcile := context.WithCancel(ctx)
+	go commandUC.StartReconciling(reconcileCtx, reconcileInterval)
+
+	go config.ReloadOnHangup(ctx, cfg, loadConfig, (*config.AgentConfig).RestartRequired, func(next *config.AgentConfig) {
+		if err := commandUC.RefreshOverrides(ctx); err != nil {
+			log.Printf("overrides error: %v", err)
+		}
+		if err := commandUC.ImportBundles(ctx); err != nil {
+			log.Printf("bundle import error: %v", err)
+		}
+		scheduler.SetInterval(next.PollInterval)
+		scheduler.SetJitter(next.PollJitter)
+		scheduler.SetMaxInterval(next.PollMaxBackoff)
+		state.SetPollInterval(next.PollInterval)
+
+		if next.ReconcileInterval != reconcileInterval {
+			stopReconcile()
+			reconcileInterval = next.ReconcileInterval
+			reconcileCtx, stopReconcile = context.WithCancel(ctx)
+			go commandUC.StartReconciling(reconcileCtx, reconcileInterval)
+		}
+		log.Printf("config reload: applied (poll interval: %s, jitter: %.0f%%, reconcile interval: %s)",
+			next.PollInterval, next.PollJitter*100, next.ReconcileInterval)
+	})
+
+	<-ctx.Done()
 
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("agent API shutdown error: %v", err)
+	}
+	if relaySrv != nil {
+		if err := relaySrv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("relay API shutdown error: %v", err)
+		}
+	}
 	log.Println("agent stopped")
-	os.Exit(0)
+}
+
+func loadConfig() (*config.AgentConfig, error) {
+	return config.LoadAgentConfig(os.Args[1:])
 }
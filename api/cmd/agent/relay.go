@@ -0,0 +1,29 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/adityawiryaa/api/internal/config"
+	controllerdelivery "github.com/adityawiryaa/api/internal/delivery/http/controller"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	controlleruc "github.com/adityawiryaa/api/internal/usecases/controller"
+)
+
+func newRelayServer(cfg *config.AgentConfig, store *memory.ConfigStore, agents *memory.AgentRegistry) *http.Server {
+	configs := memory.NewConfigMirror(store, cfg.RelayHistory)
+	commands := memory.NewCommandQueue()
+
+	commandUC := controlleruc.NewCommandUsecase(agents, configs, configs, nil, nil, agents, commands, commands)
+	queryUC := controlleruc.NewQueryUsecase(configs, agents, nil, commands, nil)
+
+	handler := controllerdelivery.NewHandler(commandUC, queryUC)
+	router := controllerdelivery.SetupRelayRouter(handler, cfg.RelayAPIKey, cfg.Compression)
+
+	log.Printf("relay mode enabled, serving downstream agents on :%d", cfg.RelayPort)
+	return &http.Server{
+		Addr:    ":" + strconv.Itoa(cfg.RelayPort),
+		Handler: router,
+	}
+}
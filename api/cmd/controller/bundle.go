@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/pkg/bundlesign"
+	"github.com/adityawiryaa/api/pkg/configformat"
+)
+
+func runExport(queryUC domainuc.UsecaseControllerQuery, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	output := fs.String("o", "", "write the bundle to this file instead of stdout")
+	formatFlag := fs.String("format", "", "bundle format: json or yaml (default from the -o extension, else json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	format, err := bundleFormat(*formatFlag, *output)
+	if err != nil {
+		return err
+	}
+
+	bundle, err := queryUC.ExportBundle(context.Background())
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("creating %s: %w", *output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := writeBundle(w, format, bundle); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+
+	if *output != "" {
+		fmt.Fprintf(os.Stderr, "exported %d config version(s) and %d agent(s) to %s\n", len(bundle.Configs), len(bundle.Agents), *output)
+	}
+	return nil
+}
+
+func runImport(commandUC domainuc.UsecaseControllerCommand, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	mode := fs.String("mode", valueobject.ImportModeReplay, "import mode: replay or squash")
+	formatFlag := fs.String("format", "", "bundle format: json or yaml (default from the file extension, else json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: controller import [-mode replay|squash] [-format json|yaml] <bundle.json|bundle.yaml|->")
+	}
+	format, err := bundleFormat(*formatFlag, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if path := fs.Arg(0); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading bundle: %w", err)
+	}
+	var bundle entity.ConfigBundle
+	if err := configformat.Unmarshal(format, raw, &bundle); err != nil {
+		return fmt.Errorf("decoding bundle: %w", err)
+	}
+
+	result, err := commandUC.ImportBundle(context.Background(), &bundle, *mode)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("imported %d config version(s) and %d agent(s) (mode=%s, latest version=%d)\n",
+		result.ConfigsImported, result.AgentsImported, result.Mode, result.LatestVersion)
+	return nil
+}
+
+func bundleFormat(flagValue, path string) (string, error) {
+	if flagValue == "" {
+		if format, err := configformat.FromExtension(filepath.Ext(path)); err == nil && format == configformat.FormatYAML {
+			return format, nil
+		}
+		return configformat.FormatJSON, nil
+	}
+	format, err := configformat.FromExtension(flagValue)
+	if err != nil || format == configformat.FormatTOML {
+		return "", fmt.Errorf("unsupported bundle format %q: must be json or yaml", flagValue)
+	}
+	return format, nil
+}
+
+func writeBundle(w io.Writer, format string, bundle *entity.ConfigBundle) error {
+	if format == configformat.FormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(bundle)
+	}
+	raw, err := configformat.Marshal(format, bundle)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(raw)
+	return err
+}
+
+func runExportAgent(queryUC domainuc.UsecaseControllerQuery, args []string) error {
+	fs := flag.NewFlagSet("export-agent", flag.ContinueOnError)
+	agentID := fs.String("agent", "", "ID of the agent the bundle is for")
+	selector := fs.String("selector", "", "label selector of the agents the bundle is for, e.g. site=north,env!=dev")
+	output := fs.String("o", "", "write the bundle to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	signed, err := queryUC.ExportAgentBundle(context.Background(), entity.BundleTarget{AgentID: *agentID, Selector: *selector})
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("creating %s: %w", *output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(signed); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+
+	if *output != "" {
+		fmt.Fprintf(os.Stderr, "exported bundle signed with key %s to %s\n", signed.KeyID, *output)
+	}
+	return nil
+}
+
+func runKeygen(args []string) error {
+	fs := flag.NewFlagSet("keygen", flag.ContinueOnError)
+	prefix := fs.String("o", "bundle-signing", "write <prefix>.key and <prefix>.pub")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	privatePEM, publicPEM, err := bundlesign.GenerateKey()
+	if err != nil {
+		return err
+	}
+	keyPath, pubPath := *prefix+".key", *prefix+".pub"
+	if err := os.WriteFile(keyPath, privatePEM, 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", keyPath, err)
+	}
+	if err := os.WriteFile(pubPath, publicPEM, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", pubPath, err)
+	}
+
+	fmt.Printf("wrote signing key %s and public key %s\n", keyPath, pubPath)
+	return nil
+}
@@ -1,21 +1,36 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"os"
 	"time"
 
+	"github.com/adityawiryaa/api/domain/usecases"
 	"github.com/adityawiryaa/api/internal/config"
 	delivery "github.com/adityawiryaa/api/internal/delivery/http/controller"
 	"github.com/adityawiryaa/api/internal/repository"
 	"github.com/adityawiryaa/api/internal/repository/commands"
 	"github.com/adityawiryaa/api/internal/repository/queries"
 	controlleruc "github.com/adityawiryaa/api/internal/usecases/controller"
+	"github.com/adityawiryaa/api/pkg/bundlesign"
+	"github.com/adityawiryaa/api/pkg/configsource"
 	"github.com/adityawiryaa/api/pkg/shutdown"
 )
 
 func main() {
-	cfg := config.LoadControllerConfig()
+	cfg, args, err := config.LoadControllerConfig(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
+
+	if len(args) > 0 && args[0] == "keygen" {
+		if err := runKeygen(args[1:]); err != nil {
+			log.Fatalf("keygen: %v", err)
+		}
+		return
+	}
 
 	db, err := config.NewDB(cfg.DBPath)
 	if err != nil {
@@ -23,19 +38,91 @@ func main() {
 	}
 	defer db.Close()
 
+	if len(args) > 0 && args[0] == "migrate" {
+		if err := runMigrate(db, args[1:]); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+
 	if err := repository.Migrate(db); err != nil {
 		log.Fatalf("failed to migrate database: %v", err)
 	}
 
 	agentCmd := commands.NewAgentCommand(db)
+	agentQuery := queries.NewAgentQuery(db)
 	configCmd := commands.NewConfigCommand(db)
 	configQuery := queries.NewConfigQuery(db)
+	bundleCmd := commands.NewBundleCommand(db)
+	bundleQuery := queries.NewBundleQuery(db)
+	commandQueueCmd := commands.NewCommandQueueCommand(db)
+	commandQueueQuery := queries.NewCommandQueueQuery(db)
 
-	commandUC := controlleruc.NewCommandUsecase(agentCmd, configCmd, configQuery)
-	queryUC := controlleruc.NewQueryUsecase(configQuery)
+	commandUC := controlleruc.NewCommandUsecase(agentCmd, configCmd, configQuery, bundleCmd, bundleQuery, agentQuery, commandQueueCmd, commandQueueQuery)
+	var signer usecases.BundleSigner
+	if cfg.BundleSigningKeyFile != "" {
+		s, err := bundlesign.LoadSigner(cfg.BundleSigningKeyFile)
+		if err != nil {
+			log.Fatalf("failed to load bundle signing key: %v", err)
+		}
+		signer = s
+		log.Printf("signing agent bundles with key %s", s.KeyID())
+	}
+	queryUC := controlleruc.NewQueryUsecase(configQuery, agentQuery, bundleQuery, commandQueueQuery, signer)
+
+	if len(args) > 0 {
+		var err error
+		switch args[0] {
+		case "export":
+			err = runExport(queryUC, args[1:])
+		case "import":
+			err = runImport(commandUC, args[1:])
+		case "export-agent":
+			err = runExportAgent(queryUC, args[1:])
+		default:
+			log.Fatalf("unknown command %q", args[0])
+		}
+		if err != nil {
+			log.Fatalf("%s: %v", args[0], err)
+		}
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if cfg.Sync.Dir != "" {
+		source := configsource.NewDirSource(cfg.Sync.Dir, cfg.Sync.GitPull)
+		syncer, err := controlleruc.NewConfigSyncer(source, commandUC, configQuery, cfg.Sync.ConflictPolicy)
+		if err != nil {
+			log.Fatalf("failed to configure sync: %v", err)
+		}
+		log.Printf("[sync] watching %s (interval: %s, git: %v, conflict policy: %s)",
+			cfg.Sync.Dir, cfg.Sync.Interval, source.IsGit(), cfg.Sync.ConflictPolicy)
+		syncInterval := cfg.Sync.Interval
+		syncCtx, stopSync := context.WithCancel(ctx)
+		go syncer.Run(syncCtx, syncInterval)
+
+		go config.ReloadOnHangup(ctx, cfg, loadConfig, (*config.ControllerConfig).RestartRequired, func(next *config.ControllerConfig) {
+			if err := syncer.SetConflictPolicy(next.Sync.ConflictPolicy); err != nil {
+				log.Printf("config reload: %v", err)
+			}
+			if next.Sync.Interval != syncInterval {
+				stopSync()
+				syncInterval = next.Sync.Interval
+				syncCtx, stopSync = context.WithCancel(ctx)
+				go syncer.Run(syncCtx, syncInterval)
+			}
+			log.Printf("config reload: applied (sync interval: %s, conflict policy: %s)", next.Sync.Interval, next.Sync.ConflictPolicy)
+		})
+	} else {
+		go config.ReloadOnHangup(ctx, cfg, loadConfig, (*config.ControllerConfig).RestartRequired, func(*config.ControllerConfig) {
+			log.Println("config reload: applied")
+		})
+	}
 
 	handler := delivery.NewHandler(commandUC, queryUC)
-	router := delivery.SetupRouter(handler, cfg.APIKey)
+	router := delivery.SetupRouter(handler, cfg.APIKey, cfg.Compression)
 
 	srv := &http.Server{
 		Addr:    ":" + cfg.Port,
@@ -51,3 +138,8 @@ func main() {
 
 	shutdown.GracefulShutdown(srv, 5*time.Second)
 }
+
+func loadConfig() (*config.ControllerConfig, error) {
+	cfg, _, err := config.LoadControllerConfig(os.Args[1:])
+	return cfg, err
+}
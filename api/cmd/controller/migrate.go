@@ -0,0 +1,57 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/adityawiryaa/api/internal/repository"
+)
+
+func runMigrate(db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: controller migrate up|down [steps]|status")
+	}
+
+	switch args[0] {
+	case "up":
+		if err := repository.MigrateUp(db); err != nil {
+			return err
+		}
+		fmt.Println("migrations applied")
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid steps %q: must be a positive number", args[1])
+			}
+			steps = n
+		}
+		if err := repository.MigrateDown(db, steps); err != nil {
+			return err
+		}
+		fmt.Printf("reverted %d migration(s)\n", steps)
+	case "status":
+		states, err := repository.MigrationStatus(db)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range states {
+			status, appliedAt := "pending", "-"
+			if s.Applied {
+				status = "applied"
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, status, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command %q: expected up, down or status", args[0])
+	}
+	return nil
+}
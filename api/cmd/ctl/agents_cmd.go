@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/adityawiryaa/api/domain/request"
+)
+
+func (a *app) runAgents(args []string) error {
+	cmd, rest, err := subcommand(args, "agents")
+	if err != nil {
+		return err
+	}
+	switch cmd {
+	case "list":
+		return a.listAgents()
+	case "command":
+		return a.sendAgentCommand(rest)
+	case "commands":
+		return a.listAgentCommands(rest)
+	default:
+		return fmt.Errorf("unknown agents subcommand %q", cmd)
+	}
+}
+
+func (a *app) listAgents() error {
+	agents, err := a.controller.ListAgents(context.Background())
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(agents))
+	for _, agent := range agents {
+		rows = append(rows, []string{agent.ID, agent.Hostname, agent.IPAddress, strconv.Itoa(agent.Port), agent.Status})
+	}
+	return a.printer.print(agents, []string{"ID", "HOSTNAME", "IP", "PORT", "STATUS"}, rows)
+}
+
+func (a *app) sendAgentCommand(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: agents command <agent-id> <type> [key=value ...]")
+	}
+
+	cmdArgs := make(map[string]string, len(args)-2)
+	for _, arg := range args[2:] {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Errorf("invalid argument %q, want key=value", arg)
+		}
+		cmdArgs[key] = value
+	}
+
+	cmd, err := a.controller.EnqueueCommand(context.Background(), args[0], &request.EnqueueCommandRequest{
+		Type: args[1],
+		Args: cmdArgs,
+	})
+	if err != nil {
+		return err
+	}
+	return a.printer.print(cmd, []string{"ID", "TYPE", "STATUS"}, [][]string{{cmd.ID, cmd.Type, cmd.Status}})
+}
+
+func (a *app) listAgentCommands(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: agents commands <agent-id>")
+	}
+
+	cmds, err := a.controller.ListAgentCommands(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		rows = append(rows, []string{cmd.ID, cmd.Type, cmd.Status, cmd.CreatedAt.Format("2006-01-02 15:04:05"), cmd.Error})
+	}
+	return a.printer.print(cmds, []string{"ID", "TYPE", "STATUS", "CREATED", "ERROR"}, rows)
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/pkg/configdiff"
+	"github.com/adityawiryaa/api/pkg/configformat"
+)
+
+func (a *app) runConfig(args []string) error {
+	cmd, rest, err := subcommand(args, "config")
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "publish":
+		return a.configPublish(rest)
+	case "show":
+		return a.configShow(rest)
+	case "diff":
+		return a.configDiff(rest)
+	case "rollback":
+		return a.configRollback(rest)
+	default:
+		return fmt.Errorf("unknown config subcommand %q", cmd)
+	}
+}
+
+func (a *app) configPublish(args []string) error {
+	fs := flag.NewFlagSet("config publish", flag.ContinueOnError)
+	file := fs.String("f", "", "config file (.json, .yaml, .yml or .toml)")
+	pollInterval := fs.Int("poll-interval", 0, "override poll_interval_seconds from the file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("-f is required")
+	}
+
+	req, err := readConfigFile(*file)
+	if err != nil {
+		return err
+	}
+	if *pollInterval > 0 {
+		req.PollIntervalSeconds = *pollInterval
+	}
+
+	cfg, err := a.controller.PublishConfig(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return a.printConfig(cfg)
+}
+
+func (a *app) configShow(args []string) error {
+	var version int64
+	if len(args) > 0 {
+		v, err := parseVersion(args[0])
+		if err != nil {
+			return err
+		}
+		version = v
+	}
+
+	cfg, err := a.controller.GetConfig(context.Background(), version)
+	if err != nil {
+		return err
+	}
+	return a.printConfig(cfg)
+}
+
+func (a *app) configDiff(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("usage: ctl config diff <from> [to]")
+	}
+
+	from, err := parseVersion(args[0])
+	if err != nil {
+		return err
+	}
+	var to int64
+	if len(args) == 2 {
+		if to, err = parseVersion(args[1]); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	fromCfg, err := a.controller.GetConfig(ctx, from)
+	if err != nil {
+		return fmt.Errorf("version %d: %w", from, err)
+	}
+	toCfg, err := a.controller.GetConfig(ctx, to)
+	if err != nil {
+		return fmt.Errorf("version %d: %w", to, err)
+	}
+
+	changes := configdiff.Diff(fromCfg.Data, toCfg.Data)
+	if fromCfg.PollIntervalSeconds != toCfg.PollIntervalSeconds {
+		changes = append(changes, configdiff.Change{
+			Key:      "poll_interval_seconds",
+			Op:       configdiff.OpChanged,
+			OldValue: strconv.Itoa(fromCfg.PollIntervalSeconds),
+			NewValue: strconv.Itoa(toCfg.PollIntervalSeconds),
+		})
+	}
+
+	rows := make([][]string, 0, len(changes))
+	for _, ch := range changes {
+		rows = append(rows, []string{ch.Op, ch.Key, ch.OldValue, ch.NewValue})
+	}
+	if a.printer.format == outputTable {
+		fmt.Fprintf(a.printer.out, "diff v%d -> v%d: %d change(s)\n", fromCfg.Version, toCfg.Version, len(changes))
+	}
+	return a.printer.print(changes, []string{"OP", "KEY", "OLD", "NEW"}, rows)
+}
+
+func (a *app) configRollback(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: ctl config rollback <version>")
+	}
+	version, err := parseVersion(args[0])
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	target, err := a.controller.GetConfig(ctx, version)
+	if err != nil {
+		return fmt.Errorf("version %d: %w", version, err)
+	}
+
+	cfg, err := a.controller.PublishConfig(ctx, &request.UpdateConfigRequest{
+		Data:                target.Data,
+		PollIntervalSeconds: target.PollIntervalSeconds,
+	})
+	if err != nil {
+		return err
+	}
+
+	if a.printer.format == outputTable {
+		fmt.Fprintf(a.printer.out, "rolled back to v%d as new version v%d\n", target.Version, cfg.Version)
+	}
+	return a.printConfig(cfg)
+}
+
+func (a *app) printConfig(cfg *dto.ConfigDTO) error {
+	if a.printer.format == outputJSON {
+		return a.printer.json(cfg)
+	}
+
+	fmt.Fprintf(a.printer.out, "version: %d\nid: %s\npoll_interval_seconds: %d\n\n", cfg.Version, cfg.ID, cfg.PollIntervalSeconds)
+
+	keys := make([]string, 0, len(cfg.Data))
+	for k := range cfg.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := make([][]string, 0, len(keys))
+	for _, k := range keys {
+		rows = append(rows, []string{k, cfg.Data[k]})
+	}
+	return a.printer.print(cfg, []string{"KEY", "VALUE"}, rows)
+}
+
+func readConfigFile(path string) (*request.UpdateConfigRequest, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	format, err := configformat.FromExtension(filepath.Ext(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var doc configformat.ConfigDocument
+	if err := configformat.Unmarshal(format, raw, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(doc.Data) == 0 {
+		return nil, fmt.Errorf("%s: data must not be empty", path)
+	}
+	return &request.UpdateConfigRequest{
+		Data:                doc.StringData(),
+		PollIntervalSeconds: doc.PollIntervalSeconds,
+	}, nil
+}
+
+func parseVersion(s string) (int64, error) {
+	v, err := strconv.ParseInt(strings.TrimPrefix(s, "v"), 10, 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("invalid version %q", s)
+	}
+	return v, nil
+}
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/pkg/hit/batch"
+)
+
+func (a *app) runHit(args []string) error {
+	cmd, rest, err := subcommand(args, "hit")
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "trigger":
+		return a.hitTrigger(rest)
+	case "result":
+		if len(rest) != 1 {
+			return fmt.Errorf("usage: ctl hit result <task-id>")
+		}
+		result, err := a.worker.GetHitResult(context.Background(), rest[0])
+		if err != nil {
+			return err
+		}
+		return a.printHitResult(result)
+	case "jobs":
+		return a.hitJobs()
+	case "schedules":
+		return a.hitSchedules()
+	case "batch":
+		return a.hitBatch(rest)
+	case "batch-status":
+		return a.hitBatchStatus(rest)
+	case "latest":
+		if len(rest) != 1 {
+			return fmt.Errorf("usage: ctl hit latest <job>")
+		}
+		result, err := a.worker.GetJobResult(context.Background(), rest[0])
+		if err != nil {
+			return err
+		}
+		return a.printHitResult(result)
+	default:
+		return fmt.Errorf("unknown hit subcommand %q", cmd)
+	}
+}
+
+func (a *app) hitTrigger(args []string) error {
+	fs := flag.NewFlagSet("hit trigger", flag.ContinueOnError)
+	wait := fs.Bool("wait", false, "wait for the hit to finish and print its result")
+	timeout := fs.Duration("timeout", 60*time.Second, "how long to wait for the result")
+	interval := fs.Duration("interval", time.Second, "result polling interval")
+	job := fs.String("job", "", "trigger only this job (default: all jobs)")
+	method := fs.String("X", "", "override the request method")
+	headers := pairFlag{sep: ":"}
+	fs.Var(&headers, "H", "add or override a request header (Name: value, repeatable)")
+	query := pairFlag{sep: "="}
+	fs.Var(&query, "q", "add or override a query parameter (key=value, repeatable)")
+	body := fs.String("d", "", "override the request body (text, JSON, or key=value&... for form bodies)")
+	bodyType := fs.String("body-type", "", "override the body type: raw, json or form")
+	contentType := fs.String("content-type", "", "override the Content-Type")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	req, err := hitRequest(fs, *method, headers.values, query.values, *body, *bodyType, *contentType)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var tasks []usecases.EnqueueHitResponse
+	if *job != "" {
+		task, err := a.worker.TriggerJob(ctx, *job, req)
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, *task)
+	} else {
+		triggered, err := a.worker.TriggerHit(ctx, req)
+		if err != nil {
+			return err
+		}
+		tasks = triggered.Tasks
+	}
+	if !*wait {
+		rows := make([][]string, 0, len(tasks))
+		for _, task := range tasks {
+			rows = append(rows, []string{task.TaskID, task.Job, task.Status})
+		}
+		return a.printer.print(tasks, []string{"TASK ID", "JOB", "STATUS"}, rows)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	results, err := a.waitForHits(ctx, tasks, *interval)
+	if err != nil {
+		return err
+	}
+	if len(results) == 1 {
+		return a.printHitResult(results[0])
+	}
+	return a.printHitResults(results)
+}
+
+func (a *app) waitForHits(ctx context.Context, tasks []usecases.EnqueueHitResponse, interval time.Duration) ([]*usecases.HitResultResponse, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	results := make([]*usecases.HitResultResponse, len(tasks))
+	for {
+		pending := 0
+		for i, task := range tasks {
+			if results[i] != nil {
+				continue
+			}
+			result, err := a.worker.GetHitResult(ctx, task.TaskID)
+			if err != nil {
+				return nil, err
+			}
+			if result.Status == valueobject.TaskStatusCompleted || result.Status == valueobject.TaskStatusFailed {
+				results[i] = result
+				continue
+			}
+			pending++
+		}
+		if pending == 0 {
+			return results, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for %d of %d task(s)", pending, len(tasks))
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *app) hitJobs() error {
+	jobs, err := a.worker.ListJobs(context.Background())
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(jobs))
+	for _, job := range jobs {
+		status, completed := "-", "-"
+		if job.Latest != nil {
+			status = job.Latest.Status
+			if job.Latest.StatusCode > 0 {
+				status += " (" + strconv.Itoa(job.Latest.StatusCode) + ")"
+			}
+			if job.Latest.CompletedAt != nil {
+				completed = job.Latest.CompletedAt.Format(time.RFC3339)
+			}
+		}
+		target := strings.TrimSpace(job.Method + " " + job.URL)
+		if job.Error != "" {
+			target = job.Error
+		}
+		rows = append(rows, []string{job.Name, target, status, completed})
+	}
+	return a.printer.print(jobs, []string{"JOB", "REQUEST", "LAST STATUS", "COMPLETED"}, rows)
+}
+
+func (a *app) hitSchedules() error {
+	schedules, err := a.worker.ListSchedules(context.Background())
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]string, 0, len(schedules))
+	for _, schedule := range schedules {
+		next := "-"
+		if schedule.NextRunAt != nil {
+			next = schedule.NextRunAt.Format(time.RFC3339)
+		}
+		target := strings.TrimSpace(schedule.Method + " " + schedule.URL)
+		if schedule.Error != "" {
+			target = schedule.Error
+		}
+		rows = append(rows, []string{schedule.Job, schedule.Schedule, target, next})
+	}
+	return a.printer.print(schedules, []string{"JOB", "SCHEDULE", "REQUEST", "NEXT RUN"}, rows)
+}
+
+func (a *app) hitBatch(args []string) error {
+	fs := flag.NewFlagSet("hit batch", flag.ContinueOnError)
+	file := fs.String("f", "", "batch file (.json, .csv, .ndjson or .jsonl)")
+	format := fs.String("format", "", "batch format when the extension is ambiguous: json, csv or ndjson")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("-f is required")
+	}
+
+	if *format == "" {
+		detected, err := batch.FormatOf("", *file)
+		if err != nil {
+			return err
+		}
+		*format = detected
+	}
+	f, err := os.Open(*file)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", *file, err)
+	}
+	defer f.Close()
+	hits, err := batch.Decode(*format, f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *file, err)
+	}
+
+	resp, err := a.worker.SubmitBatch(context.Background(), &request.HitBatchRequest{Hits: hits})
+	if err != nil {
+		return err
+	}
+	return a.printer.print(resp, []string{"BATCH ID", "STATUS", "TOTAL", "ENQUEUED", "FAILED"}, [][]string{
+		{resp.BatchID, resp.Status, strconv.Itoa(resp.Total), strconv.Itoa(resp.Enqueued), strconv.Itoa(resp.Failed)},
+	})
+}
+
+func (a *app) hitBatchStatus(args []string) error {
+	fs := flag.NewFlagSet("hit batch-status", flag.ContinueOnError)
+	page := fs.Int("page", 1, "page of task results")
+	perPage := fs.Int("per-page", 50, "task results per page")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: ctl hit batch-status [-page N] [-per-page N] <batch-id>")
+	}
+
+	status, err := a.worker.GetBatch(context.Background(), fs.Arg(0), *page, *perPage)
+	if err != nil {
+		return err
+	}
+	if a.printer.format == outputJSON {
+		return a.printer.json(status)
+	}
+
+	fmt.Fprintf(a.printer.out, "batch %s: %.1f%% done (%d pending, %d completed, %d failed of %d)\n\n",
+		status.BatchID, status.Progress, status.Counts[valueobject.TaskStatusPending],
+		status.Counts[valueobject.TaskStatusCompleted], status.Counts[valueobject.TaskStatusFailed], status.Total)
+
+	rows := make([][]string, 0, len(status.Tasks))
+	for _, task := range status.Tasks {
+		statusCode := "-"
+		if task.StatusCode > 0 {
+			statusCode = strconv.Itoa(task.StatusCode)
+		}
+		rows = append(rows, []string{strconv.Itoa(task.Index), task.TaskID, task.Method + " " + task.URL, task.Status, statusCode, task.Error})
+	}
+	if err := a.printer.print(status, []string{"#", "TASK ID", "REQUEST", "STATUS", "HTTP STATUS", "ERROR"}, rows); err != nil {
+		return err
+	}
+	if status.Pages > 1 {
+		fmt.Fprintf(a.printer.out, "\npage %d of %d\n", status.Page, status.Pages)
+	}
+	return nil
+}
+
+func hitRequest(fs *flag.FlagSet, method string, headers, query map[string]string, body, bodyType, contentType string) (*request.HitRequest, error) {
+	overridden := false
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "X", "H", "q", "d", "body-type", "content-type":
+			overridden = true
+		}
+	})
+	if !overridden {
+		return nil, nil
+	}
+
+	req := &request.HitRequest{
+		Method:      method,
+		Headers:     headers,
+		Query:       query,
+		BodyType:    bodyType,
+		ContentType: contentType,
+	}
+	if body == "" {
+		return req, nil
+	}
+
+	var err error
+	switch bodyType {
+	case valueobject.HitBodyJSON:
+		if !json.Valid([]byte(body)) {
+			return nil, fmt.Errorf("-d is not valid JSON")
+		}
+		req.Body = json.RawMessage(body)
+	case "":
+		if json.Valid([]byte(body)) {
+			req.Body = json.RawMessage(body)
+		} else {
+			req.Body, err = json.Marshal(body)
+		}
+	case valueobject.HitBodyForm:
+		values, parseErr := url.ParseQuery(body)
+		if parseErr != nil {
+			return nil, fmt.Errorf("-d is not a form body: %w", parseErr)
+		}
+		fields := make(map[string]string, len(values))
+		for key := range values {
+			fields[key] = values.Get(key)
+		}
+		req.Body, err = json.Marshal(fields)
+	default:
+		req.Body, err = json.Marshal(body)
+	}
+	return req, err
+}
+
+type pairFlag struct {
+	sep    string
+	values map[string]string
+}
+
+func (f *pairFlag) String() string {
+	return ""
+}
+
+func (f *pairFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, f.sep)
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("expected key%svalue, got %q", f.sep, value)
+	}
+	if f.values == nil {
+		f.values = make(map[string]string)
+	}
+	f.values[key] = strings.TrimSpace(val)
+	return nil
+}
+
+func (a *app) printHitResults(results []*usecases.HitResultResponse) error {
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		statusCode := "-"
+		if result.StatusCode > 0 {
+			statusCode = strconv.Itoa(result.StatusCode)
+		}
+		rows = append(rows, []string{result.TaskID, result.Job, result.Status, statusCode, strconv.Itoa(len(result.Body)), result.Error})
+	}
+	return a.printer.print(results, []string{"TASK ID", "JOB", "STATUS", "HTTP STATUS", "BODY SIZE", "ERROR"}, rows)
+}
+
+func (a *app) printHitResult(result *usecases.HitResultResponse) error {
+	if a.printer.format == outputJSON {
+		return a.printer.json(result)
+	}
+
+	statusCode := "-"
+	if result.StatusCode > 0 {
+		statusCode = strconv.Itoa(result.StatusCode)
+	}
+	if err := a.printer.print(result, []string{"TASK ID", "STATUS", "HTTP STATUS", "BODY SIZE", "ERROR"}, [][]string{
+		{result.TaskID, result.Status, statusCode, strconv.Itoa(len(result.Body)), result.Error},
+	}); err != nil {
+		return err
+	}
+	if len(result.Extracted) > 0 {
+		var extracted bytes.Buffer
+		if err := json.Indent(&extracted, result.Extracted, "", "  "); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.printer.out, "\nextracted:\n%s\n", extracted.String())
+	}
+	if result.ExtractError != "" {
+		fmt.Fprintf(a.printer.out, "\nextract error: %s\n", result.ExtractError)
+	}
+	if result.Body != "" {
+		fmt.Fprintf(a.printer.out, "\n%s\n", result.Body)
+	}
+	return nil
+}
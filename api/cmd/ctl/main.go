@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	controllerclient "github.com/adityawiryaa/api/pkg/controller"
+	workerclient "github.com/adityawiryaa/api/pkg/worker"
+)
+
+type app struct {
+	controller *controllerclient.Client
+	worker     *workerclient.Client
+	printer    *printer
+}
+
+const usage = `Usage: ctl [global flags] <command> [args]
+
+Commands:
+  config publish -f <file> [-poll-interval N]   Publish config from a JSON, YAML or TOML file
+  config show [version]                         Show the latest or a specific config version
+  config diff <from> [to]                       Diff two config versions (to defaults to latest)
+  config rollback <version>                     Republish an earlier version as a new version
+  agents list                                   List registered agents
+  agents command <id> <type> [key=value ...]    Queue a command (reload, drain, undrain, flush, reregister, diagnostics)
+  agents commands <id>                          Show an agent's command history and results
+  hit trigger [-job name] [-wait]               Trigger one job or every job, optionally waiting for results
+        [-timeout 60s] [-X method]              Result wait timeout and method override
+        [-H 'Name: value'] [-q k=v]             Override headers and query parameters
+        [-d body] [-body-type raw|json|form]    Override the body
+        [-content-type type]                    Override the Content-Type
+  hit result <task-id>                          Show a hit result
+  hit jobs                                      List hit jobs with their latest result
+  hit latest <job>                              Show the latest result of a job
+  hit schedules                                 List scheduled jobs and their next run
+  hit batch -f <file> [-format csv|ndjson|json] Submit a batch of hits from a file
+  hit batch-status [-page N] <batch-id>         Show batch progress and per-task results
+  queue stats                                   Show worker queue statistics
+
+Global flags:
+`
+
+func main() {
+	global := flag.NewFlagSet("ctl", flag.ExitOnError)
+	configPath := global.String("config", defaultProfilePath(), "profile file path (env CTL_CONFIG)")
+	profileName := global.String("profile", os.Getenv("CTL_PROFILE"), "profile name (env CTL_PROFILE)")
+	output := global.String("o", outputTable, "output format: table or json")
+	controllerURL := global.String("controller", "", "override the profile controller URL")
+	workerURL := global.String("worker", "", "override the profile worker URL")
+	apiKey := global.String("api-key", "", "override the profile API key")
+	global.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		global.PrintDefaults()
+	}
+	_ = global.Parse(os.Args[1:])
+
+	if global.NArg() < 1 {
+		global.Usage()
+		os.Exit(2)
+	}
+
+	profile, err := loadProfile(*configPath, *profileName)
+	if err != nil {
+		fatal(err)
+	}
+	if *controllerURL != "" {
+		profile.ControllerURL = *controllerURL
+	}
+	if *workerURL != "" {
+		profile.WorkerURL = *workerURL
+	}
+	if *apiKey != "" {
+		profile.APIKey = *apiKey
+	}
+
+	p, err := newPrinter(*output)
+	if err != nil {
+		fatal(err)
+	}
+
+	a := &app{
+		controller: controllerclient.NewClient(profile.ControllerURL, profile.APIKey, profile.Timeout()),
+		worker:     workerclient.NewClient(profile.WorkerURL, profile.Timeout()),
+		printer:    p,
+	}
+
+	args := global.Args()
+	switch args[0] {
+	case "config":
+		err = a.runConfig(args[1:])
+	case "agents":
+		err = a.runAgents(args[1:])
+	case "hit":
+		err = a.runHit(args[1:])
+	case "queue":
+		err = a.runQueue(args[1:])
+	default:
+		global.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	os.Exit(1)
+}
+
+func subcommand(args []string, name string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("missing %s subcommand, see ctl -h", name)
+	}
+	return args[0], args[1:], nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+type printer struct {
+	format string
+	out    io.Writer
+}
+
+func newPrinter(format string) (*printer, error) {
+	if format != outputTable && format != outputJSON {
+		return nil, fmt.Errorf("invalid output format %q: expected table or json", format)
+	}
+	return &printer{format: format, out: os.Stdout}, nil
+}
+
+func (p *printer) json(v any) error {
+	enc := json.NewEncoder(p.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (p *printer) print(v any, header []string, rows [][]string) error {
+	if p.format == outputJSON {
+		return p.json(v)
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+	for i, h := range header {
+		if i > 0 {
+			fmt.Fprint(w, "\t")
+		}
+		fmt.Fprint(w, h)
+	}
+	fmt.Fprintln(w)
+	for _, row := range rows {
+		for i, col := range row {
+			if i > 0 {
+				fmt.Fprint(w, "\t")
+			}
+			fmt.Fprint(w, col)
+		}
+		fmt.Fprintln(w)
+	}
+	return w.Flush()
+}
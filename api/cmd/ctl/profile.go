@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+type Profile struct {
+	ControllerURL  string `yaml:"controller_url"`
+	WorkerURL      string `yaml:"worker_url"`
+	APIKey         string `yaml:"api_key"`
+	TimeoutSeconds int    `yaml:"timeout_seconds"`
+}
+
+type ProfileFile struct {
+	CurrentProfile string              `yaml:"current_profile"`
+	Profiles       map[string]*Profile `yaml:"profiles"`
+}
+
+func defaultProfilePath() string {
+	if p := os.Getenv("CTL_CONFIG"); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "ctl.yaml"
+	}
+	return filepath.Join(dir, "ctl", "config.yaml")
+}
+
+func defaultProfile() *Profile {
+	apiKey := os.Getenv("API_KEY")
+	if apiKey == "" {
+		apiKey = "default-api-key"
+	}
+	return &Profile{
+		ControllerURL:  "http://localhost:6001",
+		WorkerURL:      "http://localhost:6002",
+		APIKey:         apiKey,
+		TimeoutSeconds: 10,
+	}
+}
+
+func loadProfile(path string, name string) (*Profile, error) {
+	profile := defaultProfile()
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if name != "" && name != "default" {
+			return nil, fmt.Errorf("profile %q not found: %s does not exist", name, path)
+		}
+		return profile, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var file ProfileFile
+	if err := yaml.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if name == "" {
+		name = file.CurrentProfile
+	}
+	if name == "" {
+		name = "default"
+	}
+
+	p, ok := file.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in %s", name, path)
+	}
+
+	if p.ControllerURL != "" {
+		profile.ControllerURL = p.ControllerURL
+	}
+	if p.WorkerURL != "" {
+		profile.WorkerURL = p.WorkerURL
+	}
+	if p.APIKey != "" {
+		profile.APIKey = p.APIKey
+	}
+	if p.TimeoutSeconds > 0 {
+		profile.TimeoutSeconds = p.TimeoutSeconds
+	}
+	return profile, nil
+}
+
+func (p *Profile) Timeout() time.Duration {
+	return time.Duration(p.TimeoutSeconds) * time.Second
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+func (a *app) runQueue(args []string) error {
+	cmd, _, err := subcommand(args, "queue")
+	if err != nil {
+		return err
+	}
+	if cmd != "stats" {
+		return fmt.Errorf("unknown queue subcommand %q", cmd)
+	}
+
+	stats, err := a.worker.QueueStats(context.Background())
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{
+		{"queue", stats.Queue},
+		{"size", strconv.Itoa(stats.Size)},
+		{"pending", strconv.Itoa(stats.Pending)},
+		{"active", strconv.Itoa(stats.Active)},
+		{"scheduled", strconv.Itoa(stats.Scheduled)},
+		{"retry", strconv.Itoa(stats.Retry)},
+		{"archived", strconv.Itoa(stats.Archived)},
+		{"completed", strconv.Itoa(stats.Completed)},
+		{"processed_today", strconv.Itoa(stats.ProcessedToday)},
+		{"failed_today", strconv.Itoa(stats.FailedToday)},
+		{"latency_ms", strconv.FormatInt(stats.LatencyMS, 10)},
+		{"paused", strconv.FormatBool(stats.Paused)},
+	}
+	return a.printer.print(stats, []string{"METRIC", "VALUE"}, rows)
+}
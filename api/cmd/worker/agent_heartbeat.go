@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	agentclient "github.com/adityawiryaa/api/pkg/agent"
+)
+
+func runAgentHeartbeat(ctx context.Context, client *agentclient.Client, advertiseURL string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	registered := false
+	for {
+		if err := client.RegisterWorker(ctx, advertiseURL); err != nil {
+			if ctx.Err() == nil {
+				log.Printf("[agent] heartbeat failed: %v", err)
+			}
+			registered = false
+		} else if !registered {
+			log.Printf("[agent] registered as %s", advertiseURL)
+			registered = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func deregisterFromAgent(client *agentclient.Client, advertiseURL string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.DeregisterWorker(ctx, advertiseURL); err != nil {
+		log.Printf("[shutdown] agent deregistration failed: %v", err)
+	}
+}
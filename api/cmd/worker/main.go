@@ -15,12 +15,16 @@ import (
 	delivery "github.com/adityawiryaa/api/internal/delivery/http/worker"
 	"github.com/adityawiryaa/api/internal/repository/memory"
 	workeruc "github.com/adityawiryaa/api/internal/usecases/worker"
+	agentclient "github.com/adityawiryaa/api/pkg/agent"
 	"github.com/adityawiryaa/api/pkg/cache"
 	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
 )
 
 func main() {
-	cfg := config.LoadWorkerConfig()
+	cfg, err := config.LoadWorkerConfig(os.Args[1:])
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
+	}
 
 	log.Printf("[init] loading config: port=%s redis=%s", cfg.Port, cfg.Redis.Addr())
 
@@ -32,12 +36,14 @@ func main() {
 
 	queueClient := hitqueue.NewClient(cfg.Redis.Addr(), cfg.Redis.AsynqDB)
 	resultStore := hitqueue.NewResultStore(rdb)
+	inspector := hitqueue.NewInspector(cfg.Redis.Addr(), cfg.Redis.AsynqDB)
+	scheduler := hitqueue.NewScheduler(cfg.Redis.Addr(), cfg.Redis.AsynqDB, cfg.ScheduleLocation)
 
-	commandUC := workeruc.NewCommandUsecase(executor, store, queueClient)
-	queryUC := workeruc.NewQueryUsecase(store, resultStore)
+	commandUC := workeruc.NewCommandUsecase(executor, store, queueClient, scheduler, resultStore)
+	queryUC := workeruc.NewQueryUsecase(store, resultStore, inspector, scheduler, resultStore)
 
 	handler := delivery.NewHandler(commandUC, queryUC)
-	router := delivery.SetupRouter(handler, cfg.APIKey)
+	router := delivery.SetupRouter(handler, cfg.APIKey, cfg.Compression)
 
 	srv := &http.Server{
 		Addr:    ":" + cfg.Port,
@@ -67,12 +73,48 @@ func main() {
 		}
 	}()
 
+	log.Printf("[scheduler] starting: timezone=%s", cfg.ScheduleLocation)
+	if err := scheduler.Start(); err != nil {
+		log.Fatalf("[scheduler] start error: %v", err)
+	}
+
+	heartbeatCtx, stopHeartbeat := context.WithCancel(context.Background())
+	var agentClient *agentclient.Client
+	if cfg.AgentURL != "" {
+		agentClient = agentclient.NewClient(cfg.AgentURL, 5*time.Second)
+		agentClient.SetCompression(cfg.Compression)
+		log.Printf("[agent] self-registering with %s as %s every %s", cfg.AgentURL, cfg.AdvertiseURL, cfg.HeartbeatInterval)
+		heartbeatInterval := cfg.HeartbeatInterval
+		loopCtx, stopLoop := context.WithCancel(heartbeatCtx)
+		go runAgentHeartbeat(loopCtx, agentClient, cfg.AdvertiseURL, heartbeatInterval)
+
+		go config.ReloadOnHangup(heartbeatCtx, cfg, loadConfig, (*config.WorkerConfig).RestartRequired, func(next *config.WorkerConfig) {
+			if next.HeartbeatInterval != heartbeatInterval {
+				stopLoop()
+				heartbeatInterval = next.HeartbeatInterval
+				loopCtx, stopLoop = context.WithCancel(heartbeatCtx)
+				go runAgentHeartbeat(loopCtx, agentClient, cfg.AdvertiseURL, heartbeatInterval)
+			}
+			log.Printf("config reload: applied (heartbeat: %s)", next.HeartbeatInterval)
+		})
+	} else {
+		go config.ReloadOnHangup(heartbeatCtx, cfg, loadConfig, (*config.WorkerConfig).RestartRequired, func(*config.WorkerConfig) {
+			log.Println("config reload: applied")
+		})
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("[shutdown] signal received, shutting down...")
 
+	stopHeartbeat()
+	if agentClient != nil {
+		log.Println("[shutdown] deregistering from agent...")
+		deregisterFromAgent(agentClient, cfg.AdvertiseURL)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -81,12 +123,20 @@ func main() {
 		log.Printf("[shutdown] http server error: %v", err)
 	}
 
+	log.Println("[shutdown] stopping scheduler...")
+	scheduler.Shutdown()
+
 	log.Println("[shutdown] stopping asynq worker...")
 	asynqSrv.Shutdown()
 
 	log.Println("[shutdown] closing redis connections...")
 	_ = queueClient.Close()
+	_ = inspector.Close()
 	_ = rdb.Close()
 
 	log.Println("[shutdown] worker exited")
 }
+
+func loadConfig() (*config.WorkerConfig, error) {
+	return config.LoadWorkerConfig(os.Args[1:])
+}
@@ -1,11 +1,12 @@
 package dto
 
 type AgentDTO struct {
-	ID        string `json:"id"`
-	Hostname  string `json:"hostname"`
-	IPAddress string `json:"ip_address"`
-	Port      int    `json:"port"`
-	Status    string `json:"status"`
+	ID        string          `json:"id"`
+	Hostname  string          `json:"hostname"`
+	IPAddress string          `json:"ip_address"`
+	Port      int             `json:"port"`
+	Status    string          `json:"status"`
+	Report    *AgentReportDTO `json:"report,omitempty"`
 }
 
 type RegistrationResponseDTO struct {
@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+type AgentStatusDTO struct {
+	Ready        bool                  `json:"ready"`
+	Registration RegistrationStatusDTO `json:"registration"`
+	Config       *ConfigDTO            `json:"config"`
+	Overrides    map[string]string     `json:"overrides,omitempty"`
+	Poll         PollStatusDTO         `json:"poll"`
+	Workers      []WorkerStatusDTO     `json:"workers"`
+	Hooks        []HookStatusDTO       `json:"hooks"`
+	RecentErrors []AgentErrorDTO       `json:"recent_errors"`
+}
+
+type RegistrationStatusDTO struct {
+	AgentID      string     `json:"agent_id,omitempty"`
+	Registered   bool       `json:"registered"`
+	RegisteredAt *time.Time `json:"registered_at,omitempty"`
+	Attempts     int        `json:"attempts"`
+	LastError    string     `json:"last_error,omitempty"`
+}
+
+type PollStatusDTO struct {
+	IntervalSeconds int        `json:"interval_seconds"`
+	LastPollAt      *time.Time `json:"last_poll_at,omitempty"`
+	LastResult      string     `json:"last_result,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+	LastChangeAt    *time.Time `json:"last_change_at,omitempty"`
+	Polls           int64      `json:"polls"`
+	Failures        int64      `json:"failures"`
+}
+
+type HookStatusDTO struct {
+	Name                string     `json:"name"`
+	Type                string     `json:"type"`
+	LastVersion         int64      `json:"last_version"`
+	AppliedVersion      int64      `json:"applied_version"`
+	LastRunAt           *time.Time `json:"last_run_at,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	Attempts            int        `json:"attempts"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+}
+
+type AgentErrorDTO struct {
+	Source  string    `json:"source"`
+	Message string    `json:"message"`
+	At      time.Time `json:"at"`
+}
+
+type ReadinessDTO struct {
+	Ready  bool     `json:"ready"`
+	Checks []string `json:"failed_checks,omitempty"`
+}
@@ -1,8 +1,28 @@
 package dto
 
+import "encoding/json"
+
 type ConfigDTO struct {
 	ID                  string            `json:"id"`
 	Version             int64             `json:"version"`
 	Data                map[string]string `json:"data"`
 	PollIntervalSeconds int               `json:"poll_interval_seconds"`
+	Origin              string            `json:"origin,omitempty"`
+}
+
+type ConfigPatchDTO struct {
+	ID                  string          `json:"id"`
+	BaseVersion         int64           `json:"base_version"`
+	Version             int64           `json:"version"`
+	PollIntervalSeconds int             `json:"poll_interval_seconds"`
+	Origin              string          `json:"origin,omitempty"`
+	Hash                string          `json:"hash"`
+	Patch               json.RawMessage `json:"patch"`
+}
+
+type ImportResultDTO struct {
+	Mode            string `json:"mode"`
+	ConfigsImported int    `json:"configs_imported"`
+	AgentsImported  int    `json:"agents_imported"`
+	LatestVersion   int64  `json:"latest_version"`
 }
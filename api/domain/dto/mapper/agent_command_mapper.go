@@ -0,0 +1,29 @@
+package mapper
+
+import (
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+func ToAgentCommandDTO(cmd *entity.AgentCommand) dto.AgentCommandDTO {
+	return dto.AgentCommandDTO{
+		ID:          cmd.ID,
+		AgentID:     cmd.AgentID,
+		Type:        cmd.Type,
+		Args:        cmd.Args,
+		Status:      cmd.Status,
+		Result:      cmd.Result,
+		Error:       cmd.Error,
+		CreatedAt:   cmd.CreatedAt,
+		DeliveredAt: optionalTime(cmd.DeliveredAt),
+		CompletedAt: optionalTime(cmd.CompletedAt),
+	}
+}
+
+func ToAgentCommandDTOs(cmds []*entity.AgentCommand) []dto.AgentCommandDTO {
+	result := make([]dto.AgentCommandDTO, 0, len(cmds))
+	for _, cmd := range cmds {
+		result = append(result, ToAgentCommandDTO(cmd))
+	}
+	return result
+}
@@ -6,13 +6,18 @@ import (
 )
 
 func ToAgentDTO(agent *entity.Agent) dto.AgentDTO {
-	return dto.AgentDTO{
+	result := dto.AgentDTO{
 		ID:        agent.ID,
 		Hostname:  agent.Hostname,
 		IPAddress: agent.IPAddress,
 		Port:      agent.Port,
 		Status:    agent.Status,
 	}
+	if agent.Report != nil {
+		report := ToAgentReportDTO(agent.Report)
+		result.Report = &report
+	}
+	return result
 }
 
 func ToRegistrationResponseDTO(agent *entity.Agent, pollIntervalSeconds int) dto.RegistrationResponseDTO {
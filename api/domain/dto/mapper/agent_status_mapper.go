@@ -0,0 +1,58 @@
+package mapper
+
+import (
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+func ToRegistrationStatusDTO(status entity.RegistrationStatus) dto.RegistrationStatusDTO {
+	return dto.RegistrationStatusDTO{
+		AgentID:      status.AgentID,
+		Registered:   status.Registered,
+		RegisteredAt: optionalTime(status.RegisteredAt),
+		Attempts:     status.Attempts,
+		LastError:    status.LastError,
+	}
+}
+
+func ToPollStatusDTO(status entity.PollStatus) dto.PollStatusDTO {
+	return dto.PollStatusDTO{
+		IntervalSeconds: int(status.Interval.Seconds()),
+		LastPollAt:      optionalTime(status.LastPollAt),
+		LastResult:      status.LastResult,
+		LastError:       status.LastError,
+		LastChangeAt:    optionalTime(status.LastChangeAt),
+		Polls:           status.Polls,
+		Failures:        status.Failures,
+	}
+}
+
+func ToHookStatusDTOs(statuses []entity.HookStatus) []dto.HookStatusDTO {
+	result := make([]dto.HookStatusDTO, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, dto.HookStatusDTO{
+			Name:                status.Name,
+			Type:                status.Type,
+			LastVersion:         status.LastVersion,
+			AppliedVersion:      status.AppliedVersion,
+			LastRunAt:           optionalTime(status.LastRunAt),
+			LastSuccessAt:       optionalTime(status.LastSuccessAt),
+			Attempts:            status.Attempts,
+			ConsecutiveFailures: status.ConsecutiveFailures,
+			LastError:           status.LastError,
+		})
+	}
+	return result
+}
+
+func ToAgentErrorDTOs(errs []entity.AgentError) []dto.AgentErrorDTO {
+	result := make([]dto.AgentErrorDTO, 0, len(errs))
+	for _, e := range errs {
+		result = append(result, dto.AgentErrorDTO{
+			Source:  e.Source,
+			Message: e.Message,
+			At:      e.At,
+		})
+	}
+	return result
+}
@@ -11,5 +11,6 @@ func ToConfigDTO(cfg *entity.Config) dto.ConfigDTO {
 		Version:             cfg.Version,
 		Data:                cfg.Data,
 		PollIntervalSeconds: cfg.PollIntervalSeconds,
+		Origin:              cfg.Origin,
 	}
 }
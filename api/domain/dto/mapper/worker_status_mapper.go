@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+func ToWorkerStatusDTO(status entity.WorkerStatus) dto.WorkerStatusDTO {
+	return dto.WorkerStatusDTO{
+		URL:                 status.URL,
+		Source:              status.Source,
+		Drained:             status.Drained,
+		Healthy:             status.Healthy,
+		AppliedVersion:      status.AppliedVersion,
+		InSync:              status.InSync,
+		LastSeenAt:          optionalTime(status.LastSeenAt),
+		LastCheckedAt:       optionalTime(status.LastCheckedAt),
+		LastSyncedAt:        optionalTime(status.LastSyncedAt),
+		ConsecutiveFailures: status.ConsecutiveFailures,
+		LastError:           status.LastError,
+		NextRetryAt:         optionalTime(status.NextRetryAt),
+	}
+}
+
+func ToAgentReportDTO(report *entity.AgentReport) dto.AgentReportDTO {
+	workers := make([]dto.WorkerStatusDTO, 0, len(report.Workers))
+	for _, status := range report.Workers {
+		workers = append(workers, ToWorkerStatusDTO(status))
+	}
+	result := dto.AgentReportDTO{
+		ConfigVersion: report.ConfigVersion,
+		Workers:       workers,
+		Overrides:     report.Overrides,
+		ReportedAt:    report.ReportedAt,
+	}
+	if len(report.Hooks) > 0 {
+		result.Hooks = ToHookStatusDTOs(report.Hooks)
+	}
+	for _, agent := range report.Downstream {
+		result.Downstream = append(result.Downstream, ToDownstreamAgentDTO(agent))
+	}
+	return result
+}
+
+func ToDownstreamAgentDTO(agent entity.DownstreamAgent) dto.DownstreamAgentDTO {
+	return dto.DownstreamAgentDTO{
+		ID:             agent.ID,
+		Relay:          agent.Relay,
+		Hostname:       agent.Hostname,
+		IPAddress:      agent.IPAddress,
+		Port:           agent.Port,
+		Status:         agent.Status,
+		ConfigVersion:  agent.ConfigVersion,
+		Workers:        agent.Workers,
+		HealthyWorkers: agent.HealthyWorkers,
+		ReportedAt:     optionalTime(agent.ReportedAt),
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+type WorkerStatusDTO struct {
+	URL                 string     `json:"url"`
+	Source              string     `json:"source"`
+	Drained             bool       `json:"drained"`
+	Healthy             bool       `json:"healthy"`
+	AppliedVersion      int64      `json:"applied_version"`
+	InSync              bool       `json:"in_sync"`
+	LastSeenAt          *time.Time `json:"last_seen_at,omitempty"`
+	LastCheckedAt       *time.Time `json:"last_checked_at,omitempty"`
+	LastSyncedAt        *time.Time `json:"last_synced_at,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastError           string     `json:"last_error,omitempty"`
+	NextRetryAt         *time.Time `json:"next_retry_at,omitempty"`
+}
+
+type AgentReportDTO struct {
+	ConfigVersion int64                `json:"config_version"`
+	Workers       []WorkerStatusDTO    `json:"workers"`
+	Hooks         []HookStatusDTO      `json:"hooks,omitempty"`
+	Overrides     []string             `json:"overrides,omitempty"`
+	Downstream    []DownstreamAgentDTO `json:"downstream,omitempty"`
+	ReportedAt    time.Time            `json:"reported_at"`
+}
+
+type DownstreamAgentDTO struct {
+	ID             string     `json:"id"`
+	Relay          string     `json:"relay"`
+	Hostname       string     `json:"hostname"`
+	IPAddress      string     `json:"ip_address"`
+	Port           int        `json:"port"`
+	Status         string     `json:"status"`
+	ConfigVersion  int64      `json:"config_version"`
+	Workers        int        `json:"workers"`
+	HealthyWorkers int        `json:"healthy_workers"`
+	ReportedAt     *time.Time `json:"reported_at,omitempty"`
+}
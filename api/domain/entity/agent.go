@@ -3,11 +3,12 @@ package entity
 import "time"
 
 type Agent struct {
-	ID        string    `json:"id"`
-	Hostname  string    `json:"hostname"`
-	IPAddress string    `json:"ip_address"`
-	Port      int       `json:"port"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string       `json:"id"`
+	Hostname  string       `json:"hostname"`
+	IPAddress string       `json:"ip_address"`
+	Port      int          `json:"port"`
+	Status    string       `json:"status"`
+	Report    *AgentReport `json:"report,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
 }
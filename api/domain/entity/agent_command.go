@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AgentCommand struct {
+	ID          string            `json:"id"`
+	AgentID     string            `json:"agent_id"`
+	Type        string            `json:"type"`
+	Args        map[string]string `json:"args,omitempty"`
+	Status      string            `json:"status"`
+	Result      json.RawMessage   `json:"result,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	DeliveredAt time.Time         `json:"delivered_at"`
+	CompletedAt time.Time         `json:"completed_at"`
+}
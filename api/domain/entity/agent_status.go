@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+type RegistrationStatus struct {
+	AgentID      string    `json:"agent_id"`
+	Registered   bool      `json:"registered"`
+	RegisteredAt time.Time `json:"registered_at"`
+	Attempts     int       `json:"attempts"`
+	LastError    string    `json:"last_error,omitempty"`
+}
+
+type PollStatus struct {
+	Interval     time.Duration `json:"interval"`
+	LastPollAt   time.Time     `json:"last_poll_at"`
+	LastResult   string        `json:"last_result"`
+	LastError    string        `json:"last_error,omitempty"`
+	LastChangeAt time.Time     `json:"last_change_at"`
+	Polls        int64         `json:"polls"`
+	Failures     int64         `json:"failures"`
+}
+
+type AgentError struct {
+	Source  string    `json:"source"`
+	Message string    `json:"message"`
+	At      time.Time `json:"at"`
+}
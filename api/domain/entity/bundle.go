@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+const BundleFormatVersion = 1
+
+type ConfigBundle struct {
+	FormatVersion int       `json:"format_version"`
+	SchemaVersion int64     `json:"schema_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+	Configs       []*Config `json:"configs"`
+	Agents        []*Agent  `json:"agents"`
+}
+
+const AgentBundleFormatVersion = 1
+
+type BundleTarget struct {
+	AgentID  string `json:"agent_id,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+	Selector string `json:"selector,omitempty"`
+}
+
+type AgentBundle struct {
+	FormatVersion int          `json:"format_version"`
+	IssuedAt      time.Time    `json:"issued_at"`
+	Target        BundleTarget `json:"target"`
+	Config        *Config      `json:"config"`
+}
+
+type SignedBundle struct {
+	KeyID     string `json:"key_id"`
+	Payload   []byte `json:"payload"`
+	Signature []byte `json:"signature"`
+}
+
+type BundleFile struct {
+	Name string
+	Data []byte
+}
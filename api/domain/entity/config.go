@@ -1,11 +1,32 @@
 package entity
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type Config struct {
 	ID                  string            `json:"id"`
 	Version             int64             `json:"version"`
 	Data                map[string]string `json:"data"`
 	PollIntervalSeconds int               `json:"poll_interval_seconds"`
+	Origin              string            `json:"origin,omitempty"`
 	CreatedAt           time.Time         `json:"created_at"`
 }
+
+type ConfigPatch struct {
+	ID                  string          `json:"id"`
+	BaseVersion         int64           `json:"base_version"`
+	Version             int64           `json:"version"`
+	PollIntervalSeconds int             `json:"poll_interval_seconds"`
+	Hash                string          `json:"hash"`
+	Patch               json.RawMessage `json:"patch"`
+}
+
+type ConfigFetch struct {
+	Config              *Config
+	Patch               *ConfigPatch
+	Changed             bool
+	PollIntervalSeconds int
+	PendingCommands     int
+}
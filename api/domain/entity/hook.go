@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type HookDefinition struct {
+	Name                string            `json:"name"`
+	Command             string            `json:"command"`
+	URL                 string            `json:"url"`
+	Headers             map[string]string `json:"headers"`
+	TimeoutSeconds      int               `json:"timeout_seconds"`
+	Retries             int               `json:"retries"`
+	RetryBackoffSeconds int               `json:"retry_backoff_seconds"`
+}
+
+type HookStatus struct {
+	Name                string    `json:"name"`
+	Type                string    `json:"type"`
+	LastVersion         int64     `json:"last_version"`
+	AppliedVersion      int64     `json:"applied_version"`
+	LastRunAt           time.Time `json:"last_run_at"`
+	LastSuccessAt       time.Time `json:"last_success_at"`
+	Attempts            int       `json:"attempts"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+}
@@ -10,3 +10,8 @@ type RegistrationResponse struct {
 	AgentID string `json:"agent_id"`
 	Status  string `json:"status"`
 }
+
+type AgentIdentity struct {
+	Hostname string
+	Labels   map[string]string
+}
@@ -0,0 +1,9 @@
+package entity
+
+type TemplateResource struct {
+	Src       string `json:"src"`
+	Dest      string `json:"dest"`
+	Mode      string `json:"mode"`
+	CheckCmd  string `json:"check_cmd"`
+	ReloadCmd string `json:"reload_cmd"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+type WorkerStatus struct {
+	URL                 string    `json:"url"`
+	Source              string    `json:"source"`
+	Drained             bool      `json:"drained"`
+	Healthy             bool      `json:"healthy"`
+	AppliedVersion      int64     `json:"applied_version"`
+	InSync              bool      `json:"in_sync"`
+	LastSeenAt          time.Time `json:"last_seen_at"`
+	LastCheckedAt       time.Time `json:"last_checked_at"`
+	LastSyncedAt        time.Time `json:"last_synced_at"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+	NextRetryAt         time.Time `json:"next_retry_at"`
+}
+
+type WorkerEndpoint struct {
+	URL          string    `json:"url"`
+	Source       string    `json:"source"`
+	Drained      bool      `json:"drained"`
+	RegisteredAt time.Time `json:"registered_at"`
+	LastSeenAt   time.Time `json:"last_seen_at"`
+}
+
+type AgentReport struct {
+	ConfigVersion int64             `json:"config_version"`
+	Workers       []WorkerStatus    `json:"workers"`
+	Hooks         []HookStatus      `json:"hooks,omitempty"`
+	Overrides     []string          `json:"overrides,omitempty"`
+	Downstream    []DownstreamAgent `json:"downstream,omitempty"`
+	ReportedAt    time.Time         `json:"reported_at"`
+}
+
+type DownstreamAgent struct {
+	ID             string    `json:"id"`
+	Relay          string    `json:"relay"`
+	Hostname       string    `json:"hostname"`
+	IPAddress      string    `json:"ip_address"`
+	Port           int       `json:"port"`
+	Status         string    `json:"status"`
+	ConfigVersion  int64     `json:"config_version"`
+	Workers        int       `json:"workers"`
+	HealthyWorkers int       `json:"healthy_workers"`
+	ReportedAt     time.Time `json:"reported_at"`
+}
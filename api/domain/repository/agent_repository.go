@@ -7,8 +7,10 @@ import (
 
 type AgentRepositoryCommand interface {
 	Save(ctx context.Context, agent *entity.Agent) error
+	SaveReport(ctx context.Context, id string, report *entity.AgentReport) error
 }
 
 type AgentRepositoryQuery interface {
 	FindByID(ctx context.Context, id string) (*entity.Agent, error)
+	ListAgents(ctx context.Context) ([]*entity.Agent, error)
 }
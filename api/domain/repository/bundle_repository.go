@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+type BundleRepositoryCommand interface {
+	Import(ctx context.Context, configs []*entity.Config, agents []*entity.Agent) error
+}
+
+type BundleRepositoryQuery interface {
+	SchemaVersion(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+type CommandQueueRepositoryCommand interface {
+	Enqueue(ctx context.Context, cmd *entity.AgentCommand) error
+	MarkDelivered(ctx context.Context, agentID string, at time.Time) error
+	Complete(ctx context.Context, cmd *entity.AgentCommand) error
+}
+
+type CommandQueueRepositoryQuery interface {
+	FindByID(ctx context.Context, agentID, id string) (*entity.AgentCommand, error)
+	ListByAgent(ctx context.Context, agentID, status string) ([]*entity.AgentCommand, error)
+	CountOutstanding(ctx context.Context, agentID string) (int, error)
+}
@@ -12,4 +12,5 @@ type ConfigRepositoryCommand interface {
 type ConfigRepositoryQuery interface {
 	GetLatestConfig(ctx context.Context) (*entity.Config, error)
 	GetConfigByVersion(ctx context.Context, version int64) (*entity.Config, error)
+	ListConfigs(ctx context.Context) ([]*entity.Config, error)
 }
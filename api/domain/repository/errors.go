@@ -0,0 +1,8 @@
+package repository
+
+import "errors"
+
+var (
+	ErrNotFound = errors.New("record not found")
+	ErrReadOnly = errors.New("repository is read-only")
+)
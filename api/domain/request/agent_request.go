@@ -1,7 +1,36 @@
 package request
 
+import (
+	"encoding/json"
+
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
 type RegisterAgentRequest struct {
 	Hostname  string `json:"hostname" binding:"required"`
 	IPAddress string `json:"ip_address" binding:"required"`
 	Port      int    `json:"port" binding:"required"`
 }
+
+type RegisterWorkerRequest struct {
+	URL string `json:"url" binding:"required"`
+}
+
+type AgentReportRequest struct {
+	ConfigVersion int64                    `json:"config_version"`
+	Workers       []entity.WorkerStatus    `json:"workers"`
+	Hooks         []entity.HookStatus      `json:"hooks"`
+	Overrides     []string                 `json:"overrides"`
+	Downstream    []entity.DownstreamAgent `json:"downstream,omitempty"`
+}
+
+type EnqueueCommandRequest struct {
+	Type string            `json:"type" binding:"required"`
+	Args map[string]string `json:"args"`
+}
+
+type CommandResultRequest struct {
+	Status string          `json:"status" binding:"required,oneof=succeeded failed"`
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+}
@@ -0,0 +1,10 @@
+package request
+
+type BatchHitSpec struct {
+	URL string `json:"url"`
+	HitRequest
+}
+
+type HitBatchRequest struct {
+	Hits []BatchHitSpec `json:"hits"`
+}
@@ -0,0 +1,12 @@
+package request
+
+import "encoding/json"
+
+type HitRequest struct {
+	Method      string            `json:"method"`
+	Headers     map[string]string `json:"headers"`
+	Query       map[string]string `json:"query"`
+	Body        json.RawMessage   `json:"body"`
+	BodyType    string            `json:"body_type"`
+	ContentType string            `json:"content_type"`
+}
@@ -5,23 +5,75 @@ import (
 	"time"
 	"github.com/adityawiryaa/api/domain/dto"
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/pkg/backoff"
 )
 
 type ControllerClient interface {
 	Register(ctx context.Context, req *entity.RegistrationRequest) (*entity.RegistrationResponse, error)
-	FetchConfig(ctx context.Context, currentVersion int64) (*entity.Config, bool, error)
+	FetchConfig(ctx context.Context, agentID string, currentVersion int64) (*entity.ConfigFetch, error)
+	Report(ctx context.Context, agentID string, req *request.AgentReportRequest) error
+	ClaimCommands(ctx context.Context, agentID string) ([]entity.AgentCommand, error)
+	CompleteCommand(ctx context.Context, agentID, commandID string, req *request.CommandResultRequest) error
 }
 
 type WorkerClient interface {
+	BaseURL() string
 	PushConfig(ctx context.Context, cfg *entity.Config) error
+	ConfigVersion(ctx context.Context) (int64, error)
+}
+
+type ConfigCache interface {
+	Load() (*entity.Config, error)
+	Save(cfg *entity.Config) error
+}
+
+type TemplateRenderer interface {
+	Render(ctx context.Context, cfg *entity.Config) error
+}
+
+type HookRunner interface {
+	Hooks() []entity.HookDefinition
+	Run(ctx context.Context, hook entity.HookDefinition, cfg *entity.Config) error
+}
+
+type OverrideSource interface {
+	Load() (map[string]string, error)
+}
+
+type BundleInbox interface {
+	Pending() ([]entity.BundleFile, error)
+	Accept(name string) error
+	Reject(name string) error
+}
+
+type BundleVerifier interface {
+	Verify(keyID string, payload, signature []byte) error
 }
 
 type UsecaseAgentCommand interface {
 	RegisterWithController(ctx context.Context, req *entity.RegistrationRequest) (*dto.RegistrationResponseDTO, error)
-	ForwardConfigToWorker(ctx context.Context) error
+	MaintainRegistration(ctx context.Context, req *entity.RegistrationRequest)
+	ForwardConfigToWorkers(ctx context.Context) error
+	RenderTemplates(ctx context.Context) error
+	RunHooks(ctx context.Context) error
+	RefreshOverrides(ctx context.Context) error
+	ImportBundles(ctx context.Context) error
+	ApplyConfig(ctx context.Context) error
+	RestoreFromCache(ctx context.Context) (*entity.Config, error)
+	ReconcileWorkers(ctx context.Context) error
+	StartReconciling(ctx context.Context, interval time.Duration)
+	RegisterWorker(ctx context.Context, req *request.RegisterWorkerRequest) (*dto.WorkerStatusDTO, error)
+	DeregisterWorker(ctx context.Context, url string) error
+	ReportToController(ctx context.Context) error
+	ExecuteCommands(ctx context.Context) error
 }
 
 type UsecaseAgentQuery interface {
-	PollConfig(ctx context.Context) (int, error)
-	StartPolling(ctx context.Context, initialInterval time.Duration, forwardFunc func(context.Context) error)
+	PollConfig(ctx context.Context) (*entity.ConfigFetch, error)
+	StartPolling(ctx context.Context, scheduler *backoff.Scheduler, forwardFunc, commandsFunc func(context.Context) error)
+	WorkerStatuses(ctx context.Context) []dto.WorkerStatusDTO
+	CurrentConfig(ctx context.Context) *dto.ConfigDTO
+	Status(ctx context.Context) *dto.AgentStatusDTO
+	Readiness(ctx context.Context) *dto.ReadinessDTO
 }
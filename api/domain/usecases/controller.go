@@ -3,15 +3,33 @@ package usecases
 import (
 	"context"
 	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/entity"
 	"github.com/adityawiryaa/api/domain/request"
 )
 
+type BundleSigner interface {
+	KeyID() string
+	Sign(payload []byte) []byte
+}
+
 type UsecaseControllerCommand interface {
 	RegisterAgent(ctx context.Context, req *request.RegisterAgentRequest) (*dto.RegistrationResponseDTO, error)
 	UpdateConfig(ctx context.Context, req *request.UpdateConfigRequest) (*dto.ConfigDTO, error)
+	ImportBundle(ctx context.Context, bundle *entity.ConfigBundle, mode string) (*dto.ImportResultDTO, error)
+	ReportAgentStatus(ctx context.Context, agentID string, req *request.AgentReportRequest) (*dto.AgentReportDTO, error)
+	EnqueueCommand(ctx context.Context, agentID string, req *request.EnqueueCommandRequest) (*dto.AgentCommandDTO, error)
+	ClaimCommands(ctx context.Context, agentID string) ([]dto.AgentCommandDTO, error)
+	CompleteCommand(ctx context.Context, agentID, commandID string, req *request.CommandResultRequest) (*dto.AgentCommandDTO, error)
 }
 
 type UsecaseControllerQuery interface {
 	GetLatestConfig(ctx context.Context) (*dto.ConfigDTO, error)
 	GetConfigByVersion(ctx context.Context, version int64) (*dto.ConfigDTO, error)
+	GetConfigPatch(ctx context.Context, baseVersion int64) (*dto.ConfigPatchDTO, error)
+	ExportBundle(ctx context.Context) (*entity.ConfigBundle, error)
+	ExportAgentBundle(ctx context.Context, target entity.BundleTarget) (*entity.SignedBundle, error)
+	ListAgents(ctx context.Context) ([]dto.AgentDTO, error)
+	GetAgent(ctx context.Context, id string) (*dto.AgentDTO, error)
+	ListAgentCommands(ctx context.Context, agentID, status string) ([]dto.AgentCommandDTO, error)
+	CountOutstandingCommands(ctx context.Context, agentID string) (int, error)
 }
@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrAgentNotFound        = errors.New("agent is not registered with the controller")
+	ErrWorkerNotFound       = errors.New("worker is not registered with the agent")
+	ErrCommandNotFound      = errors.New("command not found")
+	ErrInvalidCommand       = errors.New("invalid command")
+	ErrPatchBaseUnavailable = errors.New("config base version is not available for patching")
+	ErrNoConfig             = errors.New("no config available")
+	ErrSigningDisabled      = errors.New("bundle signing is not configured")
+	ErrInvalidBundleTarget  = errors.New("invalid bundle target")
+	ErrInvalidHit           = errors.New("invalid hit request")
+	ErrJobNotFound          = errors.New("hit job not found")
+	ErrNoHitResult          = errors.New("hit job has no result yet")
+	ErrInvalidBatch         = errors.New("invalid hit batch")
+	ErrBatchNotFound        = errors.New("hit batch not found")
+	ErrSyncConflict         = errors.New("config was edited outside of sync")
+	ErrBundleSchemaTooNew   = errors.New("bundle was exported from a newer database schema")
+)
+
+type RetryAfterError struct {
+	StatusCode int
+	After      time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	if e.After > 0 {
+		return fmt.Sprintf("controller asked to retry after %s (HTTP %d)", e.After, e.StatusCode)
+	}
+	return fmt.Sprintf("controller is unavailable (HTTP %d)", e.StatusCode)
+}
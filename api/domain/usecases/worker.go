@@ -2,41 +2,104 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/adityawiryaa/api/domain/dto"
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
 	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
 )
 
 type EnqueueHitResponse struct {
 	TaskID string `json:"task_id"`
+	Job    string `json:"job"`
 	Status string `json:"status"`
 }
 
+type EnqueueHitsResponse struct {
+	Tasks       []EnqueueHitResponse `json:"tasks"`
+	ImplicitJob bool                 `json:"-"`
+}
+
 type HitResultResponse struct {
-	TaskID     string `json:"task_id"`
-	Status     string `json:"status"`
-	StatusCode int    `json:"status_code,omitempty"`
-	Body       string `json:"body,omitempty"`
-	Error      string `json:"error,omitempty"`
+	TaskID       string          `json:"task_id"`
+	Job          string          `json:"job,omitempty"`
+	Status       string          `json:"status"`
+	StatusCode   int             `json:"status_code,omitempty"`
+	Body         string          `json:"body,omitempty"`
+	Extracted    json.RawMessage `json:"extracted,omitempty"`
+	ExtractError string          `json:"extract_error,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	CompletedAt  *time.Time      `json:"completed_at,omitempty"`
+}
+
+type HitJobResponse struct {
+	Name   string             `json:"name"`
+	Method string             `json:"method,omitempty"`
+	URL    string             `json:"url,omitempty"`
+	Error  string             `json:"error,omitempty"`
+	Latest *HitResultResponse `json:"latest,omitempty"`
+}
+
+type EnqueueBatchResponse struct {
+	BatchID  string `json:"batch_id"`
+	Status   string `json:"status"`
+	Total    int    `json:"total"`
+	Enqueued int    `json:"enqueued"`
+	Failed   int    `json:"failed"`
+}
+
+type HitBatchTaskResponse struct {
+	Index  int    `json:"index"`
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	HitResultResponse
+}
+
+type HitBatchResponse struct {
+	BatchID   string                 `json:"batch_id"`
+	CreatedAt time.Time              `json:"created_at"`
+	Total     int                    `json:"total"`
+	Counts    map[string]int         `json:"counts"`
+	Progress  float64                `json:"progress"`
+	Page      int                    `json:"page"`
+	PerPage   int                    `json:"per_page"`
+	Pages     int                    `json:"pages"`
+	Tasks     []HitBatchTaskResponse `json:"tasks"`
 }
 
 type UsecaseWorkerCommand interface {
 	ReceiveConfig(cfg *entity.Config)
-	EnqueueHit(ctx context.Context) (*EnqueueHitResponse, error)
+	EnqueueHit(ctx context.Context, job string, req *request.HitRequest) (*EnqueueHitResponse, error)
+	EnqueueHits(ctx context.Context, req *request.HitRequest) (*EnqueueHitsResponse, error)
+	EnqueueBatch(ctx context.Context, req *request.HitBatchRequest) (*EnqueueBatchResponse, error)
 }
 
 type UsecaseWorkerQuery interface {
 	CurrentConfig() *dto.ConfigDTO
 	GetHitResult(ctx context.Context, taskID string) (*HitResultResponse, error)
+	ListJobs(ctx context.Context) ([]HitJobResponse, error)
+	GetJobResult(ctx context.Context, job string) (*HitResultResponse, error)
+	ListSchedules(ctx context.Context) ([]hitqueue.ScheduleInfo, error)
+	GetBatch(ctx context.Context, batchID string, page, perPage int) (*HitBatchResponse, error)
+	QueueStats(ctx context.Context) (*hitqueue.QueueStats, error)
 }
 
 func ToHitResultResponse(r *hitqueue.HitResult) *HitResultResponse {
-	return &HitResultResponse{
-		TaskID:     r.TaskID,
-		Status:     r.Status,
-		StatusCode: r.StatusCode,
-		Body:       r.Body,
-		Error:      r.Error,
+	result := &HitResultResponse{
+		TaskID:       r.TaskID,
+		Job:          r.Job,
+		Status:       r.Status,
+		StatusCode:   r.StatusCode,
+		Body:         r.Body,
+		Extracted:    r.Extracted,
+		ExtractError: r.ExtractError,
+		Error:        r.Error,
+	}
+	if !r.CompletedAt.IsZero() {
+		completedAt := r.CompletedAt
+		result.CompletedAt = &completedAt
 	}
+	return result
 }
@@ -8,4 +8,49 @@ const (
 	TaskStatusPending   = "pending"
 	TaskStatusCompleted = "completed"
 	TaskStatusFailed    = "failed"
+
+	ImportModeReplay = "replay"
+	ImportModeSquash = "squash"
+
+	OriginAPI        = "api"
+	OriginImport     = "import"
+	OriginSyncPrefix = "sync:"
+
+	SyncActionApplied     = "applied"
+	SyncActionUnchanged   = "unchanged"
+	SyncActionConflict    = "conflict"
+	SyncActionOverwritten = "overwritten"
+
+	SyncConflictReject    = "reject"
+	SyncConflictOverwrite = "overwrite"
+
+	PollResultChanged   = "changed"
+	PollResultUnchanged = "unchanged"
+	PollResultError     = "error"
+
+	WorkerSourceStatic     = "static"
+	WorkerSourceRegistered = "registered"
+
+	CommandReload      = "reload"
+	CommandDrain       = "drain"
+	CommandUndrain     = "undrain"
+	CommandFlush       = "flush"
+	CommandReregister  = "reregister"
+	CommandDiagnostics = "diagnostics"
+
+	HookTypeExec = "exec"
+	HookTypeHTTP = "http"
+
+	CommandStatusPending   = "pending"
+	CommandStatusDelivered = "delivered"
+	CommandStatusSucceeded = "succeeded"
+	CommandStatusFailed    = "failed"
+
+	HitBodyRaw  = "raw"
+	HitBodyJSON = "json"
+	HitBodyForm = "form"
+
+	ExtractText = "text"
+	ExtractAttr = "attr"
+	ExtractHTML = "html"
 )
@@ -1,34 +1,149 @@
 package config
 
 import (
+	"fmt"
 	"strconv"
 	"time"
+
+	"github.com/adityawiryaa/api/pkg/compression"
+	"github.com/adityawiryaa/api/pkg/labels"
 )
 
+const controllerNone = "none"
+
 type AgentConfig struct {
-	Hostname       string
-	IPAddress      string
-	Port           int
-	ControllerURL  string
-	WorkerURL      string
-	APIKey         string
-	PollInterval   time.Duration
-	RequestTimeout time.Duration
+	Hostname               string
+	Labels                 map[string]string
+	IPAddress              string
+	Port                   int
+	ControllerURL          string
+	WorkerURLs             []string
+	WorkerTTL              time.Duration
+	APIKey                 string
+	CachePath              string
+	TemplatesFile          string
+	TemplateCommandTimeout time.Duration
+	HooksFile              string
+	OverridesFile          string
+	BundleDir              string
+	BundlePublicKeyFile    string
+	RelayPort              int
+	RelayAPIKey            string
+	RelayHistory           int
+	PollInterval           time.Duration
+	PollJitter             float64
+	PollMaxBackoff         time.Duration
+	ReconcileInterval      time.Duration
+	RequestTimeout         time.Duration
+	Compression            compression.Config
+
+	source *source
 }
 
-func LoadAgentConfig() *AgentConfig {
-	port, _ := strconv.Atoi(getEnv("AGENT_PORT", "8081"))
-	pollSec, _ := strconv.Atoi(getEnv("POLL_INTERVAL_SECONDS", "30"))
-	timeoutSec, _ := strconv.Atoi(getEnv("REQUEST_TIMEOUT_SECONDS", "10"))
-
-	return &AgentConfig{
-		Hostname:       getEnv("AGENT_HOSTNAME", "agent-01"),
-		IPAddress:      getEnv("AGENT_IP", "127.0.0.1"),
-		Port:           port,
-		ControllerURL:  getEnv("CONTROLLER_URL", "http://localhost:6001"),
-		WorkerURL:      getEnv("WORKER_URL", "http://localhost:6002"),
-		APIKey:         getEnv("API_KEY", "default-api-key"),
-		PollInterval:   time.Duration(pollSec) * time.Second,
-		RequestTimeout: time.Duration(timeoutSec) * time.Second,
+var agentSettings = append([]setting{
+	{key: "AGENT_HOSTNAME", value: "agent-01", usage: "hostname reported to the controller"},
+	{key: "AGENT_IP", value: "127.0.0.1", usage: "IP address reported to the controller"},
+	{key: "AGENT_LABELS", usage: "comma-separated key=value labels matched by bundle selectors"},
+	{key: "AGENT_PORT", value: "8081", usage: "agent API port"},
+	{key: "CONTROLLER_URL", value: "http://localhost:6001", usage: "controller base URL, or none to run from bundles only"},
+	{key: "WORKER_URLS", usage: "comma-separated static worker URLs"},
+	{key: "WORKER_URL", value: "http://localhost:6002", usage: "single static worker URL, used when WORKER_URLS is empty"},
+	{key: "WORKER_REGISTRATION_TTL_SECONDS", value: "90", usage: "seconds before a self-registered worker without heartbeat is dropped"},
+	{key: "API_KEY", value: "default-api-key", usage: "controller API key"},
+	{key: "AGENT_CACHE_PATH", value: "agent-config-cache.json", usage: "last-known-good config cache file"},
+	{key: "TEMPLATES_FILE", usage: "template to file mappings"},
+	{key: "TEMPLATE_COMMAND_TIMEOUT_SECONDS", value: "30", usage: "timeout for template check and reload commands"},
+	{key: "HOOKS_FILE", usage: "change hook definitions"},
+	{key: "OVERRIDES_FILE", usage: "local override file merged over the controller config data"},
+	{key: "BUNDLE_DIR", usage: "directory watched for signed config bundles"},
+	{key: "BUNDLE_PUBLIC_KEY_FILE", usage: "PEM ed25519 public key that bundles must be signed with"},
+	{key: "RELAY_PORT", usage: "port serving the controller API to downstream agents, empty to disable relay mode"},
+	{key: "RELAY_API_KEY", usage: "API key downstream agents must send to the relay, defaults to API_KEY"},
+	{key: "RELAY_CONFIG_HISTORY", value: "16", usage: "config versions kept by the relay for delta delivery"},
+	{key: "POLL_INTERVAL_SECONDS", value: "30", usage: "config poll interval", reloadable: true},
+	{key: "POLL_JITTER", value: "0.1", usage: "poll interval jitter fraction (0-1)", reloadable: true},
+	{key: "POLL_MAX_BACKOFF_SECONDS", value: "300", usage: "maximum poll delay after errors", reloadable: true},
+	{key: "RECONCILE_INTERVAL_SECONDS", value: "15", usage: "worker reconciliation and report interval", reloadable: true},
+	{key: "REQUEST_TIMEOUT_SECONDS", value: "10", usage: "timeout for controller and worker requests"},
+}, compressionSettings...)
+
+func LoadAgentConfig(args []string) (*AgentConfig, error) {
+	src, rest, err := load("agent", args, agentSettings)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", rest)
 	}
+
+	port, _ := strconv.Atoi(src.port("AGENT_PORT"))
+	workersKey := "WORKER_URLS"
+	if src.str(workersKey) == "" {
+		workersKey = "WORKER_URL"
+	}
+
+	agentLabels, err := labels.Parse(src.str("AGENT_LABELS"))
+	if err != nil {
+		src.invalid("AGENT_LABELS", err.Error())
+	}
+	controllerURL := src.str("CONTROLLER_URL")
+	if controllerURL == controllerNone {
+		controllerURL = ""
+	} else {
+		controllerURL = src.url("CONTROLLER_URL")
+	}
+
+	var relayPort int
+	if src.str("RELAY_PORT") != "" {
+		relayPort, _ = strconv.Atoi(src.port("RELAY_PORT"))
+	}
+	relayAPIKey := src.str("RELAY_API_KEY")
+	if relayAPIKey == "" {
+		relayAPIKey = src.str("API_KEY")
+	}
+
+	cfg := &AgentConfig{
+		Hostname:               src.str("AGENT_HOSTNAME"),
+		Labels:                 agentLabels,
+		IPAddress:              src.str("AGENT_IP"),
+		Port:                   port,
+		ControllerURL:          controllerURL,
+		WorkerURLs:             src.urls(workersKey),
+		WorkerTTL:              src.seconds("WORKER_REGISTRATION_TTL_SECONDS"),
+		APIKey:                 src.str("API_KEY"),
+		CachePath:              src.str("AGENT_CACHE_PATH"),
+		TemplatesFile:          src.str("TEMPLATES_FILE"),
+		TemplateCommandTimeout: src.seconds("TEMPLATE_COMMAND_TIMEOUT_SECONDS"),
+		HooksFile:              src.str("HOOKS_FILE"),
+		OverridesFile:          src.str("OVERRIDES_FILE"),
+		BundleDir:              src.str("BUNDLE_DIR"),
+		BundlePublicKeyFile:    src.str("BUNDLE_PUBLIC_KEY_FILE"),
+		RelayPort:              relayPort,
+		RelayAPIKey:            relayAPIKey,
+		RelayHistory:           src.integer("RELAY_CONFIG_HISTORY", 1),
+		PollInterval:           src.seconds("POLL_INTERVAL_SECONDS"),
+		PollJitter:             src.number("POLL_JITTER", 0, 1),
+		PollMaxBackoff:         src.seconds("POLL_MAX_BACKOFF_SECONDS"),
+		ReconcileInterval:      src.seconds("RECONCILE_INTERVAL_SECONDS"),
+		RequestTimeout:         src.seconds("REQUEST_TIMEOUT_SECONDS"),
+		Compression:            loadCompressionConfig(src),
+		source:                 src,
+	}
+	if cfg.ControllerURL == "" && cfg.BundleDir == "" {
+		src.invalid("CONTROLLER_URL", "is required unless BUNDLE_DIR is set")
+	}
+	if cfg.BundleDir != "" && cfg.BundlePublicKeyFile == "" {
+		src.invalid("BUNDLE_PUBLIC_KEY_FILE", "is required when BUNDLE_DIR is set")
+	}
+	if cfg.RelayPort != 0 && cfg.RelayPort == cfg.Port {
+		src.invalid("RELAY_PORT", "must differ from AGENT_PORT")
+	}
+	if err := src.err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *AgentConfig) RestartRequired(next *AgentConfig) []string {
+	return c.source.restartRequired(next.source)
 }
@@ -0,0 +1,21 @@
+package config
+
+import "github.com/adityawiryaa/api/pkg/compression"
+
+var compressionSettings = []setting{
+	{key: "COMPRESSION_ENCODINGS", value: "zstd,gzip", usage: "comma-separated response and request encodings in order of preference, or none"},
+	{key: "COMPRESSION_MIN_BYTES", value: "1024", usage: "smallest body that is compressed"},
+	{key: "COMPRESSION_MAX_REQUEST_BYTES", value: "33554432", usage: "largest decompressed request body that is accepted"},
+}
+
+func loadCompressionConfig(src *source) compression.Config {
+	encodings, err := compression.ParseEncodings(src.str("COMPRESSION_ENCODINGS"))
+	if err != nil {
+		src.invalid("COMPRESSION_ENCODINGS", "must be none or a list of zstd and gzip")
+	}
+	return compression.Config{
+		Encodings:      encodings,
+		MinSize:        src.integer("COMPRESSION_MIN_BYTES", 0),
+		MaxRequestSize: src.integer("COMPRESSION_MAX_REQUEST_BYTES", 1),
+	}
+}
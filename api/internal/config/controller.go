@@ -1,24 +1,67 @@
 package config
 
-import "os"
+import (
+	"time"
+
+	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/pkg/compression"
+)
 
 type ControllerConfig struct {
-	Port   string
-	DBPath string
-	APIKey string
+	Port                 string
+	DBPath               string
+	APIKey               string
+	BundleSigningKeyFile string
+	Sync                 *SyncConfig
+	Compression          compression.Config
+
+	source *source
 }
 
-func LoadControllerConfig() *ControllerConfig {
-	return &ControllerConfig{
-		Port:   getEnv("CONTROLLER_PORT", "6001"),
-		DBPath: getEnv("CONTROLLER_DB_PATH", "controller.db"),
-		APIKey: getEnv("API_KEY", "default-api-key"),
-	}
+type SyncConfig struct {
+	Dir            string
+	Interval       time.Duration
+	GitPull        bool
+	ConflictPolicy string
 }
 
-func getEnv(key string, fallback string) string {
-	if val := os.Getenv(key); val != "" {
-		return val
+var controllerSettings = append([]setting{
+	{key: "CONTROLLER_PORT", value: "6001", usage: "controller API port"},
+	{key: "CONTROLLER_DB_PATH", value: "controller.db", usage: "SQLite database path"},
+	{key: "API_KEY", value: "default-api-key", usage: "API key required on controller requests"},
+	{key: "BUNDLE_SIGNING_KEY_FILE", usage: "PEM ed25519 private key used to sign agent bundles"},
+	{key: "SYNC_DIR", usage: "directory or git working tree to sync configs from"},
+	{key: "SYNC_INTERVAL_SECONDS", value: "30", usage: "sync interval", reloadable: true},
+	{key: "SYNC_GIT_PULL", value: "false", usage: "run git pull before each sync"},
+	{key: "SYNC_CONFLICT_POLICY", value: valueobject.SyncConflictReject, usage: "sync conflict policy (reject or overwrite)", reloadable: true},
+}, compressionSettings...)
+
+func LoadControllerConfig(args []string) (*ControllerConfig, []string, error) {
+	src, rest, err := load("controller", args, controllerSettings)
+	if err != nil {
+		return nil, nil, err
 	}
-	return fallback
+
+	cfg := &ControllerConfig{
+		Port:                 src.port("CONTROLLER_PORT"),
+		DBPath:               src.str("CONTROLLER_DB_PATH"),
+		APIKey:               src.str("API_KEY"),
+		BundleSigningKeyFile: src.str("BUNDLE_SIGNING_KEY_FILE"),
+		Sync: &SyncConfig{
+			Dir:            src.str("SYNC_DIR"),
+			Interval:       src.seconds("SYNC_INTERVAL_SECONDS"),
+			GitPull:        src.boolean("SYNC_GIT_PULL"),
+			ConflictPolicy: src.oneOf("SYNC_CONFLICT_POLICY", valueobject.SyncConflictReject, valueobject.SyncConflictOverwrite),
+		},
+		Compression: loadCompressionConfig(src),
+		source:      src,
+	}
+	if err := src.err(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, rest, nil
+}
+
+func (c *ControllerConfig) RestartRequired(next *ControllerConfig) []string {
+	return c.source.restartRequired(next.source)
 }
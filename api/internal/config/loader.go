@@ -0,0 +1,233 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adityawiryaa/api/pkg/configformat"
+)
+
+const configFileEnv = "CONFIG_FILE"
+
+const (
+	originDefault = "default"
+	originFile    = "config file"
+	originEnv     = "env"
+	originFlag    = "flag"
+)
+
+type setting struct {
+	key        string
+	value      string
+	usage      string
+	reloadable bool
+}
+
+type source struct {
+	settings []setting
+	values   map[string]string
+	origins  map[string]string
+	errs     []error
+}
+
+func load(name string, args []string, settings []setting) (*source, []string, error) {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	configPath := fs.String("config", "", "path to a YAML, JSON or TOML config file (env "+configFileEnv+")")
+	flags := make(map[string]*string, len(settings))
+	for _, s := range settings {
+		usage := fmt.Sprintf("%s (env %s", s.usage, s.key)
+		if s.value != "" {
+			usage += ", default " + s.value
+		}
+		flags[s.key] = fs.String(flagName(s.key), "", usage+")")
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	if !setFlags["config"] {
+		*configPath = os.Getenv(configFileEnv)
+	}
+	fileValues, err := readConfigFile(*configPath, settings)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	src := &source{
+		settings: settings,
+		values:   make(map[string]string, len(settings)),
+		origins:  make(map[string]string, len(settings)),
+	}
+	for _, s := range settings {
+		value, origin := s.value, originDefault
+		if v, ok := fileValues[s.key]; ok {
+			value, origin = v, originFile
+		}
+		if v := os.Getenv(s.key); v != "" {
+			value, origin = v, originEnv
+		}
+		if setFlags[flagName(s.key)] {
+			value, origin = *flags[s.key], originFlag
+		}
+		src.values[s.key] = value
+		src.origins[s.key] = origin
+	}
+	return src, fs.Args(), nil
+}
+
+func readConfigFile(path string, settings []setting) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+	format, err := configformat.FromExtension(filepath.Ext(path))
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+	var doc map[string]any
+	if err := configformat.Unmarshal(format, raw, &doc); err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string, len(doc))
+	var errs []error
+	for name, v := range doc {
+		key := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+		if !slices.ContainsFunc(settings, func(s setting) bool { return s.key == key }) {
+			errs = append(errs, fmt.Errorf("config file %s: unknown setting %q", path, name))
+			continue
+		}
+		if list, ok := v.([]any); ok {
+			items := make([]string, len(list))
+			for i, item := range list {
+				items[i] = configformat.Stringify(item)
+			}
+			values[key] = strings.Join(items, ",")
+			continue
+		}
+		values[key] = configformat.Stringify(v)
+	}
+	return values, errors.Join(errs...)
+}
+
+func flagName(key string) string {
+	return strings.ToLower(strings.ReplaceAll(key, "_", "-"))
+}
+
+func (s *source) str(key string) string {
+	return s.values[key]
+}
+
+func (s *source) invalid(key string, reason string) {
+	s.errs = append(s.errs, fmt.Errorf("%s: invalid value %q (from %s): %s", key, s.values[key], s.origins[key], reason))
+}
+
+func (s *source) integer(key string, minimum int) int {
+	n, err := strconv.Atoi(s.values[key])
+	if err != nil {
+		s.invalid(key, "must be an integer")
+		return 0
+	}
+	if n < minimum {
+		s.invalid(key, fmt.Sprintf("must be at least %d", minimum))
+	}
+	return n
+}
+
+func (s *source) seconds(key string) time.Duration {
+	return time.Duration(s.integer(key, 1)) * time.Second
+}
+
+func (s *source) number(key string, minimum, maximum float64) float64 {
+	f, err := strconv.ParseFloat(s.values[key], 64)
+	if err != nil {
+		s.invalid(key, "must be a number")
+		return 0
+	}
+	if f < minimum || f > maximum {
+		s.invalid(key, fmt.Sprintf("must be between %g and %g", minimum, maximum))
+	}
+	return f
+}
+
+func (s *source) boolean(key string) bool {
+	b, err := strconv.ParseBool(s.values[key])
+	if err != nil {
+		s.invalid(key, "must be true or false")
+	}
+	return b
+}
+
+func (s *source) port(key string) string {
+	if n, err := strconv.Atoi(s.values[key]); err != nil || n < 1 || n > 65535 {
+		s.invalid(key, "must be a port between 1 and 65535")
+	}
+	return s.values[key]
+}
+
+func (s *source) oneOf(key string, allowed ...string) string {
+	if !slices.Contains(allowed, s.values[key]) {
+		s.invalid(key, "must be one of "+strings.Join(allowed, ", "))
+	}
+	return s.values[key]
+}
+
+func (s *source) url(key string) string {
+	if value := s.values[key]; value != "" && !validURL(value) {
+		s.invalid(key, "must be an http(s) URL")
+	}
+	return s.values[key]
+}
+
+func (s *source) urls(key string) []string {
+	list := splitList(s.values[key])
+	for _, item := range list {
+		if !validURL(item) {
+			s.invalid(key, fmt.Sprintf("%q is not an http(s) URL", item))
+		}
+	}
+	return list
+}
+
+func (s *source) err() error {
+	return errors.Join(s.errs...)
+}
+
+func (s *source) restartRequired(next *source) []string {
+	var changed []string
+	for _, setting := range s.settings {
+		if !setting.reloadable && s.values[setting.key] != next.values[setting.key] {
+			changed = append(changed, setting.key)
+		}
+	}
+	return changed
+}
+
+func validURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -1,7 +1,5 @@
 package config
 
-import "strconv"
-
 type RedisConfig struct {
 	Host    string
 	Port    string
@@ -9,15 +7,19 @@ type RedisConfig struct {
 	AsynqDB int
 }
 
-func LoadRedisConfig() *RedisConfig {
-	db, _ := strconv.Atoi(getEnv("REDIS_DB", "0"))
-	asynqDB, _ := strconv.Atoi(getEnv("ASYNQ_DB", "1"))
+var redisSettings = []setting{
+	{key: "REDIS_HOST", value: "localhost", usage: "Redis host"},
+	{key: "REDIS_PORT", value: "6379", usage: "Redis port"},
+	{key: "REDIS_DB", value: "0", usage: "Redis database for hit results"},
+	{key: "ASYNQ_DB", value: "1", usage: "Redis database for the asynq queue"},
+}
 
+func loadRedisConfig(src *source) *RedisConfig {
 	return &RedisConfig{
-		Host:    getEnv("REDIS_HOST", "localhost"),
-		Port:    getEnv("REDIS_PORT", "6379"),
-		DB:      db,
-		AsynqDB: asynqDB,
+		Host:    src.str("REDIS_HOST"),
+		Port:    src.port("REDIS_PORT"),
+		DB:      src.integer("REDIS_DB", 0),
+		AsynqDB: src.integer("ASYNQ_DB", 0),
 	}
 }
 
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func ReloadOnHangup[T any](ctx context.Context, current T, load func() (T, error), restartRequired func(current, next T) []string, apply func(next T)) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ReloadOnSignal(ctx, hup, current, load, restartRequired, apply)
+}
+
+func ReloadOnSignal[T any](ctx context.Context, signals <-chan os.Signal, current T, load func() (T, error), restartRequired func(current, next T) []string, apply func(next T)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+		}
+
+		next, err := load()
+		if err != nil {
+			log.Printf("config reload: failed, keeping current settings:\n%v", err)
+			continue
+		}
+		for _, key := range restartRequired(current, next) {
+			log.Printf("config reload: %s changed, restart required to apply it", key)
+		}
+		apply(next)
+	}
+}
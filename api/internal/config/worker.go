@@ -1,19 +1,73 @@
 package config
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/adityawiryaa/api/pkg/compression"
+)
 
 type WorkerConfig struct {
-	Port           string
-	APIKey         string
-	RequestTimeout time.Duration
-	Redis          *RedisConfig
+	Port              string
+	APIKey            string
+	RequestTimeout    time.Duration
+	AgentURL          string
+	AdvertiseURL      string
+	HeartbeatInterval time.Duration
+	ScheduleLocation  *time.Location
+	Redis             *RedisConfig
+	Compression       compression.Config
+
+	source *source
 }
 
-func LoadWorkerConfig() *WorkerConfig {
-	return &WorkerConfig{
-		Port:           getEnv("WORKER_PORT", "6002"),
-		APIKey:         getEnv("API_KEY", "default-api-key"),
-		RequestTimeout: 30 * time.Second,
-		Redis:          LoadRedisConfig(),
+var workerSettings = append([]setting{
+	{key: "WORKER_PORT", value: "6002", usage: "worker API port"},
+	{key: "API_KEY", value: "default-api-key", usage: "API key required on worker requests"},
+	{key: "AGENT_URL", usage: "agent URL to self-register with"},
+	{key: "WORKER_ADVERTISE_URL", usage: "URL the agent should use to reach this worker (default http://localhost:<WORKER_PORT>)"},
+	{key: "AGENT_HEARTBEAT_SECONDS", value: "30", usage: "self-registration heartbeat interval", reloadable: true},
+	{key: "SCHEDULE_TIMEZONE", value: "UTC", usage: "time zone used to evaluate job cron schedules"},
+}, append(redisSettings, compressionSettings...)...)
+
+func LoadWorkerConfig(args []string) (*WorkerConfig, error) {
+	src, rest, err := load("worker", args, workerSettings)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", rest)
 	}
+
+	port := src.port("WORKER_PORT")
+	advertiseURL := src.url("WORKER_ADVERTISE_URL")
+	if advertiseURL == "" {
+		advertiseURL = "http://localhost:" + port
+	}
+
+	location, err := time.LoadLocation(src.str("SCHEDULE_TIMEZONE"))
+	if err != nil {
+		src.invalid("SCHEDULE_TIMEZONE", "must be an IANA time zone name")
+	}
+
+	cfg := &WorkerConfig{
+		Port:              port,
+		APIKey:            src.str("API_KEY"),
+		RequestTimeout:    30 * time.Second,
+		AgentURL:          src.url("AGENT_URL"),
+		AdvertiseURL:      advertiseURL,
+		HeartbeatInterval: src.seconds("AGENT_HEARTBEAT_SECONDS"),
+		ScheduleLocation:  location,
+		Redis:             loadRedisConfig(src),
+		Compression:       loadCompressionConfig(src),
+		source:            src,
+	}
+	if err := src.err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *WorkerConfig) RestartRequired(next *WorkerConfig) []string {
+	return c.source.restartRequired(next.source)
 }
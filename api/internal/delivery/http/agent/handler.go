@@ -0,0 +1,17 @@
+package agent
+
+import (
+	"github.com/adityawiryaa/api/domain/usecases"
+)
+
+type Handler struct {
+	commandUC usecases.UsecaseAgentCommand
+	queryUC   usecases.UsecaseAgentQuery
+}
+
+func NewHandler(commandUC usecases.UsecaseAgentCommand, queryUC usecases.UsecaseAgentQuery) *Handler {
+	return &Handler{
+		commandUC: commandUC,
+		queryUC:   queryUC,
+	}
+}
@@ -0,0 +1,26 @@
+package agent
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/adityawiryaa/api/internal/middleware"
+	"github.com/adityawiryaa/api/pkg/compression"
+)
+
+func SetupRouter(handler *Handler, compressionCfg compression.Config) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(middleware.RequestLogging())
+	r.Use(middleware.Compression(compressionCfg))
+
+	r.GET("/healthz", handler.Healthz)
+	r.GET("/readyz", handler.Readyz)
+	r.GET("/status", handler.GetStatus)
+	r.GET("/config", handler.GetConfig)
+
+	r.POST("/workers", handler.RegisterWorker)
+	r.GET("/workers", handler.ListWorkers)
+	r.DELETE("/workers", handler.DeregisterWorker)
+
+	return r
+}
@@ -0,0 +1,35 @@
+package agent
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/adityawiryaa/api/pkg/response"
+)
+
+func (h *Handler) GetStatus(c *gin.Context) {
+	response.Success(c, http.StatusOK, h.queryUC.Status(c.Request.Context()))
+}
+
+func (h *Handler) GetConfig(c *gin.Context) {
+	cfg := h.queryUC.CurrentConfig(c.Request.Context())
+	if cfg == nil {
+		response.Error(c, http.StatusNotFound, "NOT_FOUND", "no config loaded")
+		return
+	}
+	response.Success(c, http.StatusOK, cfg)
+}
+
+func (h *Handler) Healthz(c *gin.Context) {
+	response.Success(c, http.StatusOK, map[string]any{"status": "ok"})
+}
+
+func (h *Handler) Readyz(c *gin.Context) {
+	readiness := h.queryUC.Readiness(c.Request.Context())
+	if !readiness.Ready {
+		response.Error(c, http.StatusServiceUnavailable, "NOT_READY", "failed checks: "+strings.Join(readiness.Checks, ", "))
+		return
+	}
+	response.Success(c, http.StatusOK, readiness)
+}
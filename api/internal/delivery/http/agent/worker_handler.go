@@ -0,0 +1,48 @@
+package agent
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/pkg/response"
+)
+
+func (h *Handler) RegisterWorker(c *gin.Context) {
+	var req request.RegisterWorkerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	status, err := h.commandUC.RegisterWorker(c.Request.Context(), &req)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "REGISTRATION_FAILED", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, status)
+}
+
+func (h *Handler) ListWorkers(c *gin.Context) {
+	response.Success(c, http.StatusOK, h.queryUC.WorkerStatuses(c.Request.Context()))
+}
+
+func (h *Handler) DeregisterWorker(c *gin.Context) {
+	url := c.Query("url")
+	if url == "" {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", "url query parameter is required")
+		return
+	}
+
+	if err := h.commandUC.DeregisterWorker(c.Request.Context(), url); err != nil {
+		if errors.Is(err, usecases.ErrWorkerNotFound) {
+			response.Error(c, http.StatusNotFound, "NOT_FOUND", err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "DEREGISTRATION_FAILED", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, map[string]any{"url": url})
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/pkg/response"
+)
+
+func (h *Handler) ListAgents(c *gin.Context) {
+	agents, err := h.queryUC.ListAgents(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "LIST_FAILED", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, agents)
+}
+
+func (h *Handler) ReportAgentStatus(c *gin.Context) {
+	var req request.AgentReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	report, err := h.commandUC.ReportAgentStatus(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		if errors.Is(err, usecases.ErrAgentNotFound) {
+			response.Error(c, http.StatusNotFound, "AGENT_NOT_FOUND", err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "REPORT_FAILED", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, report)
+}
@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/pkg/configformat"
+	"github.com/adityawiryaa/api/pkg/response"
+)
+
+func (h *Handler) ExportBundle(c *gin.Context) {
+	var format string
+	switch c.NegotiateFormat(configformat.MIMEJSON, configformat.MIMEYAML, configformat.MIMEXYAML) {
+	case configformat.MIMEJSON:
+		format = configformat.FormatJSON
+	case configformat.MIMEYAML, configformat.MIMEXYAML:
+		format = configformat.FormatYAML
+	default:
+		response.Error(c, http.StatusNotAcceptable, "NOT_ACCEPTABLE", "supported formats: application/json, application/yaml")
+		return
+	}
+
+	bundle, err := h.queryUC.ExportBundle(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "EXPORT_FAILED", err.Error())
+		return
+	}
+	raw, err := configformat.Marshal(format, bundle)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "EXPORT_FAILED", err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("controller-export-%s.%s", bundle.ExportedAt.UTC().Format("20060102T150405Z"), format)
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Vary", "Accept")
+	c.Data(http.StatusOK, configformat.ContentType(format), raw)
+}
+
+func (h *Handler) ExportAgentBundle(c *gin.Context) {
+	target := entity.BundleTarget{AgentID: c.Query("agent_id"), Selector: c.Query("selector")}
+	signed, err := h.queryUC.ExportAgentBundle(c.Request.Context(), target)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrInvalidBundleTarget):
+			response.Error(c, http.StatusBadRequest, "INVALID_TARGET", err.Error())
+		case errors.Is(err, usecases.ErrAgentNotFound):
+			response.Error(c, http.StatusNotFound, "AGENT_NOT_FOUND", err.Error())
+		case errors.Is(err, usecases.ErrNoConfig):
+			response.Error(c, http.StatusNotFound, "NOT_FOUND", err.Error())
+		case errors.Is(err, usecases.ErrSigningDisabled):
+			response.Error(c, http.StatusNotImplemented, "SIGNING_DISABLED", err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "EXPORT_FAILED", err.Error())
+		}
+		return
+	}
+
+	filename := fmt.Sprintf("agent-bundle-%s.json", time.Now().UTC().Format("20060102T150405Z"))
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.JSON(http.StatusOK, signed)
+}
+
+func (h *Handler) ImportBundle(c *gin.Context) {
+	format, err := configformat.FromContentType(c.ContentType())
+	if err == nil && format == configformat.FormatTOML {
+		err = fmt.Errorf("bundles must be JSON or YAML")
+	}
+	if err != nil {
+		response.Error(c, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	var bundle entity.ConfigBundle
+	if err := configformat.Unmarshal(format, body, &bundle); err != nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	mode := c.DefaultQuery("mode", valueobject.ImportModeReplay)
+	if mode != valueobject.ImportModeReplay && mode != valueobject.ImportModeSquash {
+		response.Error(c, http.StatusBadRequest, "INVALID_MODE", "mode must be replay or squash")
+		return
+	}
+
+	result, err := h.commandUC.ImportBundle(c.Request.Context(), &bundle, mode)
+	if errors.Is(err, usecases.ErrBundleSchemaTooNew) {
+		response.Error(c, http.StatusBadRequest, "UNSUPPORTED_SCHEMA", err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "IMPORT_FAILED", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, result)
+}
+
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/pkg/response"
+)
+
+func (h *Handler) EnqueueCommand(c *gin.Context) {
+	var req request.EnqueueCommandRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	cmd, err := h.commandUC.EnqueueCommand(c.Request.Context(), c.Param("id"), &req)
+	if err != nil {
+		respondCommandError(c, err, "ENQUEUE_FAILED")
+		return
+	}
+	response.Success(c, http.StatusCreated, cmd)
+}
+
+func (h *Handler) ListAgentCommands(c *gin.Context) {
+	cmds, err := h.queryUC.ListAgentCommands(c.Request.Context(), c.Param("id"), c.Query("status"))
+	if err != nil {
+		respondCommandError(c, err, "LIST_FAILED")
+		return
+	}
+	response.Success(c, http.StatusOK, cmds)
+}
+
+func (h *Handler) ClaimCommands(c *gin.Context) {
+	cmds, err := h.commandUC.ClaimCommands(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondCommandError(c, err, "CLAIM_FAILED")
+		return
+	}
+	response.Success(c, http.StatusOK, cmds)
+}
+
+func (h *Handler) CompleteCommand(c *gin.Context) {
+	var req request.CommandResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	cmd, err := h.commandUC.CompleteCommand(c.Request.Context(), c.Param("id"), c.Param("command_id"), &req)
+	if err != nil {
+		respondCommandError(c, err, "COMPLETE_FAILED")
+		return
+	}
+	response.Success(c, http.StatusOK, cmd)
+}
+
+func respondCommandError(c *gin.Context, err error, fallbackCode string) {
+	switch {
+	case errors.Is(err, usecases.ErrAgentNotFound):
+		response.Error(c, http.StatusNotFound, "AGENT_NOT_FOUND", err.Error())
+	case errors.Is(err, usecases.ErrCommandNotFound):
+		response.Error(c, http.StatusNotFound, "COMMAND_NOT_FOUND", err.Error())
+	case errors.Is(err, usecases.ErrInvalidCommand):
+		response.Error(c, http.StatusBadRequest, "INVALID_COMMAND", err.Error())
+	default:
+		response.Error(c, http.StatusInternalServerError, fallbackCode, err.Error())
+	}
+}
@@ -1,22 +1,49 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/pkg/configformat"
 	"github.com/adityawiryaa/api/pkg/response"
 )
 
+const imJSONPatch = "json-patch"
+
 func (h *Handler) UpdateConfig(c *gin.Context) {
-	var req request.UpdateConfigRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	format, err := configformat.FromContentType(c.ContentType())
+	if err != nil {
+		response.Error(c, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	var doc configformat.ConfigDocument
+	if err := configformat.Unmarshal(format, body, &doc); err != nil {
 		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
 		return
 	}
+	if doc.Data == nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", "data is required")
+		return
+	}
 
-	cfg, err := h.commandUC.UpdateConfig(c.Request.Context(), &req)
+	cfg, err := h.commandUC.UpdateConfig(c.Request.Context(), &request.UpdateConfigRequest{
+		Data:                doc.StringData(),
+		PollIntervalSeconds: doc.PollIntervalSeconds,
+	})
 	if err != nil {
 		response.Error(c, http.StatusInternalServerError, "UPDATE_FAILED", err.Error())
 		return
@@ -27,8 +54,29 @@ func (h *Handler) UpdateConfig(c *gin.Context) {
 }
 
 func (h *Handler) GetConfig(c *gin.Context) {
+	format, ok := negotiateConfigFormat(c)
+	if !ok {
+		return
+	}
 	ifNoneMatch := c.GetHeader("If-None-Match")
 
+	if agentID := c.GetHeader("X-Agent-ID"); agentID != "" {
+		if _, err := h.queryUC.GetAgent(c.Request.Context(), agentID); err != nil {
+			if errors.Is(err, usecases.ErrAgentNotFound) {
+				response.Error(c, http.StatusNotFound, "AGENT_NOT_FOUND", err.Error())
+				return
+			}
+			response.Error(c, http.StatusInternalServerError, "AGENT_LOOKUP_FAILED", err.Error())
+			return
+		}
+		outstanding, err := h.queryUC.CountOutstandingCommands(c.Request.Context(), agentID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "COMMAND_LOOKUP_FAILED", err.Error())
+			return
+		}
+		c.Header("X-Pending-Commands", strconv.Itoa(outstanding))
+	}
+
 	cfg, err := h.queryUC.GetLatestConfig(c.Request.Context())
 	if err != nil {
 		response.Error(c, http.StatusNotFound, "NOT_FOUND", "no config available")
@@ -36,16 +84,47 @@ func (h *Handler) GetConfig(c *gin.Context) {
 	}
 
 	etag := strconv.FormatInt(cfg.Version, 10)
+	c.Header("Vary", "Accept")
+	c.Header("X-Poll-Interval", strconv.Itoa(cfg.PollIntervalSeconds))
 	if ifNoneMatch == etag {
 		c.Status(http.StatusNotModified)
 		return
 	}
 
 	c.Header("ETag", etag)
-	response.Success(c, http.StatusOK, cfg)
+	if acceptsPatch(c.GetHeader("A-IM")) {
+		if baseVersion, err := strconv.ParseInt(ifNoneMatch, 10, 64); err == nil {
+			patch, err := h.queryUC.GetConfigPatch(c.Request.Context(), baseVersion)
+			switch {
+			case err == nil:
+				c.Header("IM", imJSONPatch)
+				c.Header("Delta-Base", ifNoneMatch)
+				renderConfig(c, http.StatusIMUsed, format, patch)
+				return
+			case !errors.Is(err, usecases.ErrPatchBaseUnavailable):
+				response.Error(c, http.StatusInternalServerError, "PATCH_FAILED", err.Error())
+				return
+			}
+		}
+	}
+	renderConfig(c, http.StatusOK, format, cfg)
+}
+
+func acceptsPatch(aIM string) bool {
+	for _, im := range strings.Split(aIM, ",") {
+		if strings.EqualFold(strings.TrimSpace(im), imJSONPatch) {
+			return true
+		}
+	}
+	return false
 }
 
 func (h *Handler) GetConfigByVersion(c *gin.Context) {
+	format, ok := negotiateConfigFormat(c)
+	if !ok {
+		return
+	}
+
 	versionStr := c.Param("version")
 	version, err := strconv.ParseInt(versionStr, 10, 64)
 	if err != nil {
@@ -59,5 +138,34 @@ func (h *Handler) GetConfigByVersion(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, http.StatusOK, cfg)
+	c.Header("ETag", strconv.FormatInt(cfg.Version, 10))
+	c.Header("Vary", "Accept")
+	renderConfig(c, http.StatusOK, format, cfg)
+}
+
+func negotiateConfigFormat(c *gin.Context) (string, bool) {
+	switch c.NegotiateFormat(configformat.MIMEJSON, configformat.MIMEYAML, configformat.MIMEXYAML, configformat.MIMETOML) {
+	case configformat.MIMEJSON:
+		return configformat.FormatJSON, true
+	case configformat.MIMEYAML, configformat.MIMEXYAML:
+		return configformat.FormatYAML, true
+	case configformat.MIMETOML:
+		return configformat.FormatTOML, true
+	default:
+		c.Header("Vary", "Accept")
+		response.Error(c, http.StatusNotAcceptable, "NOT_ACCEPTABLE",
+			"supported formats: application/json, application/yaml, application/toml")
+		return "", false
+	}
+}
+
+func renderConfig(c *gin.Context, status int, format string, data any) {
+	if format == configformat.FormatJSON {
+		response.Success(c, status, data)
+		return
+	}
+	if err := response.SuccessAs(c, status, format, data); err != nil {
+		c.Writer.Header().Del("ETag")
+		response.Error(c, http.StatusNotAcceptable, "NOT_ACCEPTABLE", fmt.Sprintf("encoding config as %s: %v", format, err))
+	}
 }
@@ -3,13 +3,15 @@ package controller
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/adityawiryaa/api/internal/middleware"
+	"github.com/adityawiryaa/api/pkg/compression"
 )
 
-func SetupRouter(handler *Handler, apiKey string) *gin.Engine {
+func SetupRouter(handler *Handler, apiKey string, compressionCfg compression.Config) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
 	r.Use(gin.Recovery())
 	r.Use(middleware.RequestLogging())
+	r.Use(middleware.Compression(compressionCfg))
 
 	protected := r.Group("")
 	protected.Use(middleware.APIKeyAuth(apiKey))
@@ -18,6 +20,39 @@ func SetupRouter(handler *Handler, apiKey string) *gin.Engine {
 		protected.POST("/config", handler.UpdateConfig)
 		protected.GET("/config", handler.GetConfig)
 		protected.GET("/config/:version", handler.GetConfigByVersion)
+		protected.GET("/agents", handler.ListAgents)
+		protected.POST("/agents/:id/report", handler.ReportAgentStatus)
+		protected.POST("/agents/:id/commands", handler.EnqueueCommand)
+		protected.GET("/agents/:id/commands", handler.ListAgentCommands)
+		protected.POST("/agents/:id/commands/claim", handler.ClaimCommands)
+		protected.POST("/agents/:id/commands/:command_id/result", handler.CompleteCommand)
+		protected.GET("/export", handler.ExportBundle)
+		protected.GET("/agent-bundle", handler.ExportAgentBundle)
+		protected.POST("/import", handler.ImportBundle)
+	}
+
+	return r
+}
+
+func SetupRelayRouter(handler *Handler, apiKey string, compressionCfg compression.Config) *gin.Engine {
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(middleware.RequestLogging())
+	r.Use(middleware.Compression(compressionCfg))
+
+	protected := r.Group("")
+	protected.Use(middleware.APIKeyAuth(apiKey))
+	{
+		protected.POST("/register", handler.RegisterAgent)
+		protected.GET("/config", handler.GetConfig)
+		protected.GET("/config/:version", handler.GetConfigByVersion)
+		protected.GET("/agents", handler.ListAgents)
+		protected.POST("/agents/:id/report", handler.ReportAgentStatus)
+		protected.POST("/agents/:id/commands", handler.EnqueueCommand)
+		protected.GET("/agents/:id/commands", handler.ListAgentCommands)
+		protected.POST("/agents/:id/commands/claim", handler.ClaimCommands)
+		protected.POST("/agents/:id/commands/:command_id/result", handler.CompleteCommand)
 	}
 
 	return r
@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/pkg/hit/batch"
+	"github.com/adityawiryaa/api/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) SubmitBatch(c *gin.Context) {
+	hits, err := decodeBatch(c)
+	if errors.Is(err, batch.ErrUnsupportedFormat) {
+		response.Error(c, http.StatusUnsupportedMediaType, "UNSUPPORTED_FORMAT", err.Error())
+		return
+	}
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	resp, err := h.commandUC.EnqueueBatch(c.Request.Context(), &request.HitBatchRequest{Hits: hits})
+	if errors.Is(err, usecases.ErrInvalidBatch) || errors.Is(err, usecases.ErrInvalidHit) {
+		response.Error(c, http.StatusBadRequest, "INVALID_BATCH", err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("[handler] enqueue batch failed: %v", err)
+		response.Error(c, http.StatusInternalServerError, "ENQUEUE_FAILED", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusAccepted, resp)
+}
+
+func (h *Handler) GetBatch(c *gin.Context) {
+	page, err := queryInt(c, "page")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+	perPage, err := queryInt(c, "per_page")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return
+	}
+
+	batchID := c.Param("id")
+	resp, err := h.queryUC.GetBatch(c.Request.Context(), batchID, page, perPage)
+	if errors.Is(err, usecases.ErrBatchNotFound) {
+		response.Error(c, http.StatusNotFound, "BATCH_NOT_FOUND", err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("[handler] get batch failed: id=%s error=%v", batchID, err)
+		response.Error(c, http.StatusInternalServerError, "BATCH_FAILED", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, resp)
+}
+
+func decodeBatch(c *gin.Context) ([]request.BatchHitSpec, error) {
+	if c.ContentType() != gin.MIMEMultipartPOSTForm {
+		format, err := batch.FormatOf(c.GetHeader("Content-Type"), "")
+		if err != nil {
+			return nil, err
+		}
+		return batch.Decode(format, c.Request.Body)
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+	format, err := batch.FormatOf(header.Header.Get("Content-Type"), header.Filename)
+	if err != nil {
+		return nil, err
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return batch.Decode(format, file)
+}
+
+func queryInt(c *gin.Context, key string) (int, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, errors.New(key + " must be a positive integer")
+	}
+	return n, nil
+}
@@ -1,17 +1,59 @@
 package worker
 
 import (
+	"errors"
+	"io"
 	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
 	"github.com/adityawiryaa/api/pkg/response"
 )
 
 func (h *Handler) ExecuteHit(c *gin.Context) {
-	resp, err := h.commandUC.EnqueueHit(c.Request.Context())
+	req, ok := bindHitRequest(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.commandUC.EnqueueHits(c.Request.Context(), req)
+	if errors.Is(err, usecases.ErrInvalidHit) {
+		response.Error(c, http.StatusBadRequest, "INVALID_HIT", err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("[handler] enqueue hits failed: %v", err)
+		response.Error(c, http.StatusInternalServerError, "ENQUEUE_FAILED", err.Error())
+		return
+	}
+
+	if resp.ImplicitJob && len(resp.Tasks) == 1 {
+		response.Success(c, http.StatusAccepted, resp.Tasks[0])
+		return
+	}
+	response.Success(c, http.StatusAccepted, resp)
+}
+
+func (h *Handler) ExecuteJobHit(c *gin.Context) {
+	req, ok := bindHitRequest(c)
+	if !ok {
+		return
+	}
+
+	job := c.Param("job")
+	resp, err := h.commandUC.EnqueueHit(c.Request.Context(), job, req)
+	if errors.Is(err, usecases.ErrJobNotFound) {
+		response.Error(c, http.StatusNotFound, "JOB_NOT_FOUND", err.Error())
+		return
+	}
+	if errors.Is(err, usecases.ErrInvalidHit) {
+		response.Error(c, http.StatusBadRequest, "INVALID_HIT", err.Error())
+		return
+	}
 	if err != nil {
-		log.Printf("[handler] enqueue hit failed: %v", err)
+		log.Printf("[handler] enqueue hit failed: job=%s error=%v", job, err)
 		response.Error(c, http.StatusInternalServerError, "ENQUEUE_FAILED", err.Error())
 		return
 	}
@@ -19,6 +61,18 @@ func (h *Handler) ExecuteHit(c *gin.Context) {
 	response.Success(c, http.StatusAccepted, resp)
 }
 
+func bindHitRequest(c *gin.Context) (*request.HitRequest, bool) {
+	if c.Request.Method != http.MethodPost {
+		return nil, true
+	}
+	req := &request.HitRequest{}
+	if err := c.ShouldBindJSON(req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(c, http.StatusBadRequest, "INVALID_REQUEST", err.Error())
+		return nil, false
+	}
+	return req, true
+}
+
 func (h *Handler) GetHitResult(c *gin.Context) {
 	taskID := c.Param("taskId")
 	if taskID == "" {
@@ -38,3 +92,60 @@ func (h *Handler) GetHitResult(c *gin.Context) {
 	log.Printf("[handler] result found: task=%s status=%s", taskID, resp.Status)
 	response.Success(c, http.StatusOK, resp)
 }
+
+func (h *Handler) ListJobs(c *gin.Context) {
+	jobs, err := h.queryUC.ListJobs(c.Request.Context())
+	if errors.Is(err, usecases.ErrInvalidHit) {
+		response.Error(c, http.StatusBadRequest, "INVALID_HIT", err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("[handler] list jobs failed: %v", err)
+		response.Error(c, http.StatusInternalServerError, "JOB_LIST_FAILED", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, jobs)
+}
+
+func (h *Handler) GetJobResult(c *gin.Context) {
+	job := c.Param("job")
+	resp, err := h.queryUC.GetJobResult(c.Request.Context(), job)
+	if errors.Is(err, usecases.ErrInvalidHit) {
+		response.Error(c, http.StatusBadRequest, "INVALID_HIT", err.Error())
+		return
+	}
+	if errors.Is(err, usecases.ErrJobNotFound) {
+		response.Error(c, http.StatusNotFound, "JOB_NOT_FOUND", err.Error())
+		return
+	}
+	if errors.Is(err, usecases.ErrNoHitResult) {
+		response.Error(c, http.StatusNotFound, "NO_RESULT", err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("[handler] get job result failed: job=%s error=%v", job, err)
+		response.Error(c, http.StatusInternalServerError, "RESULT_FETCH_FAILED", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, resp)
+}
+
+func (h *Handler) ListSchedules(c *gin.Context) {
+	schedules, err := h.queryUC.ListSchedules(c.Request.Context())
+	if err != nil {
+		log.Printf("[handler] list schedules failed: %v", err)
+		response.Error(c, http.StatusInternalServerError, "SCHEDULE_LIST_FAILED", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, schedules)
+}
+
+func (h *Handler) GetQueueStats(c *gin.Context) {
+	stats, err := h.queryUC.QueueStats(c.Request.Context())
+	if err != nil {
+		log.Printf("[handler] queue stats failed: %v", err)
+		response.Error(c, http.StatusInternalServerError, "QUEUE_STATS_FAILED", err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, stats)
+}
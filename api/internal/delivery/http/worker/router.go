@@ -3,18 +3,28 @@ package worker
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/adityawiryaa/api/internal/middleware"
+	"github.com/adityawiryaa/api/pkg/compression"
 )
 
-func SetupRouter(handler *Handler, apiKey string) *gin.Engine {
+func SetupRouter(handler *Handler, apiKey string, compressionCfg compression.Config) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
 	r.Use(gin.Recovery())
 	r.Use(middleware.RequestLogging())
+	r.Use(middleware.Compression(compressionCfg))
 
 	r.POST("/config", handler.ReceiveConfig)
 	r.GET("/config", handler.GetCurrentConfig)
 	r.GET("/hit", handler.ExecuteHit)
+	r.POST("/hit", handler.ExecuteHit)
+	r.POST("/hit/:job", handler.ExecuteJobHit)
 	r.GET("/hit/:taskId", handler.GetHitResult)
+	r.POST("/hits/batch", handler.SubmitBatch)
+	r.GET("/hits/batch/:id", handler.GetBatch)
+	r.GET("/jobs", handler.ListJobs)
+	r.GET("/jobs/:job", handler.GetJobResult)
+	r.GET("/schedules", handler.ListSchedules)
+	r.GET("/queue", handler.GetQueueStats)
 
 	return r
 }
@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/adityawiryaa/api/pkg/compression"
+	"github.com/adityawiryaa/api/pkg/response"
+)
+
+func Compression(cfg compression.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, ok := decompressRequest(c, cfg.RequestLimit())
+		if !ok {
+			c.Abort()
+			return
+		}
+		if body != nil {
+			defer body.Close()
+		}
+		if !cfg.Enabled() || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		encoding := compression.Negotiate(c.GetHeader("Accept-Encoding"), cfg.Encodings)
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, encoding: encoding, minSize: cfg.MinSize}
+		c.Writer = writer
+		defer func() {
+			writer.finish()
+			c.Writer = writer.ResponseWriter
+		}()
+		c.Next()
+	}
+}
+
+func decompressRequest(c *gin.Context, limit int64) (io.Closer, bool) {
+	encoding := strings.ToLower(strings.TrimSpace(c.GetHeader("Content-Encoding")))
+	if encoding == "" || encoding == compression.Identity {
+		return nil, true
+	}
+	if !compression.Supported(encoding) {
+		c.Header("Accept-Encoding", compression.DefaultConfig().AcceptEncoding())
+		response.Error(c, http.StatusUnsupportedMediaType, "UNSUPPORTED_ENCODING", "unsupported request content encoding "+encoding)
+		return nil, false
+	}
+
+	decoder, err := compression.NewReader(encoding, c.Request.Body)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "INVALID_BODY", "invalid "+encoding+" request body")
+		return nil, false
+	}
+	body := http.MaxBytesReader(c.Writer, decoder, limit)
+	c.Request.Body = body
+	c.Request.Header.Del("Content-Encoding")
+	c.Request.Header.Del("Content-Length")
+	c.Request.ContentLength = -1
+	return body, true
+}
+
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	minSize  int
+	buf      bytes.Buffer
+	writer   io.WriteCloser
+	passthru bool
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.passthru {
+		return w.ResponseWriter.Write(data)
+	}
+	if w.writer != nil {
+		return w.writer.Write(data)
+	}
+	if w.Header().Get("Content-Encoding") != "" || !bodyAllowed(w.Status()) {
+		w.passthru = true
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf.Write(data)
+	if w.buf.Len() >= w.minSize {
+		if err := w.start(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) Flush() {
+	if w.writer == nil && !w.passthru {
+		w.release()
+	}
+	if f, ok := w.writer.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressWriter) start() error {
+	header := w.Header()
+	addVary(header)
+	header.Set("Content-Encoding", w.encoding)
+	header.Del("Content-Length")
+	header.Del("Accept-Ranges")
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", "W/"+etag)
+	}
+
+	writer, err := compression.NewWriter(w.encoding, w.ResponseWriter)
+	if err != nil {
+		return err
+	}
+	w.writer = writer
+	_, err = w.writer.Write(w.buf.Bytes())
+	w.buf.Reset()
+	return err
+}
+
+func (w *compressWriter) release() {
+	w.passthru = true
+	addVary(w.Header())
+	if w.buf.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+}
+
+func (w *compressWriter) finish() {
+	if w.writer != nil {
+		_ = w.writer.Close()
+		return
+	}
+	if !w.passthru {
+		w.release()
+	}
+}
+
+func addVary(header http.Header) {
+	for _, value := range header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), "Accept-Encoding") {
+				return
+			}
+		}
+	}
+	header.Add("Vary", "Accept-Encoding")
+}
+
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
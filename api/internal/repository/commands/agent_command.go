@@ -3,8 +3,11 @@ package commands
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
 
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
 )
 
 type AgentCommand struct {
@@ -25,3 +28,26 @@ func (r *AgentCommand) Save(ctx context.Context, agent *entity.Agent) error {
 	)
 	return err
 }
+
+func (r *AgentCommand) SaveReport(ctx context.Context, id string, report *entity.AgentReport) error {
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("encoding agent report: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE agents SET report = ?, updated_at = ? WHERE id = ?`,
+		string(raw), report.ReportedAt, id,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+type BundleCommand struct {
+	db *sql.DB
+}
+
+func NewBundleCommand(db *sql.DB) *BundleCommand {
+	return &BundleCommand{db: db}
+}
+
+func (r *BundleCommand) Import(ctx context.Context, configs []*entity.Config, agents []*entity.Agent) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, cfg := range configs {
+		data, err := json.Marshal(cfg.Data)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO configs (id, version, data, poll_interval_seconds, origin, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+			cfg.ID, cfg.Version, string(data), cfg.PollIntervalSeconds, cfg.Origin, cfg.CreatedAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, agent := range agents {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO agents (id, hostname, ip_address, port, status, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(id) DO UPDATE SET hostname=?, ip_address=?, port=?, status=?, updated_at=?`,
+			agent.ID, agent.Hostname, agent.IPAddress, agent.Port, agent.Status, agent.CreatedAt, agent.UpdatedAt,
+			agent.Hostname, agent.IPAddress, agent.Port, agent.Status, agent.UpdatedAt,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
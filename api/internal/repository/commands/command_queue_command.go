@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/valueobject"
+)
+
+type CommandQueueCommand struct {
+	db *sql.DB
+}
+
+func NewCommandQueueCommand(db *sql.DB) *CommandQueueCommand {
+	return &CommandQueueCommand{db: db}
+}
+
+func (r *CommandQueueCommand) Enqueue(ctx context.Context, cmd *entity.AgentCommand) error {
+	args, err := json.Marshal(cmd.Args)
+	if err != nil {
+		return fmt.Errorf("encoding command args: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO agent_commands (id, agent_id, type, args, status, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		cmd.ID, cmd.AgentID, cmd.Type, string(args), cmd.Status, cmd.CreatedAt,
+	)
+	return err
+}
+
+func (r *CommandQueueCommand) MarkDelivered(ctx context.Context, agentID string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE agent_commands SET status = ?, delivered_at = ? WHERE agent_id = ? AND status = ?`,
+		valueobject.CommandStatusDelivered, at, agentID, valueobject.CommandStatusPending,
+	)
+	return err
+}
+
+func (r *CommandQueueCommand) Complete(ctx context.Context, cmd *entity.AgentCommand) error {
+	var result sql.NullString
+	if len(cmd.Result) > 0 {
+		result = sql.NullString{String: string(cmd.Result), Valid: true}
+	}
+
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE agent_commands SET status = ?, result = ?, error = ?, completed_at = ? WHERE id = ? AND agent_id = ?`,
+		cmd.Status, result, cmd.Error, cmd.CompletedAt, cmd.ID, cmd.AgentID,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -22,8 +22,8 @@ func (r *ConfigCommand) SaveConfig(ctx context.Context, cfg *entity.Config) erro
 		return err
 	}
 	_, err = r.db.ExecContext(ctx,
-		`INSERT INTO configs (id, version, data, poll_interval_seconds, created_at) VALUES (?, ?, ?, ?, ?)`,
-		cfg.ID, cfg.Version, string(data), cfg.PollIntervalSeconds, cfg.CreatedAt,
+		`INSERT INTO configs (id, version, data, poll_interval_seconds, origin, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+		cfg.ID, cfg.Version, string(data), cfg.PollIntervalSeconds, cfg.Origin, cfg.CreatedAt,
 	)
 	return err
 }
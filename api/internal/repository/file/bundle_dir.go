@@ -0,0 +1,65 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+const (
+	appliedBundleDir  = "applied"
+	rejectedBundleDir = "rejected"
+)
+
+type BundleDir struct {
+	path string
+}
+
+func NewBundleDir(path string) *BundleDir {
+	return &BundleDir{path: path}
+}
+
+func (d *BundleDir) Pending() ([]entity.BundleFile, error) {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []entity.BundleFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") || filepath.Ext(name) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(d.path, name))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, entity.BundleFile{Name: name, Data: data})
+	}
+	slices.SortFunc(files, func(a, b entity.BundleFile) int { return strings.Compare(a.Name, b.Name) })
+	return files, nil
+}
+
+func (d *BundleDir) Accept(name string) error {
+	return d.move(name, appliedBundleDir)
+}
+
+func (d *BundleDir) Reject(name string) error {
+	return d.move(name, rejectedBundleDir)
+}
+
+func (d *BundleDir) move(name, subdir string) error {
+	target := filepath.Join(d.path, subdir)
+	if err := os.MkdirAll(target, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", target, err)
+	}
+	if err := os.Rename(filepath.Join(d.path, name), filepath.Join(target, name)); err != nil {
+		return fmt.Errorf("moving bundle %s to %s: %w", name, subdir, err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package file
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+type cachedConfig struct {
+	Version  int64          `json:"version"`
+	Checksum string         `json:"checksum"`
+	SavedAt  time.Time      `json:"saved_at"`
+	Config   *entity.Config `json:"config"`
+}
+
+type ConfigCache struct {
+	path string
+}
+
+func NewConfigCache(path string) *ConfigCache {
+	return &ConfigCache{path: path}
+}
+
+func (c *ConfigCache) Load() (*entity.Config, error) {
+	raw, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config cache: %w", err)
+	}
+
+	var cached cachedConfig
+	if err := json.Unmarshal(raw, &cached); err != nil {
+		return nil, fmt.Errorf("decoding config cache: %w", err)
+	}
+	if cached.Config == nil {
+		return nil, fmt.Errorf("config cache %s has no config", c.path)
+	}
+
+	checksum, err := Checksum(cached.Config)
+	if err != nil {
+		return nil, err
+	}
+	if checksum != cached.Checksum || cached.Version != cached.Config.Version {
+		return nil, fmt.Errorf("config cache %s failed integrity check", c.path)
+	}
+
+	return cached.Config, nil
+}
+
+func (c *ConfigCache) Save(cfg *entity.Config) error {
+	checksum, err := Checksum(cfg)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(cachedConfig{
+		Version:  cfg.Version,
+		Checksum: checksum,
+		SavedAt:  time.Now(),
+		Config:   cfg,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding config cache: %w", err)
+	}
+
+	return WriteAtomic(c.path, raw, 0o600)
+}
+
+func Checksum(cfg *entity.Config) (string, error) {
+	raw, err := json.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("encoding config: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+func WriteAtomic(path string, data []byte, perm os.FileMode) error {
+	return WriteAtomicChecked(path, data, perm, nil)
+}
+
+func WriteAtomicChecked(path string, data []byte, perm os.FileMode, check func(stagedPath string) error) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("setting permissions: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("syncing temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+
+	if check != nil {
+		if err := check(tmpName); err != nil {
+			return fmt.Errorf("check failed, keeping %s unchanged: %w", path, err)
+		}
+	}
+
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("replacing %s: %w", path, err)
+	}
+	return nil
+}
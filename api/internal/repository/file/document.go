@@ -0,0 +1,37 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/pkg/configformat"
+)
+
+func LoadHookDefinitions(path string) ([]entity.HookDefinition, error) {
+	var doc struct {
+		Hooks []entity.HookDefinition `json:"hooks"`
+	}
+	if err := loadDocument(path, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Hooks, nil
+}
+
+func loadDocument(path string, v any) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	format, err := configformat.FromExtension(filepath.Ext(path))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := configformat.Unmarshal(format, raw, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package file
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/adityawiryaa/api/pkg/configformat"
+)
+
+type OverrideFile struct {
+	path string
+}
+
+func NewOverrideFile(path string) *OverrideFile {
+	return &OverrideFile{path: path}
+}
+
+func (f *OverrideFile) Load() (map[string]string, error) {
+	if _, err := os.Stat(f.path); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	var doc map[string]any
+	if err := loadDocument(f.path, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc) == 0 {
+		return nil, nil
+	}
+	overrides := make(map[string]string, len(doc))
+	for k, v := range doc {
+		overrides[k] = configformat.Stringify(v)
+	}
+	return overrides, nil
+}
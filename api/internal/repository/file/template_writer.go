@@ -0,0 +1,189 @@
+package file
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/pkg/shellcmd"
+)
+
+const defaultTemplateMode = 0o644
+
+type templateFile struct {
+	resource entity.TemplateResource
+	mode     os.FileMode
+}
+
+type templateData struct {
+	Version int64
+	Data    map[string]string
+}
+
+type TemplateWriter struct {
+	files          []templateFile
+	commandTimeout time.Duration
+}
+
+func LoadTemplateResources(path string) ([]entity.TemplateResource, error) {
+	var doc struct {
+		Templates []entity.TemplateResource `json:"templates"`
+	}
+	if err := loadDocument(path, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Templates, nil
+}
+
+func NewTemplateWriter(resources []entity.TemplateResource, commandTimeout time.Duration) (*TemplateWriter, error) {
+	files := make([]templateFile, 0, len(resources))
+	for i, resource := range resources {
+		if resource.Src == "" || resource.Dest == "" {
+			return nil, fmt.Errorf("template %d: src and dest are required", i)
+		}
+
+		mode := os.FileMode(defaultTemplateMode)
+		if resource.Mode != "" {
+			parsed, err := strconv.ParseUint(resource.Mode, 8, 32)
+			if err != nil || parsed > 0o777 {
+				return nil, fmt.Errorf("template %s: invalid mode %q", resource.Dest, resource.Mode)
+			}
+			mode = os.FileMode(parsed)
+		}
+
+		if _, err := parseTemplate(resource.Src, templateData{}); err != nil {
+			return nil, fmt.Errorf("template %s: %w", resource.Dest, err)
+		}
+		files = append(files, templateFile{resource: resource, mode: mode})
+	}
+	return &TemplateWriter{files: files, commandTimeout: commandTimeout}, nil
+}
+
+func (w *TemplateWriter) Render(ctx context.Context, cfg *entity.Config) error {
+	data := templateData{Version: cfg.Version, Data: cfg.Data}
+	var errs []error
+	for _, file := range w.files {
+		if err := w.render(ctx, file, data); err != nil {
+			errs = append(errs, fmt.Errorf("template %s: %w", file.resource.Dest, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (w *TemplateWriter) render(ctx context.Context, file templateFile, data templateData) error {
+	tmpl, err := parseTemplate(file.resource.Src, data)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("rendering %s: %w", file.resource.Src, err)
+	}
+
+	if unchanged(file.resource.Dest, buf.Bytes(), file.mode) {
+		return nil
+	}
+
+	var check func(string) error
+	if file.resource.CheckCmd != "" {
+		check = func(stagedPath string) error {
+			_, err := shellcmd.Run(ctx, expandCommand(file.resource.CheckCmd, stagedPath, file.resource.Dest), w.commandTimeout, nil)
+			return err
+		}
+	}
+	if err := WriteAtomicChecked(file.resource.Dest, buf.Bytes(), file.mode, check); err != nil {
+		return err
+	}
+	log.Printf("rendered %s from %s (config version %d)", file.resource.Dest, file.resource.Src, data.Version)
+
+	if file.resource.ReloadCmd != "" {
+		if _, err := shellcmd.Run(ctx, expandCommand(file.resource.ReloadCmd, file.resource.Dest, file.resource.Dest), w.commandTimeout, nil); err != nil {
+			return fmt.Errorf("reload: %w", err)
+		}
+	}
+	return nil
+}
+
+func parseTemplate(src string, data templateData) (*template.Template, error) {
+	raw, err := os.ReadFile(src)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", src, err)
+	}
+
+	tmpl, err := template.New(filepath.Base(src)).
+		Option("missingkey=error").
+		Funcs(templateFuncs(data.Data)).
+		Parse(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", src, err)
+	}
+	return tmpl, nil
+}
+
+func templateFuncs(data map[string]string) template.FuncMap {
+	return template.FuncMap{
+		"getv": func(key string, fallback ...string) (string, error) {
+			if value, ok := data[key]; ok {
+				return value, nil
+			}
+			if len(fallback) > 0 {
+				return fallback[0], nil
+			}
+			return "", fmt.Errorf("key %q not found", key)
+		},
+		"exists": func(key string) bool {
+			_, ok := data[key]
+			return ok
+		},
+		"keys": func(prefix ...string) []string {
+			var keys []string
+			for key := range data {
+				if len(prefix) == 0 || strings.HasPrefix(key, prefix[0]) {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			return keys
+		},
+		"fromJSON": func(value string) (any, error) {
+			var v any
+			if err := json.Unmarshal([]byte(value), &v); err != nil {
+				return nil, fmt.Errorf("decoding JSON: %w", err)
+			}
+			return v, nil
+		},
+		"getenv":    os.Getenv,
+		"split":     strings.Split,
+		"join":      strings.Join,
+		"toUpper":   strings.ToUpper,
+		"toLower":   strings.ToLower,
+		"trimSpace": strings.TrimSpace,
+		"replace":   strings.ReplaceAll,
+		"hasPrefix": strings.HasPrefix,
+	}
+}
+
+func unchanged(path string, content []byte, mode os.FileMode) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != mode.Perm() {
+		return false
+	}
+	existing, err := os.ReadFile(path)
+	return err == nil && bytes.Equal(existing, content)
+}
+
+func expandCommand(command, src, dest string) string {
+	return strings.NewReplacer("{{.src}}", src, "{{.dest}}", dest).Replace(command)
+}
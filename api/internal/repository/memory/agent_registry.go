@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+)
+
+type AgentRegistry struct {
+	mu     sync.RWMutex
+	agents map[string]*entity.Agent
+}
+
+func NewAgentRegistry() *AgentRegistry {
+	return &AgentRegistry{agents: make(map[string]*entity.Agent)}
+}
+
+func (r *AgentRegistry) Save(_ context.Context, agent *entity.Agent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	saved := *agent
+	r.agents[agent.ID] = &saved
+	return nil
+}
+
+func (r *AgentRegistry) SaveReport(_ context.Context, id string, report *entity.AgentReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	agent, ok := r.agents[id]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	saved := *agent
+	saved.Report = report
+	saved.UpdatedAt = report.ReportedAt
+	r.agents[id] = &saved
+	return nil
+}
+
+func (r *AgentRegistry) FindByID(_ context.Context, id string) (*entity.Agent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	agent, ok := r.agents[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return agent, nil
+}
+
+func (r *AgentRegistry) ListAgents(_ context.Context) ([]*entity.Agent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]*entity.Agent, 0, len(r.agents))
+	for _, agent := range r.agents {
+		result = append(result, agent)
+	}
+	slices.SortFunc(result, func(a, b *entity.Agent) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), strings.Compare(a.ID, b.ID))
+	})
+	return result, nil
+}
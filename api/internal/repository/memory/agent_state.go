@@ -0,0 +1,187 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/valueobject"
+)
+
+const maxRecentErrors = 50
+
+type AgentState struct {
+	mu           sync.RWMutex
+	registration entity.RegistrationStatus
+	identity     entity.AgentIdentity
+	poll         entity.PollStatus
+	errors       []entity.AgentError
+	hooks        map[string]*entity.HookStatus
+	invalidated  chan struct{}
+}
+
+func NewAgentState() *AgentState {
+	return &AgentState{
+		hooks:       make(map[string]*entity.HookStatus),
+		invalidated: make(chan struct{}, 1),
+	}
+}
+
+func (s *AgentState) AgentID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.registration.AgentID
+}
+
+func (s *AgentState) Identity() entity.AgentIdentity {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.identity
+}
+
+func (s *AgentState) SetIdentity(identity entity.AgentIdentity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.identity = identity
+}
+
+func (s *AgentState) IsRegistered() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.registration.Registered
+}
+
+func (s *AgentState) SetRegistered(agentID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.registration = entity.RegistrationStatus{
+		AgentID:      agentID,
+		Registered:   true,
+		RegisteredAt: time.Now(),
+	}
+}
+
+func (s *AgentState) SetRegistrationError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.registration.Attempts++
+	s.registration.LastError = err.Error()
+	s.appendError("registration", err)
+}
+
+func (s *AgentState) Invalidate(agentID string, reason string) {
+	s.mu.Lock()
+	if !s.registration.Registered || s.registration.AgentID != agentID {
+		s.mu.Unlock()
+		return
+	}
+	s.registration.Registered = false
+	s.registration.LastError = reason
+	s.registration.Attempts = 0
+	s.mu.Unlock()
+
+	select {
+	case s.invalidated <- struct{}{}:
+	default:
+	}
+}
+
+func (s *AgentState) Invalidated() <-chan struct{} {
+	return s.invalidated
+}
+
+func (s *AgentState) Registration() entity.RegistrationStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.registration
+}
+
+func (s *AgentState) RecordPoll(changed bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.poll.LastPollAt = now
+	s.poll.Polls++
+	switch {
+	case err != nil:
+		s.poll.LastResult = valueobject.PollResultError
+		s.poll.LastError = err.Error()
+		s.poll.Failures++
+		s.appendError("poll", err)
+	case changed:
+		s.poll.LastResult = valueobject.PollResultChanged
+		s.poll.LastError = ""
+		s.poll.LastChangeAt = now
+	default:
+		s.poll.LastResult = valueobject.PollResultUnchanged
+		s.poll.LastError = ""
+	}
+}
+
+func (s *AgentState) SetPollInterval(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.poll.Interval = interval
+}
+
+func (s *AgentState) Poll() entity.PollStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.poll
+}
+
+func (s *AgentState) RecordError(source string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appendError(source, err)
+}
+
+func (s *AgentState) RecentErrors() []entity.AgentError {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make([]entity.AgentError, len(s.errors))
+	for i, e := range s.errors {
+		result[len(s.errors)-1-i] = e
+	}
+	return result
+}
+
+func (s *AgentState) UpdateHook(name string, fn func(status *entity.HookStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status, ok := s.hooks[name]
+	if !ok {
+		status = &entity.HookStatus{Name: name}
+		s.hooks[name] = status
+	}
+	fn(status)
+}
+
+func (s *AgentState) Hook(name string) entity.HookStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if status, ok := s.hooks[name]; ok {
+		return *status
+	}
+	return entity.HookStatus{Name: name}
+}
+
+func (s *AgentState) Hooks() []entity.HookStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make([]entity.HookStatus, 0, len(s.hooks))
+	for _, status := range s.hooks {
+		result = append(result, *status)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func (s *AgentState) appendError(source string, err error) {
+	s.errors = append(s.errors, entity.AgentError{Source: source, Message: err.Error(), At: time.Now()})
+	if len(s.errors) > maxRecentErrors {
+		s.errors = s.errors[len(s.errors)-maxRecentErrors:]
+	}
+}
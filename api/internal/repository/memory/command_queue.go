@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/valueobject"
+)
+
+type CommandQueue struct {
+	mu       sync.RWMutex
+	commands []*entity.AgentCommand
+}
+
+func NewCommandQueue() *CommandQueue {
+	return &CommandQueue{}
+}
+
+func (q *CommandQueue) Enqueue(_ context.Context, cmd *entity.AgentCommand) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	saved := *cmd
+	q.commands = append(q.commands, &saved)
+	return nil
+}
+
+func (q *CommandQueue) MarkDelivered(_ context.Context, agentID string, at time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, cmd := range q.commands {
+		if cmd.AgentID == agentID && cmd.Status == valueobject.CommandStatusPending {
+			delivered := *cmd
+			delivered.Status = valueobject.CommandStatusDelivered
+			delivered.DeliveredAt = at
+			q.commands[i] = &delivered
+		}
+	}
+	return nil
+}
+
+func (q *CommandQueue) Complete(_ context.Context, cmd *entity.AgentCommand) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, existing := range q.commands {
+		if existing.ID == cmd.ID && existing.AgentID == cmd.AgentID {
+			completed := *existing
+			completed.Status = cmd.Status
+			completed.Result = cmd.Result
+			completed.Error = cmd.Error
+			completed.CompletedAt = cmd.CompletedAt
+			q.commands[i] = &completed
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (q *CommandQueue) FindByID(_ context.Context, agentID, id string) (*entity.AgentCommand, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	for _, cmd := range q.commands {
+		if cmd.ID == id && cmd.AgentID == agentID {
+			return cmd, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (q *CommandQueue) ListByAgent(_ context.Context, agentID, status string) ([]*entity.AgentCommand, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	var result []*entity.AgentCommand
+	for _, cmd := range q.commands {
+		if cmd.AgentID == agentID && (status == "" || cmd.Status == status) {
+			result = append(result, cmd)
+		}
+	}
+	return result, nil
+}
+
+func (q *CommandQueue) CountOutstanding(_ context.Context, agentID string) (int, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	count := 0
+	for _, cmd := range q.commands {
+		if cmd.AgentID == agentID && (cmd.Status == valueobject.CommandStatusPending || cmd.Status == valueobject.CommandStatusDelivered) {
+			count++
+		}
+	}
+	return count, nil
+}
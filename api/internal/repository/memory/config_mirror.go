@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+)
+
+type ConfigMirror struct {
+	store   *ConfigStore
+	depth   int
+	mu      sync.Mutex
+	history []*entity.Config
+}
+
+func NewConfigMirror(store *ConfigStore, depth int) *ConfigMirror {
+	return &ConfigMirror{store: store, depth: max(depth, 1)}
+}
+
+func (m *ConfigMirror) SaveConfig(context.Context, *entity.Config) error {
+	return repository.ErrReadOnly
+}
+
+func (m *ConfigMirror) GetLatestConfig(context.Context) (*entity.Config, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sync() == nil {
+		return nil, repository.ErrNotFound
+	}
+	return m.history[len(m.history)-1], nil
+}
+
+func (m *ConfigMirror) GetConfigByVersion(_ context.Context, version int64) (*entity.Config, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sync()
+	for _, cfg := range m.history {
+		if cfg.Version == version {
+			return cfg, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *ConfigMirror) ListConfigs(context.Context) ([]*entity.Config, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sync()
+	return slices.Clone(m.history), nil
+}
+
+func (m *ConfigMirror) sync() *entity.Config {
+	current := m.store.Get()
+	if current == nil {
+		return nil
+	}
+	if n := len(m.history); n > 0 && m.history[n-1].Version == current.Version {
+		return current
+	}
+	m.history = slices.DeleteFunc(m.history, func(cfg *entity.Config) bool { return cfg.Version >= current.Version })
+	m.history = append(m.history, current)
+	if len(m.history) > m.depth {
+		m.history = m.history[len(m.history)-m.depth:]
+	}
+	return current
+}
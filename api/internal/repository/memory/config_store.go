@@ -1,14 +1,16 @@
 package memory
 
 import (
+	"maps"
 	"sync"
 
 	"github.com/adityawiryaa/api/domain/entity"
 )
 
 type ConfigStore struct {
-	mu     sync.RWMutex
-	config *entity.Config
+	mu        sync.RWMutex
+	config    *entity.Config
+	overrides map[string]string
 }
 
 func NewConfigStore() *ConfigStore {
@@ -27,6 +29,35 @@ func (s *ConfigStore) Set(cfg *entity.Config) {
 	s.config = cfg
 }
 
+func (s *ConfigStore) SetOverrides(overrides map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides = maps.Clone(overrides)
+}
+
+func (s *ConfigStore) Overrides() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return maps.Clone(s.overrides)
+}
+
+func (s *ConfigStore) Effective() *entity.Config {
+	return s.Merge(s.Get())
+}
+
+func (s *ConfigStore) Merge(cfg *entity.Config) *entity.Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if cfg == nil || len(s.overrides) == 0 {
+		return cfg
+	}
+	merged := *cfg
+	merged.Data = make(map[string]string, len(cfg.Data)+len(s.overrides))
+	maps.Copy(merged.Data, cfg.Data)
+	maps.Copy(merged.Data, s.overrides)
+	return &merged
+}
+
 func (s *ConfigStore) Version() int64 {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+)
+
+type poolEntry struct {
+	endpoint entity.WorkerEndpoint
+	client   usecases.WorkerClient
+}
+
+type WorkerPool struct {
+	mu        sync.RWMutex
+	newClient func(url string) usecases.WorkerClient
+	ttl       time.Duration
+	entries   map[string]*poolEntry
+}
+
+func NewWorkerPool(newClient func(url string) usecases.WorkerClient, ttl time.Duration) *WorkerPool {
+	return &WorkerPool{
+		newClient: newClient,
+		ttl:       ttl,
+		entries:   make(map[string]*poolEntry),
+	}
+}
+
+func (p *WorkerPool) Add(url string, source string) (usecases.WorkerClient, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if entry, ok := p.entries[url]; ok {
+		entry.endpoint.LastSeenAt = now
+		if source == valueobject.WorkerSourceStatic {
+			entry.endpoint.Source = source
+		}
+		return entry.client, false
+	}
+
+	entry := &poolEntry{
+		endpoint: entity.WorkerEndpoint{
+			URL:          url,
+			Source:       source,
+			RegisteredAt: now,
+			LastSeenAt:   now,
+		},
+		client: p.newClient(url),
+	}
+	p.entries[url] = entry
+	return entry.client, true
+}
+
+func (p *WorkerPool) Remove(url string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.entries[url]
+	if !ok || entry.endpoint.Source == valueobject.WorkerSourceStatic {
+		return false
+	}
+	delete(p.entries, url)
+	return true
+}
+
+func (p *WorkerPool) SetDrained(url string, drained bool) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	entry, ok := p.entries[url]
+	if !ok {
+		return false
+	}
+	entry.endpoint.Drained = drained
+	return true
+}
+
+func (p *WorkerPool) Expire(now time.Time) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var expired []string
+	for url, entry := range p.entries {
+		if entry.endpoint.Source == valueobject.WorkerSourceRegistered && now.Sub(entry.endpoint.LastSeenAt) > p.ttl {
+			delete(p.entries, url)
+			expired = append(expired, url)
+		}
+	}
+	sort.Strings(expired)
+	return expired
+}
+
+func (p *WorkerPool) Clients() []usecases.WorkerClient {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	urls := p.sortedURLs()
+	clients := make([]usecases.WorkerClient, 0, len(urls))
+	for _, url := range urls {
+		if entry := p.entries[url]; !entry.endpoint.Drained {
+			clients = append(clients, entry.client)
+		}
+	}
+	return clients
+}
+
+func (p *WorkerPool) Endpoints() []entity.WorkerEndpoint {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	urls := p.sortedURLs()
+	endpoints := make([]entity.WorkerEndpoint, 0, len(urls))
+	for _, url := range urls {
+		endpoints = append(endpoints, p.entries[url].endpoint)
+	}
+	return endpoints
+}
+
+func (p *WorkerPool) sortedURLs() []string {
+	urls := make([]string, 0, len(p.entries))
+	for url := range p.entries {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	return urls
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+type WorkerStatusStore struct {
+	mu       sync.RWMutex
+	statuses map[string]*entity.WorkerStatus
+}
+
+func NewWorkerStatusStore() *WorkerStatusStore {
+	return &WorkerStatusStore{
+		statuses: make(map[string]*entity.WorkerStatus),
+	}
+}
+
+func (s *WorkerStatusStore) Get(url string) entity.WorkerStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if status, ok := s.statuses[url]; ok {
+		return *status
+	}
+	return entity.WorkerStatus{URL: url}
+}
+
+func (s *WorkerStatusStore) Update(url string, fn func(status *entity.WorkerStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status, ok := s.statuses[url]
+	if !ok {
+		status = &entity.WorkerStatus{URL: url}
+		s.statuses[url] = status
+	}
+	fn(status)
+}
+
+func (s *WorkerStatusStore) List() []entity.WorkerStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make([]entity.WorkerStatus, 0, len(s.statuses))
+	for _, status := range s.statuses {
+		result = append(result, *status)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].URL < result[j].URL })
+	return result
+}
+
+func (s *WorkerStatusStore) Delete(url string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.statuses, url)
+}
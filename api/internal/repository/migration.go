@@ -1,30 +1,235 @@
 package repository
 
-import "database/sql"
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationState struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
 
 func Migrate(db *sql.DB) error {
-	queries := []string{
-		`CREATE TABLE IF NOT EXISTS agents (
-			id TEXT PRIMARY KEY,
-			hostname TEXT NOT NULL,
-			ip_address TEXT NOT NULL,
-			port INTEGER NOT NULL,
-			status TEXT NOT NULL DEFAULT 'active',
-			created_at DATETIME NOT NULL,
-			updated_at DATETIME NOT NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS configs (
-			id TEXT PRIMARY KEY,
-			version INTEGER NOT NULL UNIQUE,
-			data TEXT NOT NULL,
-			poll_interval_seconds INTEGER NOT NULL DEFAULT 30,
-			created_at DATETIME NOT NULL
-		)`,
-	}
-	for _, q := range queries {
-		if _, err := db.Exec(q); err != nil {
+	return MigrateUp(db)
+}
+
+func MigrateUp(db *sql.DB) error {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return err
+	}
+	if err := ensureMigrationTable(db); err != nil {
+		return err
+	}
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		if err := runInTx(db, m.Up, func(tx *sql.Tx) error {
+			_, err := tx.Exec(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+				m.Version, m.Name, time.Now())
+			return err
+		}); err != nil {
+			return fmt.Errorf("applying migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+	}
+	return nil
+}
+
+func MigrateDown(db *sql.DB, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive")
+	}
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return err
+	}
+	if err := ensureMigrationTable(db); err != nil {
+		return err
+	}
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return err
+	}
+
+	for i := len(migrations) - 1; i >= 0 && steps > 0; i-- {
+		m := migrations[i]
+		if _, ok := applied[m.Version]; !ok {
+			continue
+		}
+		if err := runInTx(db, m.Down, func(tx *sql.Tx) error {
+			_, err := tx.Exec(`DELETE FROM schema_migrations WHERE version = ?`, m.Version)
 			return err
+		}); err != nil {
+			return fmt.Errorf("reverting migration %04d_%s: %w", m.Version, m.Name, err)
 		}
+		steps--
 	}
 	return nil
 }
+
+func MigrationStatus(db *sql.DB) ([]MigrationState, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureMigrationTable(db); err != nil {
+		return nil, err
+	}
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]MigrationState, 0, len(migrations))
+	for _, m := range migrations {
+		state := MigrationState{Version: m.Version, Name: m.Name}
+		if at, ok := applied[m.Version]; ok {
+			state.Applied = true
+			state.AppliedAt = &at
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+func LoadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("reading migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		version, name, direction, err := parseMigrationName(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		content, err := migrationFS.ReadFile("migrations/" + e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("reading migration %s: %w", e.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration %04d has conflicting names %q and %q", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+func parseMigrationName(filename string) (int64, string, string, error) {
+	base, ok := strings.CutSuffix(filename, ".sql")
+	if !ok {
+		return 0, "", "", fmt.Errorf("invalid migration file %q", filename)
+	}
+
+	var direction string
+	switch {
+	case strings.HasSuffix(base, ".up"):
+		direction = "up"
+	case strings.HasSuffix(base, ".down"):
+		direction = "down"
+	default:
+		return 0, "", "", fmt.Errorf("migration file %q must end in .up.sql or .down.sql", filename)
+	}
+	base = strings.TrimSuffix(base, "."+direction)
+
+	versionStr, name, ok := strings.Cut(base, "_")
+	if !ok {
+		return 0, "", "", fmt.Errorf("migration file %q must be named <version>_<name>", filename)
+	}
+	version, err := strconv.ParseInt(versionStr, 10, 64)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("migration file %q has invalid version: %w", filename, err)
+	}
+	return version, name, direction, nil
+}
+
+func ensureMigrationTable(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at DATETIME NOT NULL
+	)`)
+	return err
+}
+
+func appliedVersions(db *sql.DB) (map[int64]time.Time, error) {
+	rows, err := db.Query(`SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+func runInTx(db *sql.DB, script string, record func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(script); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := record(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
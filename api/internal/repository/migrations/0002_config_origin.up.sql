@@ -0,0 +1 @@
+ALTER TABLE configs ADD COLUMN origin TEXT NOT NULL DEFAULT 'api';
@@ -0,0 +1 @@
+ALTER TABLE agents ADD COLUMN report TEXT;
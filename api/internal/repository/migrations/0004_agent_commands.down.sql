@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_agent_commands_agent_status;
+DROP TABLE IF EXISTS agent_commands;
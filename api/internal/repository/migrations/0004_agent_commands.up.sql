@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS agent_commands (
+	id TEXT PRIMARY KEY,
+	agent_id TEXT NOT NULL,
+	type TEXT NOT NULL,
+	args TEXT,
+	status TEXT NOT NULL DEFAULT 'pending',
+	result TEXT,
+	error TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL,
+	delivered_at DATETIME,
+	completed_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_agent_commands_agent_status ON agent_commands (agent_id, status, created_at);
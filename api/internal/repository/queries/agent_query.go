@@ -3,8 +3,12 @@ package queries
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
 )
 
 type AgentQuery struct {
@@ -16,12 +20,53 @@ func NewAgentQuery(db *sql.DB) *AgentQuery {
 }
 
 func (r *AgentQuery) FindByID(ctx context.Context, id string) (*entity.Agent, error) {
-	agent := &entity.Agent{}
-	err := r.db.QueryRowContext(ctx,
-		`SELECT id, hostname, ip_address, port, status, created_at, updated_at FROM agents WHERE id = ?`, id,
-	).Scan(&agent.ID, &agent.Hostname, &agent.IPAddress, &agent.Port, &agent.Status, &agent.CreatedAt, &agent.UpdatedAt)
+	agent, err := scanAgent(r.db.QueryRowContext(ctx,
+		`SELECT id, hostname, ip_address, port, status, report, created_at, updated_at FROM agents WHERE id = ?`, id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 	return agent, nil
 }
+
+func (r *AgentQuery) ListAgents(ctx context.Context) ([]*entity.Agent, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, hostname, ip_address, port, status, report, created_at, updated_at FROM agents ORDER BY created_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var agents []*entity.Agent
+	for rows.Next() {
+		agent, err := scanAgent(rows)
+		if err != nil {
+			return nil, err
+		}
+		agents = append(agents, agent)
+	}
+	return agents, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAgent(row rowScanner) (*entity.Agent, error) {
+	agent := &entity.Agent{}
+	var report sql.NullString
+	if err := row.Scan(&agent.ID, &agent.Hostname, &agent.IPAddress, &agent.Port, &agent.Status, &report, &agent.CreatedAt, &agent.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if report.Valid && report.String != "" {
+		agent.Report = &entity.AgentReport{}
+		if err := json.Unmarshal([]byte(report.String), agent.Report); err != nil {
+			return nil, fmt.Errorf("decoding report for agent %s: %w", agent.ID, err)
+		}
+	}
+	return agent, nil
+}
@@ -0,0 +1,20 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+)
+
+type BundleQuery struct {
+	db *sql.DB
+}
+
+func NewBundleQuery(db *sql.DB) *BundleQuery {
+	return &BundleQuery{db: db}
+}
+
+func (r *BundleQuery) SchemaVersion(ctx context.Context) (int64, error) {
+	var version int64
+	err := r.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
@@ -0,0 +1,92 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/valueobject"
+)
+
+const agentCommandColumns = `id, agent_id, type, args, status, result, error, created_at, delivered_at, completed_at`
+
+type CommandQueueQuery struct {
+	db *sql.DB
+}
+
+func NewCommandQueueQuery(db *sql.DB) *CommandQueueQuery {
+	return &CommandQueueQuery{db: db}
+}
+
+func (r *CommandQueueQuery) FindByID(ctx context.Context, agentID, id string) (*entity.AgentCommand, error) {
+	cmd, err := scanAgentCommand(r.db.QueryRowContext(ctx,
+		`SELECT `+agentCommandColumns+` FROM agent_commands WHERE id = ? AND agent_id = ?`, id, agentID,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func (r *CommandQueueQuery) ListByAgent(ctx context.Context, agentID, status string) ([]*entity.AgentCommand, error) {
+	query := `SELECT ` + agentCommandColumns + ` FROM agent_commands WHERE agent_id = ?`
+	args := []any{agentID}
+	if status != "" {
+		query += ` AND status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cmds []*entity.AgentCommand
+	for rows.Next() {
+		cmd, err := scanAgentCommand(rows)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds, rows.Err()
+}
+
+func (r *CommandQueueQuery) CountOutstanding(ctx context.Context, agentID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM agent_commands WHERE agent_id = ? AND status IN (?, ?)`,
+		agentID, valueobject.CommandStatusPending, valueobject.CommandStatusDelivered,
+	).Scan(&count)
+	return count, err
+}
+
+func scanAgentCommand(row rowScanner) (*entity.AgentCommand, error) {
+	cmd := &entity.AgentCommand{}
+	var args, result sql.NullString
+	var deliveredAt, completedAt sql.NullTime
+	if err := row.Scan(&cmd.ID, &cmd.AgentID, &cmd.Type, &args, &cmd.Status, &result, &cmd.Error,
+		&cmd.CreatedAt, &deliveredAt, &completedAt); err != nil {
+		return nil, err
+	}
+	if args.Valid && args.String != "" {
+		if err := json.Unmarshal([]byte(args.String), &cmd.Args); err != nil {
+			return nil, fmt.Errorf("decoding args for command %s: %w", cmd.ID, err)
+		}
+	}
+	if result.Valid && result.String != "" {
+		cmd.Result = json.RawMessage(result.String)
+	}
+	cmd.DeliveredAt = deliveredAt.Time
+	cmd.CompletedAt = completedAt.Time
+	return cmd, nil
+}
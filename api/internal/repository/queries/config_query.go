@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
 )
 
 type ConfigQuery struct {
@@ -20,8 +22,11 @@ func (r *ConfigQuery) GetLatestConfig(ctx context.Context) (*entity.Config, erro
 	cfg := &entity.Config{}
 	var data string
 	err := r.db.QueryRowContext(ctx,
-		`SELECT id, version, data, poll_interval_seconds, created_at FROM configs ORDER BY version DESC LIMIT 1`,
-	).Scan(&cfg.ID, &cfg.Version, &data, &cfg.PollIntervalSeconds, &cfg.CreatedAt)
+		`SELECT id, version, data, poll_interval_seconds, origin, created_at FROM configs ORDER BY version DESC LIMIT 1`,
+	).Scan(&cfg.ID, &cfg.Version, &data, &cfg.PollIntervalSeconds, &cfg.Origin, &cfg.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -35,8 +40,11 @@ func (r *ConfigQuery) GetConfigByVersion(ctx context.Context, version int64) (*e
 	cfg := &entity.Config{}
 	var data string
 	err := r.db.QueryRowContext(ctx,
-		`SELECT id, version, data, poll_interval_seconds, created_at FROM configs WHERE version = ?`, version,
-	).Scan(&cfg.ID, &cfg.Version, &data, &cfg.PollIntervalSeconds, &cfg.CreatedAt)
+		`SELECT id, version, data, poll_interval_seconds, origin, created_at FROM configs WHERE version = ?`, version,
+	).Scan(&cfg.ID, &cfg.Version, &data, &cfg.PollIntervalSeconds, &cfg.Origin, &cfg.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, repository.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -45,3 +53,27 @@ func (r *ConfigQuery) GetConfigByVersion(ctx context.Context, version int64) (*e
 	}
 	return cfg, nil
 }
+
+func (r *ConfigQuery) ListConfigs(ctx context.Context) ([]*entity.Config, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, version, data, poll_interval_seconds, origin, created_at FROM configs ORDER BY version`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var configs []*entity.Config
+	for rows.Next() {
+		cfg := &entity.Config{}
+		var data string
+		if err := rows.Scan(&cfg.ID, &cfg.Version, &data, &cfg.PollIntervalSeconds, &cfg.Origin, &cfg.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(data), &cfg.Data); err != nil {
+			return nil, err
+		}
+		configs = append(configs, cfg)
+	}
+	return configs, rows.Err()
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+func (c *commandUsecase) ApplyConfig(ctx context.Context) error {
+	if cfg := c.store.Get(); cfg != nil {
+		c.persist(cfg)
+	}
+
+	var forwardErr error
+	if (c.templates == nil && c.hooks == nil) || len(c.pool.Clients()) > 0 {
+		forwardErr = c.ForwardConfigToWorkers(ctx)
+	}
+	return errors.Join(forwardErr, c.RenderTemplates(ctx), c.RunHooks(ctx))
+}
+
+func (c *commandUsecase) persist(cfg *entity.Config) {
+	if c.cache == nil {
+		return
+	}
+	if err := c.cache.Save(cfg); err != nil {
+		c.state.RecordError("cache", err)
+		log.Printf("failed to persist config version %d: %v", cfg.Version, err)
+	}
+}
+
+func (c *commandUsecase) RenderTemplates(ctx context.Context) error {
+	cfg := c.store.Effective()
+	if c.templates == nil || cfg == nil {
+		return nil
+	}
+
+	if err := c.templates.Render(ctx, cfg); err != nil {
+		c.state.RecordError("template", err)
+		log.Printf("template error: %v", err)
+		return err
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"github.com/adityawiryaa/api/domain/repository"
 	"github.com/adityawiryaa/api/domain/usecases"
 	"github.com/adityawiryaa/api/internal/repository/memory"
 	"github.com/adityawiryaa/api/pkg/backoff"
@@ -8,21 +9,48 @@ import (
 
 type commandUsecase struct {
 	controllerClient usecases.ControllerClient
-	workerClient     usecases.WorkerClient
+	pool             *memory.WorkerPool
 	store            *memory.ConfigStore
+	state            *memory.AgentState
+	workers          *memory.WorkerStatusStore
+	cache            usecases.ConfigCache
+	templates        usecases.TemplateRenderer
+	hooks            usecases.HookRunner
+	overrides        usecases.OverrideSource
+	bundles          usecases.BundleInbox
+	verifier         usecases.BundleVerifier
+	downstream       repository.AgentRepositoryQuery
 	backoffCfg       backoff.Config
 }
 
 func NewCommandUsecase(
 	controllerClient usecases.ControllerClient,
-	workerClient usecases.WorkerClient,
+	pool *memory.WorkerPool,
 	store *memory.ConfigStore,
+	state *memory.AgentState,
+	workers *memory.WorkerStatusStore,
+	cache usecases.ConfigCache,
+	templates usecases.TemplateRenderer,
+	hooks usecases.HookRunner,
+	overrides usecases.OverrideSource,
+	bundles usecases.BundleInbox,
+	verifier usecases.BundleVerifier,
+	downstream repository.AgentRepositoryQuery,
 	backoffCfg backoff.Config,
 ) usecases.UsecaseAgentCommand {
 	return &commandUsecase{
 		controllerClient: controllerClient,
-		workerClient:     workerClient,
+		pool:             pool,
 		store:            store,
+		state:            state,
+		workers:          workers,
+		cache:            cache,
+		templates:        templates,
+		hooks:            hooks,
+		overrides:        overrides,
+		bundles:          bundles,
+		verifier:         verifier,
+		downstream:       downstream,
 		backoffCfg:       backoffCfg,
 	}
 }
@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+)
+
+func (c *commandUsecase) ExecuteCommands(ctx context.Context) error {
+	if !c.state.IsRegistered() {
+		return nil
+	}
+	agentID := c.state.AgentID()
+
+	cmds, err := c.controllerClient.ClaimCommands(ctx, agentID)
+	if err != nil {
+		if errors.Is(err, usecases.ErrAgentNotFound) {
+			c.state.Invalidate(agentID, err.Error())
+		}
+		return err
+	}
+
+	var errs []error
+	reregister := false
+	for _, cmd := range cmds {
+		log.Printf("executing command %s (%s)", cmd.ID, cmd.Type)
+		result, execErr := c.executeCommand(ctx, agentID, cmd)
+
+		req := &request.CommandResultRequest{
+			Status: valueobject.CommandStatusSucceeded,
+			Result: result,
+		}
+		if execErr != nil {
+			req.Status = valueobject.CommandStatusFailed
+			req.Error = execErr.Error()
+			c.state.RecordError("command", fmt.Errorf("%s %s: %w", cmd.Type, cmd.ID, execErr))
+		} else if cmd.Type == valueobject.CommandReregister {
+			reregister = true
+		}
+
+		if err := c.controllerClient.CompleteCommand(ctx, agentID, cmd.ID, req); err != nil {
+			errs = append(errs, fmt.Errorf("acknowledging command %s: %w", cmd.ID, err))
+		}
+	}
+
+	if reregister {
+		c.state.Invalidate(agentID, "re-registration requested by controller")
+	}
+	return errors.Join(errs...)
+}
+
+func (c *commandUsecase) executeCommand(ctx context.Context, agentID string, cmd entity.AgentCommand) (json.RawMessage, error) {
+	switch cmd.Type {
+	case valueobject.CommandReload:
+		if err := c.ForwardConfigToWorkers(ctx); err != nil {
+			return nil, err
+		}
+		return commandResult(map[string]any{"version": c.store.Version(), "workers": len(c.pool.Clients())})
+
+	case valueobject.CommandDrain, valueobject.CommandUndrain:
+		workerURL := strings.TrimRight(cmd.Args["url"], "/")
+		drained := cmd.Type == valueobject.CommandDrain
+		if !c.pool.SetDrained(workerURL, drained) {
+			return nil, fmt.Errorf("%w: %s", usecases.ErrWorkerNotFound, workerURL)
+		}
+		log.Printf("worker %s drained = %v", workerURL, drained)
+		return commandResult(map[string]any{"url": workerURL, "drained": drained})
+
+	case valueobject.CommandFlush:
+		endpoints := c.pool.Endpoints()
+		for _, endpoint := range endpoints {
+			c.workers.Update(endpoint.URL, func(status *entity.WorkerStatus) {
+				status.ConsecutiveFailures = 0
+				status.NextRetryAt = time.Time{}
+			})
+		}
+		if err := c.ReconcileWorkers(ctx); err != nil {
+			return nil, err
+		}
+		return commandResult(map[string]any{"workers": len(endpoints)})
+
+	case valueobject.CommandReregister:
+		return commandResult(map[string]any{"previous_agent_id": agentID})
+
+	case valueobject.CommandDiagnostics:
+		return commandResult(agentStatus(c.store, c.state, c.pool, c.workers))
+
+	default:
+		return nil, fmt.Errorf("unsupported command type %q", cmd.Type)
+	}
+}
+
+func commandResult(v any) (json.RawMessage, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encoding command result: %w", err)
+	}
+	return raw, nil
+}
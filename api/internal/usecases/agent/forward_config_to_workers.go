@@ -0,0 +1,72 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+func (c *commandUsecase) ForwardConfigToWorkers(ctx context.Context) error {
+	cfg := c.store.Get()
+	if cfg == nil {
+		return fmt.Errorf("no config to forward")
+	}
+
+	clients := c.pool.Clients()
+	if len(clients) == 0 {
+		return fmt.Errorf("no workers to forward config to")
+	}
+	return c.pushToWorkers(ctx, clients, cfg)
+}
+
+func (c *commandUsecase) pushToWorkers(ctx context.Context, clients []usecases.WorkerClient, cfg *entity.Config) error {
+	errs := make([]error, len(clients))
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		wg.Go(func() {
+			errs[i] = c.pushToWorker(ctx, client, cfg)
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (c *commandUsecase) pushToWorker(ctx context.Context, client usecases.WorkerClient, cfg *entity.Config) error {
+	url := client.BaseURL()
+	if err := client.PushConfig(ctx, c.store.Merge(cfg)); err != nil {
+		c.recordWorkerFailure(url, err)
+		return fmt.Errorf("worker %s: %w", url, err)
+	}
+
+	now := time.Now()
+	c.workers.Update(url, func(status *entity.WorkerStatus) {
+		status.Healthy = true
+		status.AppliedVersion = cfg.Version
+		status.InSync = true
+		status.LastCheckedAt = now
+		status.LastSyncedAt = now
+		status.ConsecutiveFailures = 0
+		status.LastError = ""
+		status.NextRetryAt = time.Time{}
+	})
+	return nil
+}
+
+func (c *commandUsecase) recordWorkerFailure(url string, err error) {
+	c.state.RecordError("worker", fmt.Errorf("%s: %w", url, err))
+	now := time.Now()
+	c.workers.Update(url, func(status *entity.WorkerStatus) {
+		status.Healthy = false
+		status.InSync = false
+		status.LastCheckedAt = now
+		status.ConsecutiveFailures++
+		status.LastError = err.Error()
+		status.NextRetryAt = now.Add(backoff.NextInterval(c.backoffCfg, status.ConsecutiveFailures-1))
+	})
+}
@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/pkg/labels"
+)
+
+func (c *commandUsecase) ImportBundles(ctx context.Context) error {
+	if c.bundles == nil {
+		return nil
+	}
+
+	files, err := c.bundles.Pending()
+	if err != nil {
+		err = fmt.Errorf("reading bundles: %w", err)
+		c.state.RecordError("bundle", err)
+		return err
+	}
+
+	var errs []error
+	for _, file := range files {
+		cfg, err := c.openBundle(file)
+		if err != nil {
+			err = fmt.Errorf("bundle %s rejected: %w", file.Name, err)
+			c.state.RecordError("bundle", err)
+			log.Print(err)
+			errs = append(errs, err)
+			if err := c.bundles.Reject(file.Name); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		c.store.Set(cfg)
+		log.Printf("config updated to version %d from bundle %s", cfg.Version, file.Name)
+		if err := c.bundles.Accept(file.Name); err != nil {
+			errs = append(errs, err)
+		}
+		if err := c.ApplyConfig(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *commandUsecase) openBundle(file entity.BundleFile) (*entity.Config, error) {
+	var signed entity.SignedBundle
+	if err := json.Unmarshal(file.Data, &signed); err != nil {
+		return nil, fmt.Errorf("decoding bundle: %w", err)
+	}
+	if c.verifier == nil {
+		return nil, fmt.Errorf("no public key configured")
+	}
+	if err := c.verifier.Verify(signed.KeyID, signed.Payload, signed.Signature); err != nil {
+		return nil, err
+	}
+
+	var bundle entity.AgentBundle
+	if err := json.Unmarshal(signed.Payload, &bundle); err != nil {
+		return nil, fmt.Errorf("decoding bundle payload: %w", err)
+	}
+	if bundle.FormatVersion != entity.AgentBundleFormatVersion {
+		return nil, fmt.Errorf("unsupported bundle format version %d", bundle.FormatVersion)
+	}
+	if bundle.Config == nil {
+		return nil, fmt.Errorf("bundle has no config")
+	}
+	if err := c.matchBundleTarget(bundle.Target); err != nil {
+		return nil, err
+	}
+	if current := c.store.Version(); bundle.Config.Version <= current {
+		return nil, fmt.Errorf("config version %d is not newer than loaded version %d", bundle.Config.Version, current)
+	}
+	return bundle.Config, nil
+}
+
+func (c *commandUsecase) matchBundleTarget(target entity.BundleTarget) error {
+	identity := c.state.Identity()
+	if target.Selector != "" {
+		selector, err := labels.ParseSelector(target.Selector)
+		if err != nil {
+			return err
+		}
+		if !selector.Matches(identity.Labels) {
+			return fmt.Errorf("selector %s does not match agent labels %q", selector, labels.Format(identity.Labels))
+		}
+		return nil
+	}
+
+	agentID := c.state.AgentID()
+	if (target.AgentID != "" && target.AgentID == agentID) || (target.Hostname != "" && target.Hostname == identity.Hostname) {
+		return nil
+	}
+	return fmt.Errorf("bundle is for agent %s (%s), not this agent", target.AgentID, target.Hostname)
+}
@@ -2,50 +2,146 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/pkg/backoff"
+	"github.com/adityawiryaa/api/pkg/configdiff"
+	"github.com/adityawiryaa/api/pkg/jsonpatch"
 )
 
-func (q *queryUsecase) PollConfig(ctx context.Context) (int, error) {
+func (q *queryUsecase) PollConfig(ctx context.Context) (*entity.ConfigFetch, error) {
 	currentVersion := q.store.Version()
+	agentID := q.state.AgentID()
+
+	result, err := q.client.FetchConfig(ctx, agentID, currentVersion)
+	if err == nil && result.Patch != nil {
+		var cfg *entity.Config
+		if cfg, err = applyConfigPatch(q.store.Get(), result.Patch); err == nil {
+			result.Config = cfg
+		} else {
+			q.state.RecordError("patch", err)
+			log.Printf("config patch rejected, fetching full config: %v", err)
+			result, err = q.client.FetchConfig(ctx, agentID, 0)
+		}
+	}
+	if err != nil {
+		q.state.RecordPoll(false, err)
+		if errors.Is(err, usecases.ErrAgentNotFound) {
+			q.state.Invalidate(agentID, err.Error())
+		}
+		return nil, err
+	}
+	q.state.RecordPoll(result.Changed, nil)
 
-	cfg, changed, err := q.client.FetchConfig(ctx, currentVersion)
+	if result.Changed {
+		q.store.Set(result.Config)
+		log.Printf("config updated to version %d", result.Config.Version)
+	}
+	return result, nil
+}
+
+func applyConfigPatch(base *entity.Config, patch *entity.ConfigPatch) (*entity.Config, error) {
+	if base == nil || base.Version != patch.BaseVersion {
+		return nil, fmt.Errorf("patch is based on version %d, but version %d is loaded", patch.BaseVersion, currentVersion(base))
+	}
+
+	var ops []jsonpatch.Operation
+	if err := json.Unmarshal(patch.Patch, &ops); err != nil {
+		return nil, fmt.Errorf("decoding patch: %w", err)
+	}
+	data := base.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+	doc, err := json.Marshal(data)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	patched, err := jsonpatch.Apply(doc, ops)
+	if err != nil {
+		return nil, fmt.Errorf("applying patch: %w", err)
 	}
 
-	if !changed {
-		return 0, nil
+	var result map[string]string
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return nil, fmt.Errorf("patched config is not a string map: %w", err)
+	}
+	if hash := configdiff.Hash(result); hash != patch.Hash {
+		return nil, fmt.Errorf("patched config hash %s does not match %s", hash, patch.Hash)
 	}
 
-	q.store.Set(cfg)
-	log.Printf("config updated to version %d", cfg.Version)
+	return &entity.Config{
+		ID:                  patch.ID,
+		Version:             patch.Version,
+		Data:                result,
+		PollIntervalSeconds: patch.PollIntervalSeconds,
+	}, nil
+}
 
-	return cfg.PollIntervalSeconds, nil
+func currentVersion(cfg *entity.Config) int64 {
+	if cfg == nil {
+		return 0
+	}
+	return cfg.Version
 }
 
-func (q *queryUsecase) StartPolling(ctx context.Context, initialInterval time.Duration, forwardFunc func(context.Context) error) {
-	ticker := time.NewTicker(initialInterval)
-	defer ticker.Stop()
+func (q *queryUsecase) StartPolling(ctx context.Context, scheduler *backoff.Scheduler, forwardFunc, commandsFunc func(context.Context) error) {
+	q.state.SetPollInterval(scheduler.Interval())
+	timer := time.NewTimer(scheduler.Next())
+	defer timer.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-ticker.C:
-			pollInterval, err := q.PollConfig(ctx)
-			if err != nil {
-				log.Printf("poll error: %v", err)
-				continue
+		case <-timer.C:
+		}
+
+		if !q.state.IsRegistered() {
+			timer.Reset(scheduler.Next())
+			continue
+		}
+
+		result, err := q.PollConfig(ctx)
+		if err != nil {
+			var retryAfter *usecases.RetryAfterError
+			var wait time.Duration
+			if errors.As(err, &retryAfter) {
+				wait = scheduler.RetryAfter(retryAfter.After)
+			} else {
+				wait = scheduler.Failure()
 			}
+			log.Printf("poll error (attempt %d, next poll in %s): %v", scheduler.Failures(), wait.Round(time.Millisecond), err)
+			timer.Reset(wait)
+			continue
+		}
 
-			if pollInterval > 0 {
-				ticker.Reset(time.Duration(pollInterval) * time.Second)
+		if result.PollIntervalSeconds > 0 {
+			interval := time.Duration(result.PollIntervalSeconds) * time.Second
+			if interval != scheduler.Interval() {
+				log.Printf("poll interval changed to %s", interval)
+			}
+			scheduler.SetInterval(interval)
+			q.state.SetPollInterval(interval)
+		}
 
-				if err := forwardFunc(ctx); err != nil {
-					log.Printf("forward error: %v", err)
-				}
+		if result.Changed {
+			if err := forwardFunc(ctx); err != nil {
+				log.Printf("apply config error: %v", err)
+			}
+		}
+		if result.PendingCommands > 0 {
+			if err := commandsFunc(ctx); err != nil {
+				q.state.RecordError("command", err)
+				log.Printf("command error: %v", err)
 			}
 		}
+		timer.Reset(scheduler.Success())
 	}
 }
@@ -6,16 +6,25 @@ import (
 )
 
 type queryUsecase struct {
-	client usecases.ControllerClient
-	store  *memory.ConfigStore
+	client  usecases.ControllerClient
+	store   *memory.ConfigStore
+	state   *memory.AgentState
+	pool    *memory.WorkerPool
+	workers *memory.WorkerStatusStore
 }
 
 func NewQueryUsecase(
 	client usecases.ControllerClient,
 	store *memory.ConfigStore,
+	state *memory.AgentState,
+	pool *memory.WorkerPool,
+	workers *memory.WorkerStatusStore,
 ) usecases.UsecaseAgentQuery {
 	return &queryUsecase{
-		client: client,
-		store:  store,
+		client:  client,
+		store:   store,
+		state:   state,
+		pool:    pool,
+		workers: workers,
 	}
 }
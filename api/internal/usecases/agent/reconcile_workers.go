@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/usecases"
+)
+
+func (c *commandUsecase) ReconcileWorkers(ctx context.Context) error {
+	for _, url := range c.pool.Expire(time.Now()) {
+		c.workers.Delete(url)
+		log.Printf("worker %s stopped sending heartbeats, removed from pool", url)
+	}
+
+	cfg := c.store.Get()
+	if cfg == nil {
+		return nil
+	}
+
+	clients := c.pool.Clients()
+	errs := make([]error, len(clients))
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		wg.Go(func() {
+			errs[i] = c.reconcileWorker(ctx, client, cfg)
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (c *commandUsecase) reconcileWorker(ctx context.Context, client usecases.WorkerClient, cfg *entity.Config) error {
+	url := client.BaseURL()
+	if time.Now().Before(c.workers.Get(url).NextRetryAt) {
+		return nil
+	}
+
+	version, err := client.ConfigVersion(ctx)
+	if err != nil {
+		c.recordWorkerFailure(url, err)
+		return err
+	}
+
+	if version == cfg.Version {
+		now := time.Now()
+		c.workers.Update(url, func(status *entity.WorkerStatus) {
+			status.Healthy = true
+			status.AppliedVersion = version
+			status.InSync = true
+			status.LastCheckedAt = now
+			status.ConsecutiveFailures = 0
+			status.LastError = ""
+			status.NextRetryAt = time.Time{}
+		})
+		return nil
+	}
+
+	log.Printf("worker %s drifted (has version %d, want %d), re-pushing", url, version, cfg.Version)
+	return c.pushToWorker(ctx, client, cfg)
+}
+
+func (c *commandUsecase) StartReconciling(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.RefreshOverrides(ctx); err != nil {
+				log.Printf("overrides error: %v", err)
+			}
+			if err := c.ImportBundles(ctx); err != nil {
+				log.Printf("bundle import error: %v", err)
+			}
+			if err := c.ReconcileWorkers(ctx); err != nil {
+				log.Printf("reconcile error: %v", err)
+			}
+			if err := c.ReportToController(ctx); err != nil {
+				c.state.RecordError("report", err)
+				log.Printf("report error: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+)
+
+func (c *commandUsecase) RefreshOverrides(ctx context.Context) error {
+	if c.overrides == nil {
+		return nil
+	}
+
+	overrides, err := c.overrides.Load()
+	if err != nil {
+		err = fmt.Errorf("loading overrides: %w", err)
+		c.state.RecordError("overrides", err)
+		log.Printf("%v (keeping previous overrides)", err)
+		return err
+	}
+
+	if maps.Equal(overrides, c.store.Overrides()) {
+		return nil
+	}
+	c.store.SetOverrides(overrides)
+	if len(overrides) == 0 {
+		log.Printf("local overrides cleared")
+	} else {
+		log.Printf("local overrides active for keys %v", slices.Sorted(maps.Keys(overrides)))
+	}
+
+	if c.store.Get() == nil {
+		return nil
+	}
+	return c.ApplyConfig(ctx)
+}
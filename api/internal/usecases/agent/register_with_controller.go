@@ -13,24 +13,75 @@ import (
 func (c *commandUsecase) RegisterWithController(ctx context.Context, req *entity.RegistrationRequest) (*dto.RegistrationResponseDTO, error) {
 	var lastErr error
 	for attempt := range c.backoffCfg.MaxRetries + 1 {
-		resp, err := c.controllerClient.Register(ctx, req)
+		resp, err := c.register(ctx, req)
 		if err == nil {
-			return &dto.RegistrationResponseDTO{
-				AgentID: resp.AgentID,
-				Status:  resp.Status,
-			}, nil
+			return resp, nil
 		}
 		lastErr = err
 		log.Printf("registration attempt %d failed: %v", attempt+1, err)
 
 		if attempt < c.backoffCfg.MaxRetries {
-			interval := backoff.NextInterval(c.backoffCfg, attempt)
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(interval):
+			if err := c.wait(ctx, attempt); err != nil {
+				return nil, err
 			}
 		}
 	}
 	return nil, lastErr
 }
+
+func (c *commandUsecase) MaintainRegistration(ctx context.Context, req *entity.RegistrationRequest) {
+	for {
+		if !c.state.IsRegistered() {
+			resp, err := c.registerUntilSuccess(ctx, req)
+			if err != nil {
+				return
+			}
+			log.Printf("registered as agent %s", resp.AgentID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.state.Invalidated():
+			if registration := c.state.Registration(); !registration.Registered {
+				log.Printf("registration invalidated (%s), re-registering", registration.LastError)
+			}
+		}
+	}
+}
+
+func (c *commandUsecase) registerUntilSuccess(ctx context.Context, req *entity.RegistrationRequest) (*dto.RegistrationResponseDTO, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.register(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		log.Printf("registration attempt %d failed, running in degraded mode: %v", attempt+1, err)
+
+		if err := c.wait(ctx, attempt); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *commandUsecase) register(ctx context.Context, req *entity.RegistrationRequest) (*dto.RegistrationResponseDTO, error) {
+	resp, err := c.controllerClient.Register(ctx, req)
+	if err != nil {
+		c.state.SetRegistrationError(err)
+		return nil, err
+	}
+	c.state.SetRegistered(resp.AgentID)
+	return &dto.RegistrationResponseDTO{
+		AgentID: resp.AgentID,
+		Status:  resp.Status,
+	}, nil
+}
+
+func (c *commandUsecase) wait(ctx context.Context, attempt int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(backoff.NextInterval(c.backoffCfg, attempt)):
+		return nil
+	}
+}
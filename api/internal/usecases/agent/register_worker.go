@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/dto/mapper"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+)
+
+func (c *commandUsecase) RegisterWorker(ctx context.Context, req *request.RegisterWorkerRequest) (*dto.WorkerStatusDTO, error) {
+	workerURL := strings.TrimRight(req.URL, "/")
+	parsed, err := url.Parse(workerURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid worker url %q", req.URL)
+	}
+
+	client, created := c.pool.Add(workerURL, valueobject.WorkerSourceRegistered)
+	if created {
+		log.Printf("worker %s registered", workerURL)
+		if cfg := c.store.Get(); cfg != nil {
+			if err := c.pushToWorkers(ctx, []usecases.WorkerClient{client}, cfg); err != nil {
+				log.Printf("initial push to new worker failed: %v", err)
+			}
+		}
+	}
+
+	for _, status := range workerStatuses(c.pool, c.workers) {
+		if status.URL == workerURL {
+			result := mapper.ToWorkerStatusDTO(status)
+			return &result, nil
+		}
+	}
+	return nil, usecases.ErrWorkerNotFound
+}
+
+func (c *commandUsecase) DeregisterWorker(_ context.Context, workerURL string) error {
+	workerURL = strings.TrimRight(workerURL, "/")
+	if !c.pool.Remove(workerURL) {
+		return usecases.ErrWorkerNotFound
+	}
+	c.workers.Delete(workerURL)
+	log.Printf("worker %s deregistered", workerURL)
+	return nil
+}
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+)
+
+func (c *commandUsecase) ReportToController(ctx context.Context) error {
+	if !c.state.IsRegistered() {
+		return nil
+	}
+	agentID := c.state.AgentID()
+
+	downstream, err := c.downstreamAgents(ctx, agentID)
+	if err != nil {
+		return err
+	}
+
+	err = c.controllerClient.Report(ctx, agentID, &request.AgentReportRequest{
+		ConfigVersion: c.store.Version(),
+		Workers:       workerStatuses(c.pool, c.workers),
+		Hooks:         c.state.Hooks(),
+		Overrides:     slices.Sorted(maps.Keys(c.store.Overrides())),
+		Downstream:    downstream,
+	})
+	if errors.Is(err, usecases.ErrAgentNotFound) {
+		c.state.Invalidate(agentID, err.Error())
+	}
+	return err
+}
+
+func (c *commandUsecase) downstreamAgents(ctx context.Context, relayID string) ([]entity.DownstreamAgent, error) {
+	if c.downstream == nil {
+		return nil, nil
+	}
+	agents, err := c.downstream.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []entity.DownstreamAgent
+	for _, agent := range agents {
+		summary := entity.DownstreamAgent{
+			ID:        agent.ID,
+			Relay:     relayID,
+			Hostname:  agent.Hostname,
+			IPAddress: agent.IPAddress,
+			Port:      agent.Port,
+			Status:    agent.Status,
+		}
+		if agent.Report != nil {
+			summary.ConfigVersion = agent.Report.ConfigVersion
+			summary.Workers = len(agent.Report.Workers)
+			for _, worker := range agent.Report.Workers {
+				if worker.Healthy {
+					summary.HealthyWorkers++
+				}
+			}
+			summary.ReportedAt = agent.Report.ReportedAt
+		}
+		result = append(result, summary)
+		if agent.Report != nil {
+			result = append(result, agent.Report.Downstream...)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+func (c *commandUsecase) RestoreFromCache(_ context.Context) (*entity.Config, error) {
+	if c.cache == nil {
+		return nil, nil
+	}
+
+	cfg, err := c.cache.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading cached config: %w", err)
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+
+	if cfg.Version > c.store.Version() {
+		c.store.Set(cfg)
+	}
+	return cfg, nil
+}
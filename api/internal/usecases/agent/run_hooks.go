@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+const maxHookRetryInterval = 30 * time.Second
+
+func (c *commandUsecase) RunHooks(ctx context.Context) error {
+	cfg := c.store.Effective()
+	if c.hooks == nil || cfg == nil {
+		return nil
+	}
+
+	hooks := c.hooks.Hooks()
+	errs := make([]error, len(hooks))
+	var wg sync.WaitGroup
+	for i, hook := range hooks {
+		if c.state.Hook(hook.Name).AppliedVersion == cfg.Version {
+			continue
+		}
+		wg.Go(func() {
+			errs[i] = c.runHook(ctx, hook, cfg)
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (c *commandUsecase) runHook(ctx context.Context, hook entity.HookDefinition, cfg *entity.Config) error {
+	hookType := valueobject.HookTypeHTTP
+	if hook.Command != "" {
+		hookType = valueobject.HookTypeExec
+	}
+	policy := backoff.Config{
+		InitialInterval: time.Duration(hook.RetryBackoffSeconds) * time.Second,
+		MaxInterval:     maxHookRetryInterval,
+		Multiplier:      2,
+		MaxRetries:      hook.Retries,
+	}
+
+	var err error
+	attempts := 0
+	for attempt := range hook.Retries + 1 {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				err = errors.Join(err, ctx.Err())
+			case <-time.After(backoff.NextInterval(policy, attempt-1)):
+			}
+			if ctx.Err() != nil {
+				break
+			}
+		}
+
+		attempts++
+		if err = c.hooks.Run(ctx, hook, cfg); err == nil {
+			break
+		}
+		log.Printf("hook %s attempt %d/%d failed: %v", hook.Name, attempt+1, hook.Retries+1, err)
+	}
+
+	now := time.Now()
+	c.state.UpdateHook(hook.Name, func(status *entity.HookStatus) {
+		status.Type = hookType
+		status.LastVersion = cfg.Version
+		status.LastRunAt = now
+		status.Attempts = attempts
+		if err == nil {
+			status.AppliedVersion = cfg.Version
+			status.LastSuccessAt = now
+			status.ConsecutiveFailures = 0
+			status.LastError = ""
+			return
+		}
+		status.ConsecutiveFailures++
+		status.LastError = err.Error()
+	})
+
+	if err != nil {
+		err = fmt.Errorf("hook %s: %w", hook.Name, err)
+		c.state.RecordError("hook", err)
+		return err
+	}
+	log.Printf("hook %s ran for config version %d", hook.Name, cfg.Version)
+	return nil
+}
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/dto/mapper"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+)
+
+const checkConfigLoaded = "config_loaded"
+
+func (q *queryUsecase) CurrentConfig(_ context.Context) *dto.ConfigDTO {
+	return currentConfig(q.store)
+}
+
+func (q *queryUsecase) Status(_ context.Context) *dto.AgentStatusDTO {
+	return agentStatus(q.store, q.state, q.pool, q.workers)
+}
+
+func (q *queryUsecase) Readiness(_ context.Context) *dto.ReadinessDTO {
+	return readiness(q.store)
+}
+
+func agentStatus(store *memory.ConfigStore, state *memory.AgentState, pool *memory.WorkerPool, workers *memory.WorkerStatusStore) *dto.AgentStatusDTO {
+	return &dto.AgentStatusDTO{
+		Ready:        readiness(store).Ready,
+		Registration: mapper.ToRegistrationStatusDTO(state.Registration()),
+		Config:       currentConfig(store),
+		Overrides:    store.Overrides(),
+		Poll:         mapper.ToPollStatusDTO(state.Poll()),
+		Workers:      workerStatusDTOs(pool, workers),
+		Hooks:        mapper.ToHookStatusDTOs(state.Hooks()),
+		RecentErrors: mapper.ToAgentErrorDTOs(state.RecentErrors()),
+	}
+}
+
+func currentConfig(store *memory.ConfigStore) *dto.ConfigDTO {
+	cfg := store.Effective()
+	if cfg == nil {
+		return nil
+	}
+	result := mapper.ToConfigDTO(cfg)
+	return &result
+}
+
+func readiness(store *memory.ConfigStore) *dto.ReadinessDTO {
+	var failed []string
+	if store.Get() == nil {
+		failed = append(failed, checkConfigLoaded)
+	}
+	return &dto.ReadinessDTO{
+		Ready:  len(failed) == 0,
+		Checks: failed,
+	}
+}
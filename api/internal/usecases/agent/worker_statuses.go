@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/dto/mapper"
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+)
+
+func (q *queryUsecase) WorkerStatuses(_ context.Context) []dto.WorkerStatusDTO {
+	return workerStatusDTOs(q.pool, q.workers)
+}
+
+func workerStatusDTOs(pool *memory.WorkerPool, workers *memory.WorkerStatusStore) []dto.WorkerStatusDTO {
+	statuses := workerStatuses(pool, workers)
+	result := make([]dto.WorkerStatusDTO, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, mapper.ToWorkerStatusDTO(status))
+	}
+	return result
+}
+
+func workerStatuses(pool *memory.WorkerPool, workers *memory.WorkerStatusStore) []entity.WorkerStatus {
+	endpoints := pool.Endpoints()
+	result := make([]entity.WorkerStatus, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		status := workers.Get(endpoint.URL)
+		status.Source = endpoint.Source
+		status.Drained = endpoint.Drained
+		status.LastSeenAt = endpoint.LastSeenAt
+		result = append(result, status)
+	}
+	return result
+}
@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/dto/mapper"
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/request"
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+)
+
+var commandRequiredArgs = map[string][]string{
+	valueobject.CommandReload:      nil,
+	valueobject.CommandDrain:       {"url"},
+	valueobject.CommandUndrain:     {"url"},
+	valueobject.CommandFlush:       nil,
+	valueobject.CommandReregister:  nil,
+	valueobject.CommandDiagnostics: nil,
+}
+
+func (c *commandUsecase) EnqueueCommand(ctx context.Context, agentID string, req *request.EnqueueCommandRequest) (*dto.AgentCommandDTO, error) {
+	if err := validateCommand(req); err != nil {
+		return nil, err
+	}
+	if err := c.ensureAgent(ctx, agentID); err != nil {
+		return nil, err
+	}
+
+	cmd := &entity.AgentCommand{
+		ID:        uuid.New().String(),
+		AgentID:   agentID,
+		Type:      req.Type,
+		Args:      req.Args,
+		Status:    valueobject.CommandStatusPending,
+		CreatedAt: time.Now(),
+	}
+	if err := c.commandQueueCmd.Enqueue(ctx, cmd); err != nil {
+		return nil, err
+	}
+
+	result := mapper.ToAgentCommandDTO(cmd)
+	return &result, nil
+}
+
+func (c *commandUsecase) ClaimCommands(ctx context.Context, agentID string) ([]dto.AgentCommandDTO, error) {
+	if err := c.ensureAgent(ctx, agentID); err != nil {
+		return nil, err
+	}
+	if err := c.commandQueueCmd.MarkDelivered(ctx, agentID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	cmds, err := c.commandQueueQuery.ListByAgent(ctx, agentID, valueobject.CommandStatusDelivered)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToAgentCommandDTOs(cmds), nil
+}
+
+func (c *commandUsecase) CompleteCommand(ctx context.Context, agentID, commandID string, req *request.CommandResultRequest) (*dto.AgentCommandDTO, error) {
+	err := c.commandQueueCmd.Complete(ctx, &entity.AgentCommand{
+		ID:          commandID,
+		AgentID:     agentID,
+		Status:      req.Status,
+		Result:      req.Result,
+		Error:       req.Error,
+		CompletedAt: time.Now(),
+	})
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, domainuc.ErrCommandNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cmd, err := c.commandQueueQuery.FindByID(ctx, agentID, commandID)
+	if err != nil {
+		return nil, err
+	}
+	result := mapper.ToAgentCommandDTO(cmd)
+	return &result, nil
+}
+
+func (c *commandUsecase) ensureAgent(ctx context.Context, agentID string) error {
+	_, err := c.agentRepoQuery.FindByID(ctx, agentID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return domainuc.ErrAgentNotFound
+	}
+	return err
+}
+
+func (q *queryUsecase) ListAgentCommands(ctx context.Context, agentID, status string) ([]dto.AgentCommandDTO, error) {
+	_, err := q.agentRepoQuery.FindByID(ctx, agentID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, domainuc.ErrAgentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cmds, err := q.commandQueueQuery.ListByAgent(ctx, agentID, status)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToAgentCommandDTOs(cmds), nil
+}
+
+func (q *queryUsecase) CountOutstandingCommands(ctx context.Context, agentID string) (int, error) {
+	return q.commandQueueQuery.CountOutstanding(ctx, agentID)
+}
+
+func validateCommand(req *request.EnqueueCommandRequest) error {
+	required, ok := commandRequiredArgs[req.Type]
+	if !ok {
+		return fmt.Errorf("%w: unknown type %q", domainuc.ErrInvalidCommand, req.Type)
+	}
+	for _, arg := range required {
+		if req.Args[arg] == "" {
+			return fmt.Errorf("%w: %s requires the %q argument", domainuc.ErrInvalidCommand, req.Type, arg)
+		}
+	}
+	return nil
+}
@@ -9,16 +9,31 @@ type commandUsecase struct {
 	agentRepoCommand  repository.AgentRepositoryCommand
 	configRepoCommand repository.ConfigRepositoryCommand
 	configRepoQuery   repository.ConfigRepositoryQuery
+	bundleRepoCommand repository.BundleRepositoryCommand
+	bundleRepoQuery   repository.BundleRepositoryQuery
+	agentRepoQuery    repository.AgentRepositoryQuery
+	commandQueueCmd   repository.CommandQueueRepositoryCommand
+	commandQueueQuery repository.CommandQueueRepositoryQuery
 }
 
 func NewCommandUsecase(
 	agentRepoCommand repository.AgentRepositoryCommand,
 	configRepoCommand repository.ConfigRepositoryCommand,
 	configRepoQuery repository.ConfigRepositoryQuery,
+	bundleRepoCommand repository.BundleRepositoryCommand,
+	bundleRepoQuery repository.BundleRepositoryQuery,
+	agentRepoQuery repository.AgentRepositoryQuery,
+	commandQueueCmd repository.CommandQueueRepositoryCommand,
+	commandQueueQuery repository.CommandQueueRepositoryQuery,
 ) domainuc.UsecaseControllerCommand {
 	return &commandUsecase{
 		agentRepoCommand:  agentRepoCommand,
 		configRepoCommand: configRepoCommand,
 		configRepoQuery:   configRepoQuery,
+		bundleRepoCommand: bundleRepoCommand,
+		bundleRepoQuery:   bundleRepoQuery,
+		agentRepoQuery:    agentRepoQuery,
+		commandQueueCmd:   commandQueueCmd,
+		commandQueueQuery: commandQueueQuery,
 	}
 }
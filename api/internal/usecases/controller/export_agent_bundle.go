@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/pkg/labels"
+)
+
+func (q *queryUsecase) ExportAgentBundle(ctx context.Context, target entity.BundleTarget) (*entity.SignedBundle, error) {
+	if q.signer == nil {
+		return nil, domainuc.ErrSigningDisabled
+	}
+	if (target.AgentID == "") == (target.Selector == "") {
+		return nil, fmt.Errorf("%w: set either an agent ID or a label selector", domainuc.ErrInvalidBundleTarget)
+	}
+
+	if target.Selector != "" {
+		selector, err := labels.ParseSelector(target.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainuc.ErrInvalidBundleTarget, err)
+		}
+		target.Selector = selector.String()
+	} else {
+		agent, err := q.agentRepoQuery.FindByID(ctx, target.AgentID)
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domainuc.ErrAgentNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("looking up agent: %w", err)
+		}
+		target.Hostname = agent.Hostname
+	}
+
+	cfg, err := q.configRepoQuery.GetLatestConfig(ctx)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, domainuc.ErrNoConfig
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading latest config: %w", err)
+	}
+
+	payload, err := json.Marshal(entity.AgentBundle{
+		FormatVersion: entity.AgentBundleFormatVersion,
+		IssuedAt:      time.Now().UTC(),
+		Target:        target,
+		Config:        cfg,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding bundle: %w", err)
+	}
+
+	return &entity.SignedBundle{
+		KeyID:     q.signer.KeyID(),
+		Payload:   payload,
+		Signature: q.signer.Sign(payload),
+	}, nil
+}
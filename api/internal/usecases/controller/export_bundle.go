@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+)
+
+func (q *queryUsecase) ExportBundle(ctx context.Context) (*entity.ConfigBundle, error) {
+	configs, err := q.configRepoQuery.ListConfigs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing configs: %w", err)
+	}
+
+	agents, err := q.agentRepoQuery.ListAgents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing agents: %w", err)
+	}
+
+	schemaVersion, err := q.bundleRepoQuery.SchemaVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema version: %w", err)
+	}
+
+	return &entity.ConfigBundle{
+		FormatVersion: entity.BundleFormatVersion,
+		SchemaVersion: schemaVersion,
+		ExportedAt:    time.Now(),
+		Configs:       configs,
+		Agents:        agents,
+	}, nil
+}
@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/dto/mapper"
+	"github.com/adityawiryaa/api/domain/repository"
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+)
+
+func (q *queryUsecase) GetAgent(ctx context.Context, id string) (*dto.AgentDTO, error) {
+	agent, err := q.agentRepoQuery.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, domainuc.ErrAgentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := mapper.ToAgentDTO(agent)
+	return &result, nil
+}
@@ -2,9 +2,15 @@ package usecases
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/adityawiryaa/api/domain/dto"
 	"github.com/adityawiryaa/api/domain/dto/mapper"
+	"github.com/adityawiryaa/api/domain/repository"
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/pkg/configdiff"
 )
 
 func (q *queryUsecase) GetLatestConfig(ctx context.Context) (*dto.ConfigDTO, error) {
@@ -24,3 +30,35 @@ func (q *queryUsecase) GetConfigByVersion(ctx context.Context, version int64) (*
 	result := mapper.ToConfigDTO(cfg)
 	return &result, nil
 }
+
+func (q *queryUsecase) GetConfigPatch(ctx context.Context, baseVersion int64) (*dto.ConfigPatchDTO, error) {
+	latest, err := q.configRepoQuery.GetLatestConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baseVersion <= 0 || baseVersion >= latest.Version {
+		return nil, fmt.Errorf("%w: version %d", domainuc.ErrPatchBaseUnavailable, baseVersion)
+	}
+
+	base, err := q.configRepoQuery.GetConfigByVersion(ctx, baseVersion)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: version %d", domainuc.ErrPatchBaseUnavailable, baseVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := json.Marshal(configdiff.JSONPatch(base.Data, latest.Data))
+	if err != nil {
+		return nil, err
+	}
+	return &dto.ConfigPatchDTO{
+		ID:                  latest.ID,
+		BaseVersion:         base.Version,
+		Version:             latest.Version,
+		PollIntervalSeconds: latest.PollIntervalSeconds,
+		Origin:              latest.Origin,
+		Hash:                configdiff.Hash(latest.Data),
+		Patch:               patch,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/entity"
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+)
+
+func (c *commandUsecase) ImportBundle(ctx context.Context, bundle *entity.ConfigBundle, mode string) (*dto.ImportResultDTO, error) {
+	if bundle.FormatVersion != entity.BundleFormatVersion {
+		return nil, fmt.Errorf("unsupported bundle format version %d", bundle.FormatVersion)
+	}
+	schemaVersion, err := c.bundleRepoQuery.SchemaVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading schema version: %w", err)
+	}
+	if bundle.SchemaVersion > schemaVersion {
+		return nil, fmt.Errorf("%w: bundle schema %d, controller schema %d", domainuc.ErrBundleSchemaTooNew, bundle.SchemaVersion, schemaVersion)
+	}
+	if mode == "" {
+		mode = valueobject.ImportModeReplay
+	}
+
+	source := make([]*entity.Config, 0, len(bundle.Configs))
+	for _, cfg := range bundle.Configs {
+		if cfg != nil {
+			source = append(source, cfg)
+		}
+	}
+	sort.Slice(source, func(i, j int) bool {
+		return source[i].Version < source[j].Version
+	})
+
+	switch mode {
+	case valueobject.ImportModeReplay:
+	case valueobject.ImportModeSquash:
+		if len(source) > 0 {
+			source = source[len(source)-1:]
+		}
+	default:
+		return nil, fmt.Errorf("unknown import mode %q: expected %s or %s", mode, valueobject.ImportModeReplay, valueobject.ImportModeSquash)
+	}
+
+	var nextVersion int64 = 1
+	latest, err := c.configRepoQuery.GetLatestConfig(ctx)
+	if err == nil && latest != nil {
+		nextVersion = latest.Version + 1
+	}
+
+	now := time.Now()
+	configs := make([]*entity.Config, 0, len(source))
+	for _, src := range source {
+		createdAt := src.CreatedAt
+		if mode == valueobject.ImportModeSquash || createdAt.IsZero() {
+			createdAt = now
+		}
+		pollInterval := src.PollIntervalSeconds
+		if pollInterval <= 0 {
+			pollInterval = 30
+		}
+		configs = append(configs, &entity.Config{
+			ID:                  uuid.New().String(),
+			Version:             nextVersion,
+			Data:                src.Data,
+			PollIntervalSeconds: pollInterval,
+			Origin:              valueobject.OriginImport,
+			CreatedAt:           createdAt,
+		})
+		nextVersion++
+	}
+
+	agents := make([]*entity.Agent, 0, len(bundle.Agents))
+	for _, agent := range bundle.Agents {
+		if agent == nil || agent.ID == "" {
+			continue
+		}
+		agents = append(agents, agent)
+	}
+
+	if err := c.bundleRepoCommand.Import(ctx, configs, agents); err != nil {
+		return nil, fmt.Errorf("importing bundle: %w", err)
+	}
+
+	return &dto.ImportResultDTO{
+		Mode:            mode,
+		ConfigsImported: len(configs),
+		AgentsImported:  len(agents),
+		LatestVersion:   nextVersion - 1,
+	}, nil
+}
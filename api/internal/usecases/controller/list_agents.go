@@ -0,0 +1,20 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/dto/mapper"
+)
+
+func (q *queryUsecase) ListAgents(ctx context.Context) ([]dto.AgentDTO, error) {
+	agents, err := q.agentRepoQuery.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dto.AgentDTO, 0, len(agents))
+	for _, agent := range agents {
+		result = append(result, mapper.ToAgentDTO(agent))
+	}
+	return result, nil
+}
@@ -6,11 +6,25 @@ import (
 )
 
 type queryUsecase struct {
-	configRepoQuery repository.ConfigRepositoryQuery
+	configRepoQuery   repository.ConfigRepositoryQuery
+	agentRepoQuery    repository.AgentRepositoryQuery
+	bundleRepoQuery   repository.BundleRepositoryQuery
+	commandQueueQuery repository.CommandQueueRepositoryQuery
+	signer            domainuc.BundleSigner
 }
 
-func NewQueryUsecase(configRepoQuery repository.ConfigRepositoryQuery) domainuc.UsecaseControllerQuery {
+func NewQueryUsecase(
+	configRepoQuery repository.ConfigRepositoryQuery,
+	agentRepoQuery repository.AgentRepositoryQuery,
+	bundleRepoQuery repository.BundleRepositoryQuery,
+	commandQueueQuery repository.CommandQueueRepositoryQuery,
+	signer domainuc.BundleSigner,
+) domainuc.UsecaseControllerQuery {
 	return &queryUsecase{
-		configRepoQuery: configRepoQuery,
+		configRepoQuery:   configRepoQuery,
+		agentRepoQuery:    agentRepoQuery,
+		bundleRepoQuery:   bundleRepoQuery,
+		commandQueueQuery: commandQueueQuery,
+		signer:            signer,
 	}
 }
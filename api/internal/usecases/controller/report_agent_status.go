@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/dto"
+	"github.com/adityawiryaa/api/domain/dto/mapper"
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/request"
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+)
+
+func (c *commandUsecase) ReportAgentStatus(ctx context.Context, agentID string, req *request.AgentReportRequest) (*dto.AgentReportDTO, error) {
+	report := &entity.AgentReport{
+		ConfigVersion: req.ConfigVersion,
+		Workers:       req.Workers,
+		Hooks:         req.Hooks,
+		Overrides:     req.Overrides,
+		Downstream:    req.Downstream,
+		ReportedAt:    time.Now(),
+	}
+
+	err := c.agentRepoCommand.SaveReport(ctx, agentID, report)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, domainuc.ErrAgentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := mapper.ToAgentReportDTO(report)
+	return &result, nil
+}
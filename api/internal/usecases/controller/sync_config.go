@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/request"
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/pkg/configdiff"
+	"github.com/adityawiryaa/api/pkg/configsource"
+)
+
+type ConfigSource interface {
+	Load(ctx context.Context) (*configsource.Snapshot, error)
+}
+
+type SyncResult struct {
+	Action          string `json:"action"`
+	Version         int64  `json:"version"`
+	Revision        string `json:"revision"`
+	ConflictVersion int64  `json:"conflict_version,omitempty"`
+}
+
+type ConfigSyncer struct {
+	source          ConfigSource
+	commandUC       domainuc.UsecaseControllerCommand
+	configRepoQuery repository.ConfigRepositoryQuery
+	conflictPolicy  string
+
+	mu                sync.Mutex
+	initialized       bool
+	lastSyncedVersion int64
+	lastConflict      int64
+}
+
+func NewConfigSyncer(
+	source ConfigSource,
+	commandUC domainuc.UsecaseControllerCommand,
+	configRepoQuery repository.ConfigRepositoryQuery,
+	conflictPolicy string,
+) (*ConfigSyncer, error) {
+	conflictPolicy, err := validConflictPolicy(conflictPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigSyncer{
+		source:          source,
+		commandUC:       commandUC,
+		configRepoQuery: configRepoQuery,
+		conflictPolicy:  conflictPolicy,
+	}, nil
+}
+
+func (s *ConfigSyncer) SetConflictPolicy(conflictPolicy string) error {
+	conflictPolicy, err := validConflictPolicy(conflictPolicy)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conflictPolicy = conflictPolicy
+	return nil
+}
+
+func validConflictPolicy(conflictPolicy string) (string, error) {
+	if conflictPolicy == "" {
+		return valueobject.SyncConflictReject, nil
+	}
+	if conflictPolicy != valueobject.SyncConflictReject && conflictPolicy != valueobject.SyncConflictOverwrite {
+		return "", fmt.Errorf("invalid conflict policy %q: expected %s or %s", conflictPolicy, valueobject.SyncConflictReject, valueobject.SyncConflictOverwrite)
+	}
+	return conflictPolicy, nil
+}
+
+func (s *ConfigSyncer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.SyncOnce(ctx); err != nil && !errors.Is(err, domainuc.ErrSyncConflict) {
+			log.Printf("[sync] error: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ConfigSyncer) SyncOnce(ctx context.Context) (*SyncResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot, err := s.source.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading sync source: %w", err)
+	}
+
+	if !s.initialized {
+		if err := s.loadSyncState(ctx); err != nil {
+			return nil, err
+		}
+		s.initialized = true
+	}
+
+	latest, err := s.configRepoQuery.GetLatestConfig(ctx)
+	if errors.Is(err, repository.ErrNotFound) {
+		latest = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("loading latest config: %w", err)
+	}
+
+	if latest != nil && sameContent(latest, snapshot) {
+		if isSyncOrigin(latest.Origin) {
+			s.lastSyncedVersion = latest.Version
+		}
+		return &SyncResult{Action: valueobject.SyncActionUnchanged, Version: latest.Version, Revision: snapshot.Revision}, nil
+	}
+
+	action := valueobject.SyncActionApplied
+	if latest != nil && s.lastSyncedVersion > 0 && latest.Version > s.lastSyncedVersion && !isSyncOrigin(latest.Origin) {
+		if s.conflictPolicy == valueobject.SyncConflictReject {
+			if s.lastConflict != latest.Version {
+				log.Printf("[sync] conflict: version %d (origin=%s) was published after last synced version %d, not applying revision %s",
+					latest.Version, latest.Origin, s.lastSyncedVersion, snapshot.Revision)
+				s.lastConflict = latest.Version
+			}
+			return &SyncResult{
+				Action:          valueobject.SyncActionConflict,
+				Version:         s.lastSyncedVersion,
+				Revision:        snapshot.Revision,
+				ConflictVersion: latest.Version,
+			}, domainuc.ErrSyncConflict
+		}
+		log.Printf("[sync] overwriting manual edit at version %d with revision %s", latest.Version, snapshot.Revision)
+		action = valueobject.SyncActionOverwritten
+	}
+
+	cfg, err := s.commandUC.UpdateConfig(ctx, &request.UpdateConfigRequest{
+		Data:                snapshot.Data,
+		PollIntervalSeconds: snapshot.PollIntervalSeconds,
+		Origin:              valueobject.OriginSyncPrefix + snapshot.Revision,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("publishing synced config: %w", err)
+	}
+
+	s.lastSyncedVersion = cfg.Version
+	s.lastConflict = 0
+	log.Printf("[sync] published version %d from revision %s (%d file(s))", cfg.Version, snapshot.Revision, len(snapshot.Files))
+
+	return &SyncResult{Action: action, Version: cfg.Version, Revision: snapshot.Revision}, nil
+}
+
+func (s *ConfigSyncer) loadSyncState(ctx context.Context) error {
+	configs, err := s.configRepoQuery.ListConfigs(ctx)
+	if err != nil {
+		return fmt.Errorf("loading config history: %w", err)
+	}
+	for _, cfg := range configs {
+		if isSyncOrigin(cfg.Origin) && cfg.Version > s.lastSyncedVersion {
+			s.lastSyncedVersion = cfg.Version
+		}
+	}
+	return nil
+}
+
+func sameContent(cfg *entity.Config, snapshot *configsource.Snapshot) bool {
+	pollInterval := snapshot.PollIntervalSeconds
+	if pollInterval <= 0 {
+		pollInterval = 30
+	}
+	return cfg.PollIntervalSeconds == pollInterval && configdiff.Equal(cfg.Data, snapshot.Data)
+}
+
+func isSyncOrigin(origin string) bool {
+	return strings.HasPrefix(origin, valueobject.OriginSyncPrefix)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/adityawiryaa/api/domain/dto/mapper"
 	"github.com/adityawiryaa/api/domain/entity"
 	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/valueobject"
 )
 
 func (c *commandUsecase) UpdateConfig(ctx context.Context, req *request.UpdateConfigRequest) (*dto.ConfigDTO, error) {
@@ -24,11 +25,17 @@ func (c *commandUsecase) UpdateConfig(ctx context.Context, req *request.UpdateCo
 		pollInterval = req.PollIntervalSeconds
 	}
 
+	origin := req.Origin
+	if origin == "" {
+		origin = valueobject.OriginAPI
+	}
+
 	cfg := &entity.Config{
 		ID:                  uuid.New().String(),
 		Version:             nextVersion,
 		Data:                req.Data,
 		PollIntervalSeconds: pollInterval,
+		Origin:              origin,
 		CreatedAt:           time.Now(),
 	}
 
@@ -9,31 +9,46 @@ import (
 )
 
 type HTTPExecutor interface {
-	Execute(ctx context.Context, method string, url string, headers map[string]string, body []byte) (int, []byte, error)
+	Execute(ctx context.Context, method string, url string, headers map[string]string, body []byte) (int, string, []byte, error)
 }
 
 type HitEnqueuer interface {
 	EnqueueExecuteHit(payload *hitqueue.ExecuteHitPayload) error
+	EnqueueExecuteHits(payloads []*hitqueue.ExecuteHitPayload) (int, error)
+}
+
+type HitScheduler interface {
+	Sync(entries []hitqueue.ScheduleEntry) error
+}
+
+type HitBatchWriter interface {
+	SaveBatch(ctx context.Context, batch *hitqueue.HitBatch) error
 }
 
 type commandUsecase struct {
 	executor    HTTPExecutor
 	store       *memory.ConfigStore
 	queueClient HitEnqueuer
+	scheduler   HitScheduler
+	batches     HitBatchWriter
 }
 
-func NewCommandUsecase(executor HTTPExecutor, store *memory.ConfigStore, queueClient *hitqueue.Client) domainuc.UsecaseWorkerCommand {
+func NewCommandUsecase(executor HTTPExecutor, store *memory.ConfigStore, queueClient *hitqueue.Client, scheduler HitScheduler, batches HitBatchWriter) domainuc.UsecaseWorkerCommand {
 	return &commandUsecase{
 		executor:    executor,
 		store:       store,
 		queueClient: queueClient,
+		scheduler:   scheduler,
+		batches:     batches,
 	}
 }
 
-func NewCommandUsecaseWithEnqueuer(executor HTTPExecutor, store *memory.ConfigStore, queueClient HitEnqueuer) domainuc.UsecaseWorkerCommand {
+func NewCommandUsecaseWithEnqueuer(executor HTTPExecutor, store *memory.ConfigStore, queueClient HitEnqueuer, scheduler HitScheduler, batches HitBatchWriter) domainuc.UsecaseWorkerCommand {
 	return &commandUsecase{
 		executor:    executor,
 		store:       store,
 		queueClient: queueClient,
+		scheduler:   scheduler,
+		batches:     batches,
 	}
 }
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/adityawiryaa/api/domain/request"
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+)
+
+const maxBatchHits = 1000
+
+func (c *commandUsecase) EnqueueBatch(ctx context.Context, req *request.HitBatchRequest) (*domainuc.EnqueueBatchResponse, error) {
+	if c.batches == nil {
+		return nil, fmt.Errorf("batch store not configured")
+	}
+	if req == nil || len(req.Hits) == 0 {
+		return nil, fmt.Errorf("%w: no hits", domainuc.ErrInvalidBatch)
+	}
+	if len(req.Hits) > maxBatchHits {
+		return nil, fmt.Errorf("%w: %d hits exceeds the limit of %d", domainuc.ErrInvalidBatch, len(req.Hits), maxBatchHits)
+	}
+
+	batch := &hitqueue.HitBatch{
+		ID:        uuid.New().String(),
+		CreatedAt: time.Now(),
+		Tasks:     make([]hitqueue.HitBatchTask, 0, len(req.Hits)),
+	}
+	payloads := make([]*hitqueue.ExecuteHitPayload, 0, len(req.Hits))
+	for i, hit := range req.Hits {
+		if !validHitURL(hit.URL) {
+			return nil, fmt.Errorf("hits[%d]: %w: url %q must be an http(s) URL", i, domainuc.ErrInvalidHit, hit.URL)
+		}
+		payload, err := buildHitPayload(map[string]string{"url": hit.URL}, &hit.HitRequest)
+		if err != nil {
+			return nil, fmt.Errorf("hits[%d]: %w", i, err)
+		}
+		payload.TaskID = uuid.New().String()
+		payload.Batch = batch.ID
+		payloads = append(payloads, payload)
+		batch.Tasks = append(batch.Tasks, hitqueue.HitBatchTask{TaskID: payload.TaskID, Method: payload.Method, URL: payload.URL})
+	}
+
+	if err := c.batches.SaveBatch(ctx, batch); err != nil {
+		return nil, err
+	}
+	log.Printf("[enqueue] creating hit batch: id=%s tasks=%d", batch.ID, len(payloads))
+
+	// Tasks are enqueued one by one, so a queue failure can leave the batch
+	// partially enqueued. The tasks that made it stay queued and count as
+	// Enqueued; the rest are marked "not enqueued" on the saved batch and
+	// count as Failed. Only a batch with nothing enqueued returns an error.
+	enqueued, err := c.queueClient.EnqueueExecuteHits(payloads)
+	if err != nil {
+		log.Printf("[enqueue] batch partially enqueued: id=%s enqueued=%d/%d error=%v", batch.ID, enqueued, len(payloads), err)
+		for i := enqueued; i < len(batch.Tasks); i++ {
+			batch.Tasks[i].Error = "not enqueued: " + err.Error()
+		}
+		if saveErr := c.batches.SaveBatch(ctx, batch); saveErr != nil {
+			log.Printf("[enqueue] failed to record batch enqueue errors: id=%s error=%v", batch.ID, saveErr)
+		}
+		if enqueued == 0 {
+			return nil, fmt.Errorf("enqueuing batch: %w", err)
+		}
+	} else {
+		log.Printf("[enqueue] batch enqueued successfully: id=%s tasks=%d", batch.ID, enqueued)
+	}
+
+	return &domainuc.EnqueueBatchResponse{
+		BatchID:  batch.ID,
+		Status:   valueobject.TaskStatusQueued,
+		Total:    len(payloads),
+		Enqueued: enqueued,
+		Failed:   len(payloads) - enqueued,
+	}, nil
+}
+
+func validHitURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -7,30 +7,77 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/adityawiryaa/api/domain/request"
 	domainuc "github.com/adityawiryaa/api/domain/usecases"
 	"github.com/adityawiryaa/api/domain/valueobject"
 	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
 )
 
-func (c *commandUsecase) EnqueueHit(_ context.Context) (*domainuc.EnqueueHitResponse, error) {
+func (c *commandUsecase) EnqueueHit(_ context.Context, job string, req *request.HitRequest) (*domainuc.EnqueueHitResponse, error) {
+	jobs, err := c.jobs()
+	if err != nil {
+		return nil, err
+	}
+	data, ok := jobs[job]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domainuc.ErrJobNotFound, job)
+	}
+
+	payload, err := buildHitPayload(data, req)
+	if err != nil {
+		return nil, fmt.Errorf("job %s: %w", job, err)
+	}
+	payload.Job = job
+	return c.enqueue(payload)
+}
+
+func (c *commandUsecase) EnqueueHits(_ context.Context, req *request.HitRequest) (*domainuc.EnqueueHitsResponse, error) {
 	cfg := c.store.Get()
 	if cfg == nil {
 		return nil, fmt.Errorf("no config available")
 	}
+	jobs, err := hitJobs(cfg.Data)
+	if err != nil {
+		return nil, err
+	}
 
-	url, ok := cfg.Data["url"]
-	if !ok || url == "" {
-		return nil, fmt.Errorf("no url configured")
+	names := hitJobNames(jobs)
+	payloads := make([]*hitqueue.ExecuteHitPayload, 0, len(names))
+	for _, name := range names {
+		payload, err := buildHitPayload(jobs[name], req)
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", name, err)
+		}
+		payload.Job = name
+		payloads = append(payloads, payload)
 	}
 
-	taskID := uuid.New().String()
-	log.Printf("[enqueue] creating hit task: id=%s url=%s", taskID, url)
+	result := &domainuc.EnqueueHitsResponse{
+		Tasks:       make([]domainuc.EnqueueHitResponse, 0, len(payloads)),
+		ImplicitJob: !hasNamedJobs(cfg.Data),
+	}
+	for _, payload := range payloads {
+		task, err := c.enqueue(payload)
+		if err != nil {
+			return nil, err
+		}
+		result.Tasks = append(result.Tasks, *task)
+	}
+	return result, nil
+}
 
-	payload := &hitqueue.ExecuteHitPayload{
-		TaskID: taskID,
-		URL:    url,
-		Method: "GET",
+func (c *commandUsecase) jobs() (map[string]map[string]string, error) {
+	cfg := c.store.Get()
+	if cfg == nil {
+		return nil, fmt.Errorf("no config available")
 	}
+	return hitJobs(cfg.Data)
+}
+
+func (c *commandUsecase) enqueue(payload *hitqueue.ExecuteHitPayload) (*domainuc.EnqueueHitResponse, error) {
+	taskID := uuid.New().String()
+	payload.TaskID = taskID
+	log.Printf("[enqueue] creating hit task: id=%s job=%s method=%s url=%s", taskID, payload.Job, payload.Method, payload.URL)
 
 	if err := c.queueClient.EnqueueExecuteHit(payload); err != nil {
 		log.Printf("[enqueue] failed to enqueue task: id=%s error=%v", taskID, err)
@@ -41,6 +88,7 @@ func (c *commandUsecase) EnqueueHit(_ context.Context) (*domainuc.EnqueueHitResp
 
 	return &domainuc.EnqueueHitResponse{
 		TaskID: taskID,
+		Job:    payload.Job,
 		Status: valueobject.TaskStatusQueued,
 	}, nil
 }
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+)
+
+const (
+	defaultBatchPageSize = 50
+	maxBatchPageSize     = 500
+)
+
+func (q *queryUsecase) GetBatch(ctx context.Context, batchID string, page, perPage int) (*domainuc.HitBatchResponse, error) {
+	if q.batches == nil || q.resultStore == nil {
+		return nil, fmt.Errorf("batch store not configured")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = defaultBatchPageSize
+	}
+	perPage = min(perPage, maxBatchPageSize)
+
+	batch, err := q.batches.GetBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		return nil, fmt.Errorf("%w: %s", domainuc.ErrBatchNotFound, batchID)
+	}
+
+	taskIDs := make([]string, len(batch.Tasks))
+	for i, task := range batch.Tasks {
+		taskIDs[i] = task.TaskID
+	}
+	results, err := q.resultStore.GetResults(ctx, taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("getting batch results: %w", err)
+	}
+
+	total := len(batch.Tasks)
+	counts := map[string]int{
+		valueobject.TaskStatusPending:   0,
+		valueobject.TaskStatusCompleted: 0,
+		valueobject.TaskStatusFailed:    0,
+	}
+	tasks := make([]domainuc.HitBatchTaskResponse, total)
+	for i, task := range batch.Tasks {
+		tasks[i] = batchTaskResponse(i, task, results[i])
+		counts[tasks[i].Status]++
+	}
+
+	done := counts[valueobject.TaskStatusCompleted] + counts[valueobject.TaskStatusFailed]
+	progress := 100.0
+	if total > 0 {
+		progress = math.Round(float64(done)*1000/float64(total)) / 10
+	}
+
+	start := min((page-1)*perPage, total)
+	end := min(start+perPage, total)
+	return &domainuc.HitBatchResponse{
+		BatchID:   batch.ID,
+		CreatedAt: batch.CreatedAt,
+		Total:     total,
+		Counts:    counts,
+		Progress:  progress,
+		Page:      page,
+		PerPage:   perPage,
+		Pages:     (total + perPage - 1) / perPage,
+		Tasks:     tasks[start:end],
+	}, nil
+}
+
+func batchTaskResponse(index int, task hitqueue.HitBatchTask, result *hitqueue.HitResult) domainuc.HitBatchTaskResponse {
+	response := domainuc.HitBatchTaskResponse{Index: index, Method: task.Method, URL: task.URL}
+	switch {
+	case task.Error != "":
+		response.HitResultResponse = domainuc.HitResultResponse{
+			TaskID: task.TaskID,
+			Status: valueobject.TaskStatusFailed,
+			Error:  task.Error,
+		}
+	case result != nil:
+		response.HitResultResponse = *domainuc.ToHitResultResponse(result)
+	default:
+		response.HitResultResponse = domainuc.HitResultResponse{
+			TaskID: task.TaskID,
+			Status: valueobject.TaskStatusPending,
+		}
+	}
+	return response
+}
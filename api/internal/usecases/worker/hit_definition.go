@@ -0,0 +1,174 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/adityawiryaa/api/domain/request"
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+)
+
+const (
+	hitHeaderPrefix = "headers."
+	hitQueryPrefix  = "query."
+	hitFormPrefix   = "form."
+)
+
+func buildHitPayload(data map[string]string, req *request.HitRequest) (*hitqueue.ExecuteHitPayload, error) {
+	if req == nil {
+		req = &request.HitRequest{}
+	}
+
+	target, ok := data["url"]
+	if !ok || target == "" {
+		return nil, fmt.Errorf("no url configured")
+	}
+
+	method := strings.ToUpper(override(data["method"], req.Method))
+	if method == "" {
+		method = http.MethodGet
+	}
+	if !validMethod(method) {
+		return nil, fmt.Errorf("%w: method %q", domainuc.ErrInvalidHit, method)
+	}
+
+	headers := canonicalHeaders(withPrefix(data, hitHeaderPrefix))
+	maps.Copy(headers, canonicalHeaders(req.Headers))
+	for name := range headers {
+		if name == "" || strings.ContainsAny(name, " :\t\r\n") {
+			return nil, fmt.Errorf("%w: header name %q", domainuc.ErrInvalidHit, name)
+		}
+	}
+
+	query := withPrefix(data, hitQueryPrefix)
+	maps.Copy(query, req.Query)
+
+	bodyType := override(data["body_type"], req.BodyType)
+	if bodyType == "" {
+		bodyType = valueobject.HitBodyRaw
+	}
+	body, defaultContentType, err := buildHitBody(data, req.Body, bodyType)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := override(data["content_type"], req.ContentType)
+	if contentType == "" && body != "" {
+		contentType = defaultContentType
+	}
+
+	rules, err := extractRules(data)
+	if err != nil {
+		return nil, err
+	}
+	store, err := storeBody(data)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &hitqueue.ExecuteHitPayload{
+		URL:         target,
+		Method:      method,
+		Body:        body,
+		ContentType: contentType,
+		Extract:     rules,
+		DropBody:    !store,
+	}
+	if len(headers) > 0 {
+		payload.Headers = headers
+	}
+	if len(query) > 0 {
+		payload.Query = query
+	}
+	if _, err := payload.RequestURL(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domainuc.ErrInvalidHit, err)
+	}
+	return payload, nil
+}
+
+func buildHitBody(data map[string]string, raw json.RawMessage, bodyType string) (string, string, error) {
+	overridden := len(raw) > 0 && string(raw) != "null"
+
+	switch bodyType {
+	case valueobject.HitBodyRaw:
+		body := data["body"]
+		if overridden {
+			if err := json.Unmarshal(raw, &body); err != nil {
+				body = string(raw)
+			}
+		}
+		return body, "text/plain; charset=utf-8", nil
+
+	case valueobject.HitBodyJSON:
+		body := []byte(data["body"])
+		if overridden {
+			body = raw
+		}
+		if len(body) == 0 {
+			return "", "application/json", nil
+		}
+		if !json.Valid(body) {
+			return "", "", fmt.Errorf("%w: body is not valid JSON", domainuc.ErrInvalidHit)
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, body); err != nil {
+			return "", "", fmt.Errorf("%w: %v", domainuc.ErrInvalidHit, err)
+		}
+		return compact.String(), "application/json", nil
+
+	case valueobject.HitBodyForm:
+		fields := withPrefix(data, hitFormPrefix)
+		if overridden {
+			fields = nil
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				return "", "", fmt.Errorf("%w: form body must be an object of strings", domainuc.ErrInvalidHit)
+			}
+		}
+		form := make(url.Values, len(fields))
+		for key, value := range fields {
+			form.Set(key, value)
+		}
+		return form.Encode(), "application/x-www-form-urlencoded", nil
+
+	default:
+		return "", "", fmt.Errorf("%w: body type %q must be one of %s, %s, %s", domainuc.ErrInvalidHit,
+			bodyType, valueobject.HitBodyRaw, valueobject.HitBodyJSON, valueobject.HitBodyForm)
+	}
+}
+
+func withPrefix(data map[string]string, prefix string) map[string]string {
+	result := make(map[string]string)
+	for key, value := range data {
+		if name, ok := strings.CutPrefix(key, prefix); ok {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+func canonicalHeaders(headers map[string]string) map[string]string {
+	result := make(map[string]string, len(headers))
+	for _, name := range slices.Sorted(maps.Keys(headers)) {
+		result[http.CanonicalHeaderKey(name)] = headers[name]
+	}
+	return result
+}
+
+func override(configured, requested string) string {
+	if requested != "" {
+		return requested
+	}
+	return configured
+}
+
+func validMethod(method string) bool {
+	return strings.IndexFunc(method, func(r rune) bool { return r < 'A' || r > 'Z' }) < 0
+}
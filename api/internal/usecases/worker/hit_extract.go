@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/pkg/hit/extract"
+)
+
+const (
+	hitExtractPrefix = "extract."
+	hitFieldsKey     = "fields"
+)
+
+type extractNode struct {
+	rule   extract.Rule
+	fields map[string]*extractNode
+}
+
+func extractRules(data map[string]string) ([]extract.Rule, error) {
+	root := &extractNode{fields: make(map[string]*extractNode)}
+	for key, value := range withPrefix(data, hitExtractPrefix) {
+		if err := root.set(key, value); err != nil {
+			return nil, fmt.Errorf("%w: key %q: %v", domainuc.ErrInvalidHit, hitExtractPrefix+key, err)
+		}
+	}
+
+	rules := root.rules()
+	if err := extract.Validate(rules); err != nil {
+		return nil, fmt.Errorf("%w: extract %v", domainuc.ErrInvalidHit, err)
+	}
+	return rules, nil
+}
+
+func (n *extractNode) set(key, value string) error {
+	segments := strings.Split(key, ".")
+	node := n
+	i := 0
+	for {
+		if !validJobName.MatchString(segments[i]) {
+			return fmt.Errorf("field name %q must use letters, digits, - or _", segments[i])
+		}
+		child := node.fields[segments[i]]
+		if child == nil {
+			child = &extractNode{rule: extract.Rule{Name: segments[i]}, fields: make(map[string]*extractNode)}
+			node.fields[segments[i]] = child
+		}
+		node = child
+		i++
+		if i+1 < len(segments) && segments[i] == hitFieldsKey {
+			i++
+			continue
+		}
+		break
+	}
+
+	switch len(segments) - i {
+	case 0:
+		node.rule.Selector = value
+		return nil
+	case 1:
+		return node.setProperty(segments[i], value)
+	default:
+		return fmt.Errorf("must be extract.<field>[.fields.<field>...][.selector|.mode|.attr|.list]")
+	}
+}
+
+func (n *extractNode) setProperty(name, value string) error {
+	switch name {
+	case "selector":
+		n.rule.Selector = value
+	case "mode":
+		n.rule.Mode = strings.ToLower(value)
+	case "attr":
+		n.rule.Attr = value
+	case "list":
+		list, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("list must be true or false")
+		}
+		n.rule.List = list
+	default:
+		return fmt.Errorf("unknown property %q, want selector, mode, attr or list", name)
+	}
+	return nil
+}
+
+func (n *extractNode) rules() []extract.Rule {
+	if len(n.fields) == 0 {
+		return nil
+	}
+	rules := make([]extract.Rule, 0, len(n.fields))
+	for _, name := range slices.Sorted(maps.Keys(n.fields)) {
+		child := n.fields[name]
+		rule := child.rule
+		if rule.Mode == "" && rule.Attr != "" {
+			rule.Mode = valueobject.ExtractAttr
+		}
+		rule.Fields = child.rules()
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func storeBody(data map[string]string) (bool, error) {
+	value, ok := data["store_body"]
+	if !ok || value == "" {
+		return true, nil
+	}
+	store, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: store_body must be true or false", domainuc.ErrInvalidHit)
+	}
+	return store, nil
+}
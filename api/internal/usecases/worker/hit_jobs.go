@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+)
+
+const (
+	defaultHitJob = "default"
+	hitJobPrefix  = "jobs."
+)
+
+var validJobName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func hitJobs(data map[string]string) (map[string]map[string]string, error) {
+	shared := make(map[string]string)
+	jobs := make(map[string]map[string]string)
+	for key, value := range data {
+		rest, ok := strings.CutPrefix(key, hitJobPrefix)
+		if !ok {
+			shared[key] = value
+			continue
+		}
+		name, setting, ok := strings.Cut(rest, ".")
+		if !ok || setting == "" || !validJobName.MatchString(name) {
+			return nil, fmt.Errorf("%w: key %q must be jobs.<name>.<setting> with a name of letters, digits, - or _", domainuc.ErrInvalidHit, key)
+		}
+		if jobs[name] == nil {
+			jobs[name] = make(map[string]string)
+		}
+		jobs[name][setting] = value
+	}
+
+	if len(jobs) == 0 {
+		return map[string]map[string]string{defaultHitJob: shared}, nil
+	}
+	for name, settings := range jobs {
+		merged := maps.Clone(shared)
+		maps.Copy(merged, settings)
+		jobs[name] = merged
+	}
+	return jobs, nil
+}
+
+func hasNamedJobs(data map[string]string) bool {
+	for key := range data {
+		if strings.HasPrefix(key, hitJobPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func hitJobNames(jobs map[string]map[string]string) []string {
+	return slices.Sorted(maps.Keys(jobs))
+}
@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+)
+
+const hitScheduleSetting = "schedule"
+
+func (c *commandUsecase) syncSchedules(cfg *entity.Config) {
+	if c.scheduler == nil {
+		return
+	}
+	entries, err := scheduleEntries(cfg.Data)
+	if err != nil {
+		log.Printf("[scheduler] config version=%d has no usable jobs, clearing schedules: %v", cfg.Version, err)
+	}
+	if err := c.scheduler.Sync(entries); err != nil {
+		log.Printf("[scheduler] config version=%d: %v", cfg.Version, err)
+	}
+}
+
+func scheduleEntries(data map[string]string) ([]hitqueue.ScheduleEntry, error) {
+	jobs, err := hitJobs(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []hitqueue.ScheduleEntry
+	for _, name := range hitJobNames(jobs) {
+		spec := normalizeSchedule(jobs[name][hitScheduleSetting])
+		if spec == "" {
+			continue
+		}
+		entry := hitqueue.ScheduleEntry{Job: name, Spec: spec}
+		entry.Payload, entry.Err = buildHitPayload(jobs[name], nil)
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func normalizeSchedule(value string) string {
+	value = strings.TrimSpace(value)
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return fmt.Sprintf("@every %s", d)
+	}
+	return value
+}
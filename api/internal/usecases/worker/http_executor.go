@@ -19,7 +19,7 @@ func NewHTTPExecutor(timeout time.Duration) *DefaultHTTPExecutor {
 	}
 }
 
-func (e *DefaultHTTPExecutor) Execute(ctx context.Context, method string, url string, headers map[string]string, body []byte) (int, []byte, error) {
+func (e *DefaultHTTPExecutor) Execute(ctx context.Context, method string, url string, headers map[string]string, body []byte) (int, string, []byte, error) {
 	var reqBody io.Reader
 	if body != nil {
 		reqBody = bytes.NewReader(body)
@@ -27,7 +27,7 @@ func (e *DefaultHTTPExecutor) Execute(ctx context.Context, method string, url st
 
 	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return 0, nil, fmt.Errorf("creating request: %w", err)
+		return 0, "", nil, fmt.Errorf("creating request: %w", err)
 	}
 
 	for k, v := range headers {
@@ -36,14 +36,14 @@ func (e *DefaultHTTPExecutor) Execute(ctx context.Context, method string, url st
 
 	resp, err := e.client.Do(req)
 	if err != nil {
-		return 0, nil, fmt.Errorf("executing request: %w", err)
+		return 0, "", nil, fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return resp.StatusCode, nil, fmt.Errorf("reading response: %w", err)
+		return resp.StatusCode, "", nil, fmt.Errorf("reading response: %w", err)
 	}
 
-	return resp.StatusCode, respBody, nil
+	return resp.StatusCode, resp.Header.Get("Content-Type"), respBody, nil
 }
@@ -12,15 +12,39 @@ import (
 	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
 )
 
+type QueueInspector interface {
+	QueueStats(queue string) (*hitqueue.QueueStats, error)
+}
+
+type HitResultReader interface {
+	GetResult(ctx context.Context, taskID string) (*hitqueue.HitResult, error)
+	GetLatestResult(ctx context.Context, job string) (*hitqueue.HitResult, error)
+	GetResults(ctx context.Context, taskIDs []string) ([]*hitqueue.HitResult, error)
+}
+
+type HitBatchReader interface {
+	GetBatch(ctx context.Context, batchID string) (*hitqueue.HitBatch, error)
+}
+
+type ScheduleLister interface {
+	Entries() []hitqueue.ScheduleInfo
+}
+
 type queryUsecase struct {
 	store       *memory.ConfigStore
-	resultStore *hitqueue.ResultStore
+	resultStore HitResultReader
+	inspector   QueueInspector
+	schedules   ScheduleLister
+	batches     HitBatchReader
 }
 
-func NewQueryUsecase(store *memory.ConfigStore, resultStore *hitqueue.ResultStore) domainuc.UsecaseWorkerQuery {
+func NewQueryUsecase(store *memory.ConfigStore, resultStore HitResultReader, inspector QueueInspector, schedules ScheduleLister, batches HitBatchReader) domainuc.UsecaseWorkerQuery {
 	return &queryUsecase{
 		store:       store,
 		resultStore: resultStore,
+		inspector:   inspector,
+		schedules:   schedules,
+		batches:     batches,
 	}
 }
 
@@ -58,3 +82,76 @@ func (q *queryUsecase) GetHitResult(ctx context.Context, taskID string) (*domain
 
 	return domainuc.ToHitResultResponse(result), nil
 }
+
+func (q *queryUsecase) ListJobs(ctx context.Context) ([]domainuc.HitJobResponse, error) {
+	cfg := q.store.Get()
+	if cfg == nil {
+		return []domainuc.HitJobResponse{}, nil
+	}
+	jobs, err := hitJobs(cfg.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domainuc.HitJobResponse, 0, len(jobs))
+	for _, name := range hitJobNames(jobs) {
+		job := domainuc.HitJobResponse{Name: name}
+		if payload, err := buildHitPayload(jobs[name], nil); err != nil {
+			job.Error = err.Error()
+		} else {
+			job.Method, job.URL = payload.Method, payload.URL
+		}
+		if q.resultStore != nil {
+			latest, err := q.resultStore.GetLatestResult(ctx, name)
+			if err != nil {
+				return nil, fmt.Errorf("getting latest result for job %s: %w", name, err)
+			}
+			if latest != nil {
+				job.Latest = domainuc.ToHitResultResponse(latest)
+			}
+		}
+		result = append(result, job)
+	}
+	return result, nil
+}
+
+func (q *queryUsecase) GetJobResult(ctx context.Context, job string) (*domainuc.HitResultResponse, error) {
+	if q.resultStore == nil {
+		return nil, fmt.Errorf("result store not configured")
+	}
+
+	cfg := q.store.Get()
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: %s", domainuc.ErrJobNotFound, job)
+	}
+	jobs, err := hitJobs(cfg.Data)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := jobs[job]; !ok {
+		return nil, fmt.Errorf("%w: %s", domainuc.ErrJobNotFound, job)
+	}
+
+	latest, err := q.resultStore.GetLatestResult(ctx, job)
+	if err != nil {
+		return nil, fmt.Errorf("getting latest result for job %s: %w", job, err)
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("%w: %s", domainuc.ErrNoHitResult, job)
+	}
+	return domainuc.ToHitResultResponse(latest), nil
+}
+
+func (q *queryUsecase) ListSchedules(_ context.Context) ([]hitqueue.ScheduleInfo, error) {
+	if q.schedules == nil {
+		return []hitqueue.ScheduleInfo{}, nil
+	}
+	return q.schedules.Entries(), nil
+}
+
+func (q *queryUsecase) QueueStats(_ context.Context) (*hitqueue.QueueStats, error) {
+	if q.inspector == nil {
+		return nil, fmt.Errorf("queue inspector not configured")
+	}
+	return q.inspector.QueueStats(hitqueue.QueueDefault)
+}
@@ -9,4 +9,5 @@ import (
 func (c *commandUsecase) ReceiveConfig(cfg *entity.Config) {
 	log.Printf("[config] received config version=%d", cfg.Version)
 	c.store.Set(cfg)
+	c.syncSchedules(cfg)
 }
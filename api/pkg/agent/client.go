@@ -0,0 +1,54 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/pkg/compression"
+	"github.com/adityawiryaa/api/pkg/httpclient"
+	"github.com/adityawiryaa/api/pkg/response"
+)
+
+type Client struct {
+	httpClient *httpclient.Client
+	baseURL    string
+}
+
+func NewClient(baseURL string, timeout time.Duration) *Client {
+	return &Client{
+		httpClient: httpclient.New(timeout),
+		baseURL:    baseURL,
+	}
+}
+
+func (c *Client) SetCompression(cfg compression.Config) {
+	c.httpClient.SetCompression(cfg)
+}
+
+func (c *Client) RegisterWorker(ctx context.Context, workerURL string) error {
+	resp, err := c.httpClient.Post(ctx, c.baseURL+"/workers", &request.RegisterWorkerRequest{URL: workerURL}, nil)
+	if err != nil {
+		return fmt.Errorf("registering worker with agent: %w", err)
+	}
+	return decodeAPIError(resp)
+}
+
+func (c *Client) DeregisterWorker(ctx context.Context, workerURL string) error {
+	resp, err := c.httpClient.Delete(ctx, c.baseURL+"/workers?url="+url.QueryEscape(workerURL), nil)
+	if err != nil {
+		return fmt.Errorf("deregistering worker from agent: %w", err)
+	}
+	return decodeAPIError(resp)
+}
+
+func decodeAPIError(resp *http.Response) error {
+	var apiResp response.APIResponse
+	if err := httpclient.DecodeResponse(resp, &apiResp); err != nil {
+		return err
+	}
+	return apiResp.Err()
+}
@@ -0,0 +1,104 @@
+package backoff
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type Scheduler struct {
+	mu       sync.Mutex
+	interval time.Duration
+	jitter   float64
+	cfg      Config
+	failures int
+}
+
+func NewScheduler(interval time.Duration, jitter float64, cfg Config) *Scheduler {
+	return &Scheduler{
+		interval: interval,
+		jitter:   jitter,
+		cfg:      cfg,
+	}
+}
+
+func (s *Scheduler) SetInterval(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.interval = interval
+}
+
+func (s *Scheduler) SetJitter(jitter float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jitter = jitter
+}
+
+func (s *Scheduler) SetMaxInterval(maxInterval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg.MaxInterval = maxInterval
+}
+
+func (s *Scheduler) Interval() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.interval
+}
+
+func (s *Scheduler) Failures() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failures
+}
+
+func (s *Scheduler) Next() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return Jitter(s.interval, s.jitter)
+}
+
+func (s *Scheduler) Success() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = 0
+	return Jitter(s.interval, s.jitter)
+}
+
+func (s *Scheduler) Failure() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures++
+	maxInterval := max(s.cfg.MaxInterval, s.interval)
+	wait := NextInterval(Config{
+		InitialInterval: s.interval,
+		MaxInterval:     maxInterval,
+		Multiplier:      s.cfg.Multiplier,
+		MaxRetries:      s.cfg.MaxRetries,
+	}, s.failures-1)
+	return min(wait, maxInterval)
+}
+
+// RetryAfter waits at least the server's requested delay, even past the
+// backoff cap, plus up to the jitter fraction of it so clients don't retry
+// in lockstep.
+func (s *Scheduler) RetryAfter(delay time.Duration) time.Duration {
+	if delay <= 0 {
+		return s.Failure()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures++
+	return delay + time.Duration(rand.Float64()*s.jitter*float64(delay))
+}
+
+func Jitter(interval time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || interval <= 0 {
+		return interval
+	}
+	offset := (rand.Float64()*2 - 1) * fraction * float64(interval)
+	return interval + time.Duration(offset)
+}
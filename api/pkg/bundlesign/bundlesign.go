@@ -0,0 +1,113 @@
+package bundlesign
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var ErrInvalidSignature = errors.New("bundle signature is invalid")
+
+type Signer struct {
+	key   ed25519.PrivateKey
+	keyID string
+}
+
+type Verifier struct {
+	key   ed25519.PublicKey
+	keyID string
+}
+
+func GenerateKey() (privatePEM, publicPEM []byte, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), nil
+}
+
+func LoadSigner(path string) (*Signer, error) {
+	der, err := readPEM(path, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing key %s: %w", path, err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is not an ed25519 key", path)
+	}
+	return &Signer{key: key, keyID: KeyID(key.Public().(ed25519.PublicKey))}, nil
+}
+
+func LoadVerifier(path string) (*Verifier, error) {
+	der, err := readPEM(path, "PUBLIC KEY")
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key %s: %w", path, err)
+	}
+	key, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key %s is not an ed25519 key", path)
+	}
+	return &Verifier{key: key, keyID: KeyID(key)}, nil
+}
+
+func KeyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+func (s *Signer) Sign(payload []byte) []byte {
+	return ed25519.Sign(s.key, payload)
+}
+
+func (v *Verifier) KeyID() string {
+	return v.keyID
+}
+
+func (v *Verifier) Verify(keyID string, payload, signature []byte) error {
+	if keyID != "" && keyID != v.keyID {
+		return fmt.Errorf("%w: signed with key %s, expected %s", ErrInvalidSignature, keyID, v.keyID)
+	}
+	if !ed25519.Verify(v.key, payload, signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func readPEM(path, blockType string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading key: %w", err)
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil || block.Type != blockType {
+		return nil, fmt.Errorf("%s does not contain a PEM %q block", path, blockType)
+	}
+	return block.Bytes, nil
+}
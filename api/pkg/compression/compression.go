@@ -0,0 +1,158 @@
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	Gzip     = "gzip"
+	Zstd     = "zstd"
+	Identity = "identity"
+	None     = "none"
+)
+
+const (
+	DefaultMinSize        = 1024
+	DefaultMaxRequestSize = 32 << 20
+)
+
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+type Config struct {
+	Encodings      []string
+	MinSize        int
+	MaxRequestSize int
+}
+
+func DefaultConfig() Config {
+	return Config{Encodings: []string{Zstd, Gzip}, MinSize: DefaultMinSize, MaxRequestSize: DefaultMaxRequestSize}
+}
+
+func (c Config) Enabled() bool {
+	return len(c.Encodings) > 0
+}
+
+func (c Config) Preferred() string {
+	if len(c.Encodings) == 0 {
+		return ""
+	}
+	return c.Encodings[0]
+}
+
+func (c Config) RequestLimit() int64 {
+	if c.MaxRequestSize <= 0 {
+		return DefaultMaxRequestSize
+	}
+	return int64(c.MaxRequestSize)
+}
+
+func (c Config) AcceptEncoding() string {
+	return strings.Join(c.Encodings, ", ")
+}
+
+func Supported(encoding string) bool {
+	return encoding == Gzip || encoding == Zstd
+}
+
+func NewWriter(encoding string, w io.Writer) (io.WriteCloser, error) {
+	switch encoding {
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedEncoding, encoding)
+	}
+}
+
+func NewReader(encoding string, r io.Reader) (io.ReadCloser, error) {
+	switch encoding {
+	case Gzip:
+		return gzip.NewReader(r)
+	case Zstd:
+		dec, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedEncoding, encoding)
+	}
+}
+
+func Compress(encoding string, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := NewWriter(encoding, &buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func Negotiate(acceptEncoding string, offered []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	quality := make(map[string]float64)
+	wildcard := -1.0
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if name == "*" {
+			wildcard = q
+			continue
+		}
+		quality[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range offered {
+		q, ok := quality[encoding]
+		if !ok {
+			q = wildcard
+		}
+		if q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+func ParseEncodings(value string) ([]string, error) {
+	var encodings []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		if item == "" || item == None {
+			continue
+		}
+		if !Supported(item) {
+			return nil, fmt.Errorf("%w: %q", ErrUnsupportedEncoding, item)
+		}
+		if !slices.Contains(encodings, item) {
+			encodings = append(encodings, item)
+		}
+	}
+	return encodings, nil
+}
@@ -0,0 +1,76 @@
+package configdiff
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/adityawiryaa/api/pkg/jsonpatch"
+)
+
+const (
+	OpAdded   = "added"
+	OpRemoved = "removed"
+	OpChanged = "changed"
+)
+
+type Change struct {
+	Key      string `json:"key"`
+	Op       string `json:"op"`
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
+}
+
+func Diff(oldData map[string]string, newData map[string]string) []Change {
+	var changes []Change
+	for k, oldVal := range oldData {
+		newVal, ok := newData[k]
+		switch {
+		case !ok:
+			changes = append(changes, Change{Key: k, Op: OpRemoved, OldValue: oldVal})
+		case newVal != oldVal:
+			changes = append(changes, Change{Key: k, Op: OpChanged, OldValue: oldVal, NewValue: newVal})
+		}
+	}
+	for k, newVal := range newData {
+		if _, ok := oldData[k]; !ok {
+			changes = append(changes, Change{Key: k, Op: OpAdded, NewValue: newVal})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+func Equal(a map[string]string, b map[string]string) bool {
+	return len(Diff(a, b)) == 0
+}
+
+func JSONPatch(oldData map[string]string, newData map[string]string) []jsonpatch.Operation {
+	changes := Diff(oldData, newData)
+	patch := make([]jsonpatch.Operation, 0, len(changes))
+	for _, change := range changes {
+		path := "/" + jsonpatch.EscapeKey(change.Key)
+		switch change.Op {
+		case OpAdded:
+			patch = append(patch, jsonpatch.Add(path, change.NewValue))
+		case OpRemoved:
+			patch = append(patch, jsonpatch.Remove(path))
+		case OpChanged:
+			patch = append(patch, jsonpatch.Replace(path, change.NewValue))
+		}
+	}
+	return patch
+}
+
+func Hash(data map[string]string) string {
+	if data == nil {
+		data = map[string]string{}
+	}
+	raw, _ := json.Marshal(data)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,200 @@
+package configformat
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+
+	MIMEJSON  = "application/json"
+	MIMEYAML  = "application/yaml"
+	MIMEXYAML = "application/x-yaml"
+	MIMETOML  = "application/toml"
+)
+
+type ConfigDocument struct {
+	Data                map[string]any `json:"data"`
+	PollIntervalSeconds int            `json:"poll_interval_seconds"`
+}
+
+func (d *ConfigDocument) StringData() map[string]string {
+	if d.Data == nil {
+		return nil
+	}
+	data := make(map[string]string, len(d.Data))
+	for k, v := range d.Data {
+		data[k] = Stringify(v)
+	}
+	return data
+}
+
+func FromContentType(contentType string) (string, error) {
+	if contentType == "" {
+		return FormatJSON, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("invalid content type %q: %w", contentType, err)
+	}
+	switch mediaType {
+	case MIMEJSON:
+		return FormatJSON, nil
+	case MIMEYAML, MIMEXYAML, "text/yaml":
+		return FormatYAML, nil
+	case MIMETOML:
+		return FormatTOML, nil
+	default:
+		return "", fmt.Errorf("unsupported content type %q", mediaType)
+	}
+}
+
+func FromExtension(ext string) (string, error) {
+	switch strings.ToLower(strings.TrimPrefix(ext, ".")) {
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "toml":
+		return FormatTOML, nil
+	default:
+		return "", fmt.Errorf("unsupported file extension %q", ext)
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatYAML:
+		return MIMEYAML + "; charset=utf-8"
+	case FormatTOML:
+		return MIMETOML + "; charset=utf-8"
+	default:
+		return MIMEJSON + "; charset=utf-8"
+	}
+}
+
+func Marshal(format string, v any) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatJSON {
+		return raw, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var generic any
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	generic = normalizeNumbers(generic)
+
+	switch format {
+	case FormatYAML:
+		return yaml.Marshal(generic)
+	case FormatTOML:
+		return toml.Marshal(generic)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func Unmarshal(format string, raw []byte, v any) error {
+	if format == FormatJSON {
+		return decodeJSON(raw, v)
+	}
+
+	var generic any
+	switch format {
+	case FormatYAML:
+		if err := yaml.Unmarshal(raw, &generic); err != nil {
+			return err
+		}
+	case FormatTOML:
+		var m map[string]any
+		if err := toml.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		generic = m
+	default:
+		return fmt.Errorf("unsupported format %q", format)
+	}
+
+	intermediate, err := json.Marshal(generic)
+	if err != nil {
+		return err
+	}
+	return decodeJSON(intermediate, v)
+}
+
+func decodeJSON(raw []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after the document")
+	}
+	return nil
+}
+
+func Stringify(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(val), 'f', -1, 32)
+	case map[string]any, []any:
+		raw, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprintf("%v", val)
+		}
+		return string(raw)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
+
+func normalizeNumbers(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			val[k] = normalizeNumbers(item)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = normalizeNumbers(item)
+		}
+		return val
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		if f, err := val.Float64(); err == nil {
+			return f
+		}
+		return val.String()
+	default:
+		return val
+	}
+}
@@ -0,0 +1,163 @@
+package configsource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/adityawiryaa/api/pkg/configformat"
+)
+
+type Snapshot struct {
+	Data                map[string]string
+	PollIntervalSeconds int
+	Revision            string
+	Hash                string
+	Files               []string
+}
+
+type DirSource struct {
+	dir     string
+	gitPull bool
+}
+
+func NewDirSource(dir string, gitPull bool) *DirSource {
+	return &DirSource{dir: dir, gitPull: gitPull}
+}
+
+func (s *DirSource) IsGit() bool {
+	_, err := os.Stat(filepath.Join(s.dir, ".git"))
+	return err == nil
+}
+
+func (s *DirSource) Load(ctx context.Context) (*Snapshot, error) {
+	isGit := s.IsGit()
+	if isGit && s.gitPull {
+		if _, err := s.git(ctx, "pull", "--ff-only", "--quiet"); err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := s.configFiles()
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no config files found in %s", s.dir)
+	}
+
+	snapshot := &Snapshot{Data: make(map[string]string)}
+	keySource := make(map[string]string)
+	for _, name := range files {
+		doc, err := readDocument(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range doc.Data {
+			if prev, ok := keySource[k]; ok {
+				return nil, fmt.Errorf("key %q is defined in both %s and %s", k, prev, name)
+			}
+			keySource[k] = name
+			snapshot.Data[k] = configformat.Stringify(v)
+		}
+
+		if doc.PollIntervalSeconds > 0 {
+			if snapshot.PollIntervalSeconds > 0 && snapshot.PollIntervalSeconds != doc.PollIntervalSeconds {
+				return nil, fmt.Errorf("conflicting poll_interval_seconds in %s", name)
+			}
+			snapshot.PollIntervalSeconds = doc.PollIntervalSeconds
+		}
+	}
+	snapshot.Files = files
+
+	hash, err := contentHash(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Hash = hash
+	snapshot.Revision = "sha256:" + hash[:12]
+
+	if isGit {
+		rev, err := s.git(ctx, "rev-parse", "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Revision = rev
+		dirty, err := s.git(ctx, "status", "--porcelain", "--", ".")
+		if err != nil {
+			return nil, err
+		}
+		if dirty != "" {
+			snapshot.Revision += "-dirty"
+		}
+	}
+
+	return snapshot, nil
+}
+
+func (s *DirSource) configFiles() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading sync dir: %w", err)
+	}
+
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".yaml", ".yml", ".json", ".toml":
+			files = append(files, e.Name())
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (s *DirSource) git(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", s.dir}, args...)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func readDocument(path string) (*configformat.ConfigDocument, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	format, err := configformat.FromExtension(filepath.Ext(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var doc configformat.ConfigDocument
+	if err := configformat.Unmarshal(format, raw, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &doc, nil
+}
+
+func contentHash(snapshot *Snapshot) (string, error) {
+	raw, err := json.Marshal(struct {
+		Data                map[string]string `json:"data"`
+		PollIntervalSeconds int               `json:"poll_interval_seconds"`
+	}{snapshot.Data, snapshot.PollIntervalSeconds})
+	if err != nil {
+		return "", fmt.Errorf("hashing snapshot: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
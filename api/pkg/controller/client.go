@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
+	"github.com/adityawiryaa/api/domain/dto"
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/pkg/compression"
 	"github.com/adityawiryaa/api/pkg/httpclient"
 	"github.com/adityawiryaa/api/pkg/response"
 )
@@ -26,6 +31,10 @@ func NewClient(baseURL string, apiKey string, timeout time.Duration) *Client {
 	}
 }
 
+func (c *Client) SetCompression(cfg compression.Config) {
+	c.httpClient.SetCompression(cfg)
+}
+
 func (c *Client) Register(ctx context.Context, req *entity.RegistrationRequest) (*entity.RegistrationResponse, error) {
 	headers := map[string]string{
 		"X-API-Key": c.apiKey,
@@ -56,34 +65,70 @@ func (c *Client) Register(ctx context.Context, req *entity.RegistrationRequest)
 	}, nil
 }
 
-func (c *Client) FetchConfig(ctx context.Context, currentVersion int64) (*entity.Config, bool, error) {
+func (c *Client) FetchConfig(ctx context.Context, agentID string, currentVersion int64) (*entity.ConfigFetch, error) {
 	headers := map[string]string{
 		"If-None-Match": strconv.FormatInt(currentVersion, 10),
 		"X-API-Key":     c.apiKey,
 	}
+	if agentID != "" {
+		headers["X-Agent-ID"] = agentID
+	}
+	if currentVersion > 0 {
+		headers["A-IM"] = "json-patch"
+	}
 
 	resp, err := c.httpClient.Get(ctx, c.baseURL+"/config", headers)
 	if err != nil {
-		return nil, false, fmt.Errorf("fetching config: %w", err)
+		return nil, fmt.Errorf("fetching config: %w", err)
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		resp.Body.Close()
+		return nil, &usecases.RetryAfterError{
+			StatusCode: resp.StatusCode,
+			After:      httpclient.RetryAfter(resp, time.Now()),
+		}
+	}
+
+	pollIntervalHint, _ := strconv.Atoi(resp.Header.Get("X-Poll-Interval"))
+	pendingCommands, _ := strconv.Atoi(resp.Header.Get("X-Pending-Commands"))
+
 	if resp.StatusCode == http.StatusNotModified {
 		resp.Body.Close()
-		return nil, false, nil
+		return &entity.ConfigFetch{PollIntervalSeconds: pollIntervalHint, PendingCommands: pendingCommands}, nil
 	}
 
 	var apiResp response.APIResponse
 	if err := httpclient.DecodeResponse(resp, &apiResp); err != nil {
-		return nil, false, err
+		return nil, err
 	}
 
 	if !apiResp.Success {
-		return nil, false, fmt.Errorf("fetch config failed: %s", apiResp.Error.Message)
+		if isAgentRejected(resp.StatusCode, apiResp.Error) {
+			return nil, fmt.Errorf("%w (HTTP %d)", usecases.ErrAgentNotFound, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("fetch config failed: %s", apiResp.Error.Message)
+	}
+
+	if resp.StatusCode == http.StatusIMUsed {
+		var patch entity.ConfigPatch
+		if err := apiResp.DecodeData(&patch); err != nil {
+			return nil, fmt.Errorf("decoding config patch: %w", err)
+		}
+		if pollIntervalHint <= 0 {
+			pollIntervalHint = patch.PollIntervalSeconds
+		}
+		return &entity.ConfigFetch{
+			Patch:               &patch,
+			Changed:             true,
+			PollIntervalSeconds: pollIntervalHint,
+			PendingCommands:     pendingCommands,
+		}, nil
 	}
 
 	data, ok := apiResp.Data.(map[string]any)
 	if !ok {
-		return nil, false, fmt.Errorf("unexpected response format")
+		return nil, fmt.Errorf("unexpected response format")
 	}
 
 	version, _ := data["version"].(float64)
@@ -103,5 +148,167 @@ func (c *Client) FetchConfig(ctx context.Context, currentVersion int64) (*entity
 		PollIntervalSeconds: int(pollInterval),
 	}
 
-	return cfg, true, nil
+	if pollIntervalHint <= 0 {
+		pollIntervalHint = cfg.PollIntervalSeconds
+	}
+	return &entity.ConfigFetch{
+		Config:              cfg,
+		Changed:             true,
+		PollIntervalSeconds: pollIntervalHint,
+		PendingCommands:     pendingCommands,
+	}, nil
+}
+
+func (c *Client) Report(ctx context.Context, agentID string, req *request.AgentReportRequest) error {
+	resp, err := c.httpClient.Post(ctx, c.agentURL(agentID)+"/report", req, c.authHeaders())
+	if err != nil {
+		return fmt.Errorf("reporting agent status: %w", err)
+	}
+
+	var apiResp response.APIResponse
+	if err := httpclient.DecodeResponse(resp, &apiResp); err != nil {
+		return err
+	}
+	if !apiResp.Success {
+		if isAgentRejected(resp.StatusCode, apiResp.Error) {
+			return fmt.Errorf("%w (HTTP %d)", usecases.ErrAgentNotFound, resp.StatusCode)
+		}
+		return apiResp.Err()
+	}
+	return nil
+}
+
+func (c *Client) ClaimCommands(ctx context.Context, agentID string) ([]entity.AgentCommand, error) {
+	resp, err := c.httpClient.Post(ctx, c.agentURL(agentID)+"/commands/claim", nil, c.authHeaders())
+	if err != nil {
+		return nil, fmt.Errorf("claiming commands: %w", err)
+	}
+
+	var apiResp response.APIResponse
+	if err := httpclient.DecodeResponse(resp, &apiResp); err != nil {
+		return nil, err
+	}
+	if !apiResp.Success {
+		if isAgentRejected(resp.StatusCode, apiResp.Error) {
+			return nil, fmt.Errorf("%w (HTTP %d)", usecases.ErrAgentNotFound, resp.StatusCode)
+		}
+		return nil, apiResp.Err()
+	}
+
+	var cmds []entity.AgentCommand
+	if err := apiResp.DecodeData(&cmds); err != nil {
+		return nil, err
+	}
+	return cmds, nil
+}
+
+func (c *Client) CompleteCommand(ctx context.Context, agentID, commandID string, req *request.CommandResultRequest) error {
+	resp, err := c.httpClient.Post(ctx, c.agentURL(agentID)+"/commands/"+url.PathEscape(commandID)+"/result", req, c.authHeaders())
+	if err != nil {
+		return fmt.Errorf("completing command %s: %w", commandID, err)
+	}
+
+	var apiResp response.APIResponse
+	if err := httpclient.DecodeResponse(resp, &apiResp); err != nil {
+		return err
+	}
+	return apiResp.Err()
+}
+
+func (c *Client) EnqueueCommand(ctx context.Context, agentID string, req *request.EnqueueCommandRequest) (*dto.AgentCommandDTO, error) {
+	resp, err := c.httpClient.Post(ctx, c.agentURL(agentID)+"/commands", req, c.authHeaders())
+	if err != nil {
+		return nil, fmt.Errorf("enqueueing command: %w", err)
+	}
+
+	var cmd dto.AgentCommandDTO
+	if err := decodeAPIData(resp, &cmd); err != nil {
+		return nil, err
+	}
+	return &cmd, nil
+}
+
+func (c *Client) ListAgentCommands(ctx context.Context, agentID string) ([]dto.AgentCommandDTO, error) {
+	resp, err := c.httpClient.Get(ctx, c.agentURL(agentID)+"/commands", c.authHeaders())
+	if err != nil {
+		return nil, fmt.Errorf("listing commands: %w", err)
+	}
+
+	var cmds []dto.AgentCommandDTO
+	if err := decodeAPIData(resp, &cmds); err != nil {
+		return nil, err
+	}
+	return cmds, nil
+}
+
+func (c *Client) PublishConfig(ctx context.Context, req *request.UpdateConfigRequest) (*dto.ConfigDTO, error) {
+	resp, err := c.httpClient.Post(ctx, c.baseURL+"/config", req, c.authHeaders())
+	if err != nil {
+		return nil, fmt.Errorf("publishing config: %w", err)
+	}
+
+	var cfg dto.ConfigDTO
+	if err := decodeAPIData(resp, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Client) GetConfig(ctx context.Context, version int64) (*dto.ConfigDTO, error) {
+	url := c.baseURL + "/config"
+	if version > 0 {
+		url += "/" + strconv.FormatInt(version, 10)
+	}
+
+	resp, err := c.httpClient.Get(ctx, url, c.authHeaders())
+	if err != nil {
+		return nil, fmt.Errorf("getting config: %w", err)
+	}
+
+	var cfg dto.ConfigDTO
+	if err := decodeAPIData(resp, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Client) ListAgents(ctx context.Context) ([]dto.AgentDTO, error) {
+	resp, err := c.httpClient.Get(ctx, c.baseURL+"/agents", c.authHeaders())
+	if err != nil {
+		return nil, fmt.Errorf("listing agents: %w", err)
+	}
+
+	var agents []dto.AgentDTO
+	if err := decodeAPIData(resp, &agents); err != nil {
+		return nil, err
+	}
+	return agents, nil
+}
+
+func isAgentRejected(statusCode int, apiErr *response.APIError) bool {
+	if statusCode == http.StatusUnauthorized {
+		return true
+	}
+	return statusCode == http.StatusNotFound && apiErr != nil && apiErr.Code == "AGENT_NOT_FOUND"
+}
+
+func (c *Client) agentURL(agentID string) string {
+	return c.baseURL + "/agents/" + url.PathEscape(agentID)
+}
+
+func (c *Client) authHeaders() map[string]string {
+	return map[string]string{
+		"X-API-Key": c.apiKey,
+	}
+}
+
+func decodeAPIData(resp *http.Response, target any) error {
+	var apiResp response.APIResponse
+	if err := httpclient.DecodeResponse(resp, &apiResp); err != nil {
+		return err
+	}
+	if err := apiResp.Err(); err != nil {
+		return err
+	}
+	return apiResp.DecodeData(target)
 }
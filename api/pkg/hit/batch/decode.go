@@ -0,0 +1,217 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/valueobject"
+)
+
+const (
+	FormatJSON   = "json"
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+const maxLineBytes = 1 << 20
+
+var ErrUnsupportedFormat = errors.New("unsupported batch format")
+
+func FormatOf(contentType, filename string) (string, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json":
+		return FormatJSON, nil
+	case "text/csv", "application/csv":
+		return FormatCSV, nil
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return FormatNDJSON, nil
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON, nil
+	}
+	if mediaType == "" && filename == "" {
+		return FormatJSON, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, strings.TrimSpace(mediaType+" "+filename))
+}
+
+func Decode(format string, r io.Reader) ([]request.BatchHitSpec, error) {
+	switch format {
+	case FormatJSON:
+		return decodeJSON(r)
+	case FormatCSV:
+		return decodeCSV(r)
+	case FormatNDJSON:
+		return decodeNDJSON(r)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+}
+
+func decodeJSON(r io.Reader) ([]request.BatchHitSpec, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading batch: %w", err)
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var hits []request.BatchHitSpec
+		if err := json.Unmarshal(data, &hits); err != nil {
+			return nil, fmt.Errorf("decoding batch: %w", err)
+		}
+		return hits, nil
+	}
+
+	var req request.HitBatchRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("decoding batch: %w", err)
+	}
+	return req.Hits, nil
+}
+
+func decodeNDJSON(r io.Reader) ([]request.BatchHitSpec, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+
+	var hits []request.BatchHitSpec
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var hit request.BatchHitSpec
+		if err := json.Unmarshal(text, &hit); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		hits = append(hits, hit)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading batch: %w", err)
+	}
+	return hits, nil
+}
+
+func decodeCSV(r io.Reader) ([]request.BatchHitSpec, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading csv header: %w", err)
+	}
+	for i, column := range header {
+		header[i] = strings.TrimSpace(column)
+		if !knownColumn(header[i]) {
+			return nil, fmt.Errorf("csv column %q must be url, method, body, body_type, content_type, headers.<name> or query.<name>", column)
+		}
+	}
+
+	var hits []request.BatchHitSpec
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return hits, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading csv: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		hit, err := csvHit(header, record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		hits = append(hits, hit)
+	}
+}
+
+func knownColumn(column string) bool {
+	switch column {
+	case "url", "method", "body", "body_type", "content_type":
+		return true
+	}
+	for _, prefix := range []string{"headers.", "query."} {
+		if name, ok := strings.CutPrefix(column, prefix); ok && name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func csvHit(header, record []string) (request.BatchHitSpec, error) {
+	var hit request.BatchHitSpec
+	var body string
+	for i, column := range header {
+		value := record[i]
+		if value == "" {
+			continue
+		}
+		switch column {
+		case "url":
+			hit.URL = value
+		case "method":
+			hit.Method = value
+		case "body":
+			body = value
+		case "body_type":
+			hit.BodyType = value
+		case "content_type":
+			hit.ContentType = value
+		default:
+			if name, ok := strings.CutPrefix(column, "headers."); ok {
+				hit.Headers = set(hit.Headers, name, value)
+			} else if name, ok := strings.CutPrefix(column, "query."); ok {
+				hit.Query = set(hit.Query, name, value)
+			}
+		}
+	}
+	if body == "" {
+		return hit, nil
+	}
+
+	var err error
+	switch hit.BodyType {
+	case valueobject.HitBodyJSON:
+		hit.Body = json.RawMessage(body)
+	case valueobject.HitBodyForm:
+		var fields url.Values
+		if fields, err = url.ParseQuery(body); err != nil {
+			return hit, fmt.Errorf("form body: %w", err)
+		}
+		form := make(map[string]string, len(fields))
+		for key := range fields {
+			form[key] = fields.Get(key)
+		}
+		hit.Body, err = json.Marshal(form)
+	default:
+		hit.Body, err = json.Marshal(body)
+	}
+	return hit, err
+}
+
+func set(values map[string]string, key, value string) map[string]string {
+	if values == nil {
+		values = make(map[string]string)
+	}
+	values[key] = value
+	return values
+}
@@ -0,0 +1,143 @@
+package extract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+
+	"github.com/adityawiryaa/api/domain/valueobject"
+)
+
+var ErrNotHTML = errors.New("response is not HTML")
+
+type Rule struct {
+	Name     string `json:"name"`
+	Selector string `json:"selector,omitempty"`
+	Mode     string `json:"mode,omitempty"`
+	Attr     string `json:"attr,omitempty"`
+	List     bool   `json:"list,omitempty"`
+	Fields   []Rule `json:"fields,omitempty"`
+}
+
+func Validate(rules []Rule) error {
+	return validate(rules, "")
+}
+
+func validate(rules []Rule, parent string) error {
+	for _, rule := range rules {
+		path := parent + rule.Name
+		if rule.Selector == "" && parent == "" {
+			return fmt.Errorf("%s: selector is required", path)
+		}
+		if rule.Selector != "" {
+			if _, err := cascadia.ParseGroup(rule.Selector); err != nil {
+				return fmt.Errorf("%s: selector %q: %w", path, rule.Selector, err)
+			}
+		}
+		if len(rule.Fields) > 0 {
+			if rule.Mode != "" || rule.Attr != "" {
+				return fmt.Errorf("%s: a group with fields cannot set mode or attr", path)
+			}
+			if err := validate(rule.Fields, path+"."); err != nil {
+				return err
+			}
+			continue
+		}
+		switch rule.Mode {
+		case "", valueobject.ExtractText, valueobject.ExtractHTML:
+			if rule.Attr != "" {
+				return fmt.Errorf("%s: attr is only used with mode %s", path, valueobject.ExtractAttr)
+			}
+		case valueobject.ExtractAttr:
+			if rule.Attr == "" {
+				return fmt.Errorf("%s: mode %s needs an attr", path, valueobject.ExtractAttr)
+			}
+		default:
+			return fmt.Errorf("%s: mode %q must be one of %s, %s, %s", path, rule.Mode,
+				valueobject.ExtractText, valueobject.ExtractAttr, valueobject.ExtractHTML)
+		}
+	}
+	return nil
+}
+
+func IsHTML(contentType string, body []byte) bool {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+	}
+	detected := http.DetectContentType(body)
+	return strings.HasPrefix(detected, "text/html") ||
+		(strings.HasPrefix(detected, "text/") && bytes.ContainsRune(body, '<'))
+}
+
+func Extract(contentType string, body []byte, rules []Rule) (json.RawMessage, error) {
+	if !IsHTML(contentType, body) {
+		if contentType == "" {
+			return nil, fmt.Errorf("%w: detected %s", ErrNotHTML, http.DetectContentType(body))
+		}
+		return nil, fmt.Errorf("%w: content type %s", ErrNotHTML, contentType)
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("parsing html: %w", err)
+	}
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(evaluate(doc.Selection, rules)); err != nil {
+		return nil, fmt.Errorf("encoding extracted fields: %w", err)
+	}
+	return bytes.TrimSpace(data.Bytes()), nil
+}
+
+func evaluate(root *goquery.Selection, rules []Rule) map[string]any {
+	result := make(map[string]any, len(rules))
+	for _, rule := range rules {
+		matches := root
+		if rule.Selector != "" {
+			matches = root.Find(rule.Selector)
+		}
+		if !rule.List {
+			if matches.Length() == 0 {
+				result[rule.Name] = nil
+				continue
+			}
+			result[rule.Name] = rule.value(matches.First())
+			continue
+		}
+
+		values := make([]any, 0, matches.Length())
+		matches.Each(func(_ int, s *goquery.Selection) {
+			values = append(values, rule.value(s))
+		})
+		result[rule.Name] = values
+	}
+	return result
+}
+
+func (r Rule) value(s *goquery.Selection) any {
+	if len(r.Fields) > 0 {
+		return evaluate(s, r.Fields)
+	}
+	switch r.Mode {
+	case valueobject.ExtractAttr:
+		if value, ok := s.Attr(r.Attr); ok {
+			return value
+		}
+		return nil
+	case valueobject.ExtractHTML:
+		html, err := s.Html()
+		if err != nil {
+			return nil
+		}
+		return strings.TrimSpace(html)
+	default:
+		return strings.TrimSpace(s.Text())
+	}
+}
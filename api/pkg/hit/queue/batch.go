@@ -0,0 +1,85 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const resultBatchSize = 500
+
+type HitBatch struct {
+	ID        string         `json:"id"`
+	CreatedAt time.Time      `json:"created_at"`
+	Tasks     []HitBatchTask `json:"tasks"`
+}
+
+type HitBatchTask struct {
+	TaskID string `json:"task_id"`
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (s *ResultStore) SaveBatch(ctx context.Context, batch *HitBatch) error {
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("marshaling batch: %w", err)
+	}
+	if err := s.rdb.Set(ctx, batchKey(batch.ID), data, resultTTL).Err(); err != nil {
+		return fmt.Errorf("saving batch: %w", err)
+	}
+	return nil
+}
+
+func (s *ResultStore) GetBatch(ctx context.Context, id string) (*HitBatch, error) {
+	data, err := s.rdb.Get(ctx, batchKey(id)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting batch: %w", err)
+	}
+
+	var batch HitBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, fmt.Errorf("unmarshaling batch: %w", err)
+	}
+	return &batch, nil
+}
+
+func (s *ResultStore) GetResults(ctx context.Context, taskIDs []string) ([]*HitResult, error) {
+	results := make([]*HitResult, 0, len(taskIDs))
+	for start := 0; start < len(taskIDs); start += resultBatchSize {
+		end := min(start+resultBatchSize, len(taskIDs))
+		keys := make([]string, 0, end-start)
+		for _, id := range taskIDs[start:end] {
+			keys = append(keys, resultKey(id))
+		}
+
+		values, err := s.rdb.MGet(ctx, keys...).Result()
+		if err != nil {
+			return nil, fmt.Errorf("getting results: %w", err)
+		}
+		for _, value := range values {
+			data, ok := value.(string)
+			if !ok {
+				results = append(results, nil)
+				continue
+			}
+			var result HitResult
+			if err := json.Unmarshal([]byte(data), &result); err != nil {
+				return nil, fmt.Errorf("unmarshaling result: %w", err)
+			}
+			results = append(results, &result)
+		}
+	}
+	return results, nil
+}
+
+func batchKey(id string) string {
+	return "worker:hit:batch:" + id
+}
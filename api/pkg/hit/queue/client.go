@@ -10,21 +10,34 @@ import (
 	"github.com/hibiken/asynq"
 )
 
+type TaskEnqueuer interface {
+	Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
 type Client struct {
-	client *asynq.Client
+	client   *asynq.Client
+	enqueuer TaskEnqueuer
 }
 
 func NewClient(redisAddr string, db int) *Client {
 	log.Printf("[queue] client connected to redis: addr=%s db=%d", redisAddr, db)
-	return &Client{
-		client: asynq.NewClient(asynq.RedisClientOpt{
-			Addr: redisAddr,
-			DB:   db,
-		}),
-	}
+	client := asynq.NewClient(asynq.RedisClientOpt{
+		Addr: redisAddr,
+		DB:   db,
+	})
+	c := NewClientWithEnqueuer(client)
+	c.client = client
+	return c
+}
+
+func NewClientWithEnqueuer(enqueuer TaskEnqueuer) *Client {
+	return &Client{enqueuer: enqueuer}
 }
 
 func (c *Client) Close() error {
+	if c.client == nil {
+		return nil
+	}
 	return c.client.Close()
 }
 
@@ -35,7 +48,7 @@ func (c *Client) EnqueueExecuteHit(payload *ExecuteHitPayload) error {
 	}
 
 	task := asynq.NewTask(TypeHitExecute, data)
-	_, err = c.client.Enqueue(task, getTaskOptions()...)
+	_, err = c.enqueuer.Enqueue(task, getTaskOptions()...)
 	if err != nil {
 		return fmt.Errorf("enqueuing task: %w", err)
 	}
@@ -43,6 +56,18 @@ func (c *Client) EnqueueExecuteHit(payload *ExecuteHitPayload) error {
 	return nil
 }
 
+// EnqueueExecuteHits enqueues the payloads one task at a time, in order, and
+// stops at the first failure. It returns how many leading payloads were
+// enqueued; those tasks stay queued, and payloads[n:] were not enqueued.
+func (c *Client) EnqueueExecuteHits(payloads []*ExecuteHitPayload) (int, error) {
+	for i, payload := range payloads {
+		if err := c.EnqueueExecuteHit(payload); err != nil {
+			return i, fmt.Errorf("task %s: %w", payload.TaskID, err)
+		}
+	}
+	return len(payloads), nil
+}
+
 func getTaskOptions() []asynq.Option {
 	maxRetry := 3
 	if v := os.Getenv("WORKER_RETRY_MAX"); v != "" {
@@ -53,6 +78,6 @@ func getTaskOptions() []asynq.Option {
 
 	return []asynq.Option{
 		asynq.MaxRetry(maxRetry),
-		asynq.Queue("default"),
+		asynq.Queue(QueueDefault),
 	}
 }
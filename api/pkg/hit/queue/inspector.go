@@ -0,0 +1,65 @@
+package queue
+
+import (
+	"fmt"
+
+	"github.com/hibiken/asynq"
+)
+
+type QueueStats struct {
+	Queue          string `json:"queue"`
+	Size           int    `json:"size"`
+	Pending        int    `json:"pending"`
+	Active         int    `json:"active"`
+	Scheduled      int    `json:"scheduled"`
+	Retry          int    `json:"retry"`
+	Archived       int    `json:"archived"`
+	Completed      int    `json:"completed"`
+	ProcessedToday int    `json:"processed_today"`
+	FailedToday    int    `json:"failed_today"`
+	ProcessedTotal int    `json:"processed_total"`
+	FailedTotal    int    `json:"failed_total"`
+	LatencyMS      int64  `json:"latency_ms"`
+	Paused         bool   `json:"paused"`
+}
+
+type Inspector struct {
+	inspector *asynq.Inspector
+}
+
+func NewInspector(redisAddr string, db int) *Inspector {
+	return &Inspector{
+		inspector: asynq.NewInspector(asynq.RedisClientOpt{
+			Addr: redisAddr,
+			DB:   db,
+		}),
+	}
+}
+
+func (i *Inspector) Close() error {
+	return i.inspector.Close()
+}
+
+func (i *Inspector) QueueStats(queue string) (*QueueStats, error) {
+	info, err := i.inspector.GetQueueInfo(queue)
+	if err != nil {
+		return nil, fmt.Errorf("getting queue info: %w", err)
+	}
+
+	return &QueueStats{
+		Queue:          info.Queue,
+		Size:           info.Size,
+		Pending:        info.Pending,
+		Active:         info.Active,
+		Scheduled:      info.Scheduled,
+		Retry:          info.Retry,
+		Archived:       info.Archived,
+		Completed:      info.Completed,
+		ProcessedToday: info.Processed,
+		FailedToday:    info.Failed,
+		ProcessedTotal: info.ProcessedTotal,
+		FailedTotal:    info.FailedTotal,
+		LatencyMS:      info.Latency.Milliseconds(),
+		Paused:         info.Paused,
+	}, nil
+}
@@ -5,13 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"time"
 
 	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/pkg/hit/extract"
 	"github.com/hibiken/asynq"
 )
 
 type HTTPExecutor interface {
-	Execute(ctx context.Context, method string, url string, headers map[string]string, body []byte) (int, []byte, error)
+	Execute(ctx context.Context, method string, url string, headers map[string]string, body []byte) (int, string, []byte, error)
 }
 
 type Processor struct {
@@ -31,16 +34,21 @@ func (p *Processor) HandleExecuteHit(ctx context.Context, t *asynq.Task) error {
 	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
 		return fmt.Errorf("unmarshaling payload: %w", err)
 	}
+	if payload.TaskID == "" {
+		payload.TaskID, _ = asynq.GetTaskID(ctx)
+	}
 
-	log.Printf("[processor] picked up task: id=%s type=%s url=%s method=%s", payload.TaskID, TypeHitExecute, payload.URL, payload.Method)
+	log.Printf("[processor] picked up task: id=%s type=%s job=%s url=%s method=%s", payload.TaskID, TypeHitExecute, payload.Job, payload.URL, payload.Method)
 
-	statusCode, body, err := p.executor.Execute(ctx, payload.Method, payload.URL, nil, nil)
+	statusCode, contentType, body, err := p.execute(ctx, &payload)
 	if err != nil {
 		log.Printf("[processor] execution failed: id=%s error=%v", payload.TaskID, err)
 		saveErr := p.store.SaveResult(ctx, &HitResult{
-			TaskID: payload.TaskID,
-			Status: valueobject.TaskStatusFailed,
-			Error:  err.Error(),
+			TaskID:      payload.TaskID,
+			Job:         payload.Job,
+			Status:      valueobject.TaskStatusFailed,
+			Error:       err.Error(),
+			CompletedAt: time.Now(),
 		})
 		if saveErr != nil {
 			log.Printf("[processor] failed to save error result: id=%s error=%v", payload.TaskID, saveErr)
@@ -49,10 +57,23 @@ func (p *Processor) HandleExecuteHit(ctx context.Context, t *asynq.Task) error {
 	}
 
 	result := &HitResult{
-		TaskID:     payload.TaskID,
-		Status:     valueobject.TaskStatusCompleted,
-		StatusCode: statusCode,
-		Body:       string(body),
+		TaskID:      payload.TaskID,
+		Job:         payload.Job,
+		Status:      valueobject.TaskStatusCompleted,
+		StatusCode:  statusCode,
+		Body:        string(body),
+		CompletedAt: time.Now(),
+	}
+	if len(payload.Extract) > 0 {
+		extracted, err := extract.Extract(contentType, body, payload.Extract)
+		if err != nil {
+			log.Printf("[processor] extraction failed: id=%s error=%v", payload.TaskID, err)
+			result.ExtractError = err.Error()
+		}
+		result.Extracted = extracted
+	}
+	if payload.DropBody {
+		result.Body = ""
 	}
 
 	if err := p.store.SaveResult(ctx, result); err != nil {
@@ -64,6 +85,18 @@ func (p *Processor) HandleExecuteHit(ctx context.Context, t *asynq.Task) error {
 	return nil
 }
 
+func (p *Processor) execute(ctx context.Context, payload *ExecuteHitPayload) (int, string, []byte, error) {
+	requestURL, err := payload.RequestURL()
+	if err != nil {
+		return 0, "", nil, err
+	}
+	method := payload.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return p.executor.Execute(ctx, method, requestURL, payload.RequestHeaders(), payload.RequestBody())
+}
+
 func (p *Processor) RegisterHandlers(mux *asynq.ServeMux) {
 	mux.HandleFunc(TypeHitExecute, p.HandleExecuteHit)
 }
@@ -9,14 +9,21 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-const resultTTL = 1 * time.Hour
+const (
+	resultTTL       = 1 * time.Hour
+	latestResultTTL = 7 * 24 * time.Hour
+)
 
 type HitResult struct {
-	TaskID     string `json:"task_id"`
-	Status     string `json:"status"`
-	StatusCode int    `json:"status_code,omitempty"`
-	Body       string `json:"body,omitempty"`
-	Error      string `json:"error,omitempty"`
+	TaskID       string          `json:"task_id"`
+	Job          string          `json:"job,omitempty"`
+	Status       string          `json:"status"`
+	StatusCode   int             `json:"status_code,omitempty"`
+	Body         string          `json:"body,omitempty"`
+	Extracted    json.RawMessage `json:"extracted,omitempty"`
+	ExtractError string          `json:"extract_error,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	CompletedAt  time.Time       `json:"completed_at"`
 }
 
 type ResultStore struct {
@@ -33,12 +40,24 @@ func (s *ResultStore) SaveResult(ctx context.Context, result *HitResult) error {
 		return fmt.Errorf("marshaling result: %w", err)
 	}
 
-	key := resultKey(result.TaskID)
-	return s.rdb.Set(ctx, key, data, resultTTL).Err()
+	pipe := s.rdb.TxPipeline()
+	pipe.Set(ctx, resultKey(result.TaskID), data, resultTTL)
+	if result.Job != "" {
+		pipe.Set(ctx, latestKey(result.Job), data, latestResultTTL)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
 }
 
 func (s *ResultStore) GetResult(ctx context.Context, taskID string) (*HitResult, error) {
-	key := resultKey(taskID)
+	return s.get(ctx, resultKey(taskID))
+}
+
+func (s *ResultStore) GetLatestResult(ctx context.Context, job string) (*HitResult, error) {
+	return s.get(ctx, latestKey(job))
+}
+
+func (s *ResultStore) get(ctx context.Context, key string) (*HitResult, error) {
 	data, err := s.rdb.Get(ctx, key).Bytes()
 	if err == redis.Nil {
 		return nil, nil
@@ -58,3 +77,7 @@ func (s *ResultStore) GetResult(ctx context.Context, taskID string) (*HitResult,
 func resultKey(taskID string) string {
 	return "worker:hit:result:" + taskID
 }
+
+func latestKey(job string) string {
+	return "worker:hit:latest:" + job
+}
@@ -0,0 +1,224 @@
+package queue
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/robfig/cron/v3"
+)
+
+const minScheduleUniqueTTL = time.Second
+
+type Registrar interface {
+	Register(cronspec string, task *asynq.Task, opts ...asynq.Option) (string, error)
+	Unregister(entryID string) error
+}
+
+type ScheduleEntry struct {
+	Job     string
+	Spec    string
+	Payload *ExecuteHitPayload
+	Err     error
+}
+
+type ScheduleInfo struct {
+	Job          string     `json:"job"`
+	Schedule     string     `json:"schedule"`
+	EntryID      string     `json:"entry_id,omitempty"`
+	Method       string     `json:"method,omitempty"`
+	URL          string     `json:"url,omitempty"`
+	NextRunAt    *time.Time `json:"next_run_at,omitempty"`
+	RegisteredAt *time.Time `json:"registered_at,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+type scheduled struct {
+	info        ScheduleInfo
+	fingerprint string
+	schedule    cron.Schedule
+}
+
+type Scheduler struct {
+	scheduler *asynq.Scheduler
+	registrar Registrar
+	location  *time.Location
+	now       func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*scheduled
+}
+
+func NewScheduler(redisAddr string, db int, location *time.Location) *Scheduler {
+	scheduler := asynq.NewScheduler(
+		asynq.RedisClientOpt{Addr: redisAddr, DB: db},
+		&asynq.SchedulerOpts{
+			Location: location,
+			PostEnqueueFunc: func(info *asynq.TaskInfo, err error) {
+				if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) {
+					log.Printf("[scheduler] failed to enqueue scheduled hit: %v", err)
+				}
+			},
+		},
+	)
+	s := NewSchedulerWithRegistrar(scheduler, location, time.Now)
+	s.scheduler = scheduler
+	return s
+}
+
+func NewSchedulerWithRegistrar(registrar Registrar, location *time.Location, now func() time.Time) *Scheduler {
+	if location == nil {
+		location = time.UTC
+	}
+	return &Scheduler{
+		registrar: registrar,
+		location:  location,
+		now:       now,
+		entries:   make(map[string]*scheduled),
+	}
+}
+
+func (s *Scheduler) Start() error {
+	if s.scheduler == nil {
+		return nil
+	}
+	return s.scheduler.Start()
+}
+
+func (s *Scheduler) Shutdown() {
+	if s.scheduler != nil {
+		s.scheduler.Shutdown()
+	}
+}
+
+func (s *Scheduler) Sync(entries []ScheduleEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	wanted := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		wanted[entry.Job] = true
+		if err := s.apply(entry); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", entry.Job, err))
+		}
+	}
+
+	for job, current := range s.entries {
+		if wanted[job] {
+			continue
+		}
+		if err := s.unregister(current); err != nil {
+			errs = append(errs, fmt.Errorf("job %s: %w", job, err))
+		}
+		delete(s.entries, job)
+		log.Printf("[scheduler] removed schedule: job=%s", job)
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Scheduler) Entries() []ScheduleInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now().In(s.location)
+	result := make([]ScheduleInfo, 0, len(s.entries))
+	for _, job := range slices.Sorted(maps.Keys(s.entries)) {
+		entry := s.entries[job]
+		info := entry.info
+		if entry.schedule != nil {
+			next := entry.schedule.Next(now)
+			info.NextRunAt = &next
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+func (s *Scheduler) apply(entry ScheduleEntry) error {
+	current := s.entries[entry.Job]
+	next := &scheduled{info: ScheduleInfo{Job: entry.Job, Schedule: entry.Spec}}
+
+	err := entry.Err
+	var task *asynq.Task
+	if err == nil {
+		next.schedule, err = cron.ParseStandard(entry.Spec)
+		if err != nil {
+			err = fmt.Errorf("invalid schedule %q: %w", entry.Spec, err)
+		}
+	}
+	if err == nil {
+		task, next.fingerprint, err = scheduledTask(entry)
+	}
+	if err != nil {
+		next.schedule = nil
+		next.info.Error = err.Error()
+		if unregisterErr := s.unregister(current); unregisterErr != nil {
+			err = errors.Join(err, unregisterErr)
+		}
+		s.entries[entry.Job] = next
+		return err
+	}
+
+	if current != nil && current.info.EntryID != "" && current.fingerprint == next.fingerprint {
+		return nil
+	}
+	if err := s.unregister(current); err != nil {
+		return err
+	}
+
+	next.info.Method, next.info.URL = entry.Payload.Method, entry.Payload.URL
+	entryID, err := s.registrar.Register(entry.Spec, task, s.taskOptions(next.schedule)...)
+	if err != nil {
+		next.schedule = nil
+		next.info.Error = err.Error()
+		s.entries[entry.Job] = next
+		return fmt.Errorf("registering schedule: %w", err)
+	}
+	registeredAt := s.now()
+	next.info.EntryID = entryID
+	next.info.RegisteredAt = &registeredAt
+	s.entries[entry.Job] = next
+	log.Printf("[scheduler] registered schedule: job=%s spec=%q entry=%s", entry.Job, entry.Spec, entryID)
+	return nil
+}
+
+func (s *Scheduler) unregister(entry *scheduled) error {
+	if entry == nil || entry.info.EntryID == "" {
+		return nil
+	}
+	if err := s.registrar.Unregister(entry.info.EntryID); err != nil {
+		return fmt.Errorf("unregistering schedule %s: %w", entry.info.EntryID, err)
+	}
+	entry.info.EntryID = ""
+	return nil
+}
+
+func (s *Scheduler) taskOptions(schedule cron.Schedule) []asynq.Option {
+	first := schedule.Next(s.now().In(s.location))
+	ttl := schedule.Next(first).Sub(first) / 2
+	if ttl < minScheduleUniqueTTL {
+		ttl = minScheduleUniqueTTL
+	}
+	return append(getTaskOptions(), asynq.Unique(ttl))
+}
+
+func scheduledTask(entry ScheduleEntry) (*asynq.Task, string, error) {
+	if entry.Payload == nil {
+		return nil, "", fmt.Errorf("missing hit payload")
+	}
+	payload := *entry.Payload
+	payload.TaskID = ""
+	payload.Job = entry.Job
+	data, err := json.Marshal(&payload)
+	if err != nil {
+		return nil, "", fmt.Errorf("marshaling payload: %w", err)
+	}
+	return asynq.NewTask(TypeHitExecute, data), entry.Spec + "\n" + string(data), nil
+}
@@ -1,9 +1,64 @@
 package queue
 
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/adityawiryaa/api/pkg/hit/extract"
+)
+
 const TypeHitExecute = "worker:hit:execute"
 
+const QueueDefault = "default"
+
 type ExecuteHitPayload struct {
-	TaskID string `json:"task_id"`
-	URL    string `json:"url"`
-	Method string `json:"method"`
+	TaskID      string            `json:"task_id"`
+	Job         string            `json:"job,omitempty"`
+	Batch       string            `json:"batch,omitempty"`
+	URL         string            `json:"url"`
+	Method      string            `json:"method"`
+	Query       map[string]string `json:"query,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        string            `json:"body,omitempty"`
+	ContentType string            `json:"content_type,omitempty"`
+	Extract     []extract.Rule    `json:"extract,omitempty"`
+	DropBody    bool              `json:"drop_body,omitempty"`
+}
+
+func (p *ExecuteHitPayload) RequestURL() (string, error) {
+	if len(p.Query) == 0 {
+		return p.URL, nil
+	}
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return "", fmt.Errorf("parsing hit url: %w", err)
+	}
+	query := u.Query()
+	for key, value := range p.Query {
+		query.Set(key, value)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func (p *ExecuteHitPayload) RequestHeaders() map[string]string {
+	if len(p.Headers) == 0 && p.ContentType == "" {
+		return nil
+	}
+	headers := make(map[string]string, len(p.Headers)+1)
+	for key, value := range p.Headers {
+		headers[http.CanonicalHeaderKey(key)] = value
+	}
+	if _, ok := headers["Content-Type"]; !ok && p.ContentType != "" {
+		headers["Content-Type"] = p.ContentType
+	}
+	return headers
+}
+
+func (p *ExecuteHitPayload) RequestBody() []byte {
+	if p.Body == "" {
+		return nil
+	}
+	return []byte(p.Body)
 }
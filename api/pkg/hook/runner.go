@@ -0,0 +1,107 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/pkg/compression"
+	"github.com/adityawiryaa/api/pkg/httpclient"
+	"github.com/adityawiryaa/api/pkg/shellcmd"
+)
+
+const (
+	defaultTimeoutSeconds      = 10
+	defaultRetryBackoffSeconds = 1
+)
+
+type Runner struct {
+	hooks      []entity.HookDefinition
+	httpClient *httpclient.Client
+}
+
+func NewRunner(defs []entity.HookDefinition) (*Runner, error) {
+	seen := make(map[string]bool, len(defs))
+	hooks := make([]entity.HookDefinition, 0, len(defs))
+	for i, def := range defs {
+		if def.Name == "" {
+			return nil, fmt.Errorf("hook %d: name is required", i)
+		}
+		if seen[def.Name] {
+			return nil, fmt.Errorf("hook %s: duplicate name", def.Name)
+		}
+		seen[def.Name] = true
+
+		if (def.Command == "") == (def.URL == "") {
+			return nil, fmt.Errorf("hook %s: exactly one of command or url is required", def.Name)
+		}
+		if def.URL != "" {
+			parsed, err := url.Parse(def.URL)
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return nil, fmt.Errorf("hook %s: invalid url %q", def.Name, def.URL)
+			}
+		}
+		if def.TimeoutSeconds < 0 || def.Retries < 0 || def.RetryBackoffSeconds < 0 {
+			return nil, fmt.Errorf("hook %s: timeout, retries and backoff must not be negative", def.Name)
+		}
+
+		if def.TimeoutSeconds == 0 {
+			def.TimeoutSeconds = defaultTimeoutSeconds
+		}
+		if def.RetryBackoffSeconds == 0 {
+			def.RetryBackoffSeconds = defaultRetryBackoffSeconds
+		}
+		hooks = append(hooks, def)
+	}
+
+	httpClient := httpclient.New(0)
+	httpClient.SetCompression(compression.Config{})
+
+	return &Runner{
+		hooks:      hooks,
+		httpClient: httpClient,
+	}, nil
+}
+
+func (r *Runner) Hooks() []entity.HookDefinition {
+	return r.hooks
+}
+
+func (r *Runner) Run(ctx context.Context, def entity.HookDefinition, cfg *entity.Config) error {
+	payload, err := json.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+
+	timeout := time.Duration(def.TimeoutSeconds) * time.Second
+	if def.Command != "" {
+		_, err := shellcmd.Run(ctx, def.Command, timeout, bytes.NewReader(payload), "CONFIG_VERSION="+strconv.FormatInt(cfg.Version, 10))
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	headers := map[string]string{"X-Config-Version": strconv.FormatInt(cfg.Version, 10)}
+	for k, v := range def.Headers {
+		headers[k] = v
+	}
+	resp, err := r.httpClient.Post(ctx, def.URL, json.RawMessage(payload), headers)
+	if err != nil {
+		return fmt.Errorf("POST %s: %w", def.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("POST %s: HTTP %d: %s", def.URL, resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return nil
+}
@@ -7,19 +7,35 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/adityawiryaa/api/pkg/compression"
 )
 
 type Client struct {
-	http *http.Client
+	http        *http.Client
+	compression compression.Config
+
+	mu         sync.Mutex
+	plainHosts map[string]bool
 }
 
 func New(timeout time.Duration) *Client {
 	return &Client{
-		http: &http.Client{Timeout: timeout},
+		http:        &http.Client{Timeout: timeout},
+		compression: compression.DefaultConfig(),
+		plainHosts:  make(map[string]bool),
 	}
 }
 
+func (c *Client) SetCompression(cfg compression.Config) {
+	c.compression = cfg
+}
+
 func (c *Client) Get(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -28,39 +44,120 @@ func (c *Client) Get(ctx context.Context, url string, headers map[string]string)
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
-	return c.http.Do(req)
+	return c.do(req)
 }
 
 func (c *Client) Post(ctx context.Context, url string, body any, headers map[string]string) (*http.Response, error) {
+	return c.sendJSON(ctx, http.MethodPost, url, body, headers)
+}
+
+func (c *Client) Put(ctx context.Context, url string, body any, headers map[string]string) (*http.Response, error) {
+	return c.sendJSON(ctx, http.MethodPut, url, body, headers)
+}
+
+func (c *Client) sendJSON(ctx context.Context, method string, url string, body any, headers map[string]string) (*http.Response, error) {
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling body: %w", err)
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonBody))
+
+	encoding := c.requestEncoding(url, len(jsonBody))
+	resp, err := c.sendBody(ctx, method, url, jsonBody, encoding, headers)
+	if err != nil || encoding == "" || resp.StatusCode != http.StatusUnsupportedMediaType {
+		return resp, err
+	}
+
+	resp.Body.Close()
+	c.markPlain(url)
+	return c.sendBody(ctx, method, url, jsonBody, "", headers)
+}
+
+func (c *Client) sendBody(ctx context.Context, method string, url string, body []byte, encoding string, headers map[string]string) (*http.Response, error) {
+	if encoding != "" {
+		compressed, err := compression.Compress(encoding, body)
+		if err != nil {
+			return nil, fmt.Errorf("compressing body: %w", err)
+		}
+		body = compressed
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	if encoding != "" {
+		req.Header.Set("Content-Encoding", encoding)
+	}
 	for k, v := range headers {
 		req.Header.Set(k, v)
 	}
-	return c.http.Do(req)
+	return c.do(req)
 }
 
-func (c *Client) Put(ctx context.Context, url string, body any, headers map[string]string) (*http.Response, error) {
-	jsonBody, err := json.Marshal(body)
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.compression.Enabled() && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", c.compression.AcceptEncoding())
+	}
+	resp, err := c.http.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("marshaling body: %w", err)
+		return nil, err
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(jsonBody))
+
+	encoding := strings.ToLower(resp.Header.Get("Content-Encoding"))
+	if !compression.Supported(encoding) {
+		return resp, nil
+	}
+	decoded, err := compression.NewReader(encoding, resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
+		resp.Body.Close()
+		return nil, fmt.Errorf("decoding %s response: %w", encoding, err)
 	}
-	req.Header.Set("Content-Type", "application/json")
-	for k, v := range headers {
-		req.Header.Set(k, v)
+	resp.Body = &decodedBody{ReadCloser: decoded, raw: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+func (c *Client) requestEncoding(rawURL string, size int) string {
+	if !c.compression.Enabled() || size < c.compression.MinSize {
+		return ""
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.plainHosts[hostOf(rawURL)] {
+		return ""
+	}
+	return c.compression.Preferred()
+}
+
+func (c *Client) markPlain(rawURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.plainHosts[hostOf(rawURL)] = true
+}
+
+func hostOf(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return parsed.Host
+}
+
+type decodedBody struct {
+	io.ReadCloser
+	raw io.Closer
+}
+
+func (b *decodedBody) Close() error {
+	err := b.ReadCloser.Close()
+	if rawErr := b.raw.Close(); err == nil {
+		err = rawErr
 	}
-	return c.http.Do(req)
+	return err
 }
 
 func DecodeResponse(resp *http.Response, target any) error {
@@ -74,3 +171,28 @@ func DecodeResponse(resp *http.Response, target any) error {
 	}
 	return nil
 }
+
+func (c *Client) Delete(ctx context.Context, url string, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return c.do(req)
+}
+
+func RetryAfter(resp *http.Response, now time.Time) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,290 @@
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+var ErrTestFailed = errors.New("test operation failed")
+
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func Add(path string, value any) Operation {
+	return Operation{Op: OpAdd, Path: path, Value: marshalValue(value)}
+}
+
+func Remove(path string) Operation {
+	return Operation{Op: OpRemove, Path: path}
+}
+
+func Replace(path string, value any) Operation {
+	return Operation{Op: OpReplace, Path: path, Value: marshalValue(value)}
+}
+
+func EscapeKey(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+func Apply(doc []byte, patch []Operation) ([]byte, error) {
+	root, err := decode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("decoding document: %w", err)
+	}
+
+	for i, op := range patch {
+		if root, err = applyOperation(root, op); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+func applyOperation(root any, op Operation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case OpAdd, OpReplace, OpTest:
+		if op.Value == nil {
+			return nil, fmt.Errorf("missing value")
+		}
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("decoding value: %w", err)
+		}
+		switch op.Op {
+		case OpAdd:
+			return add(root, path, value)
+		case OpReplace:
+			return replace(root, path, value)
+		default:
+			current, err := get(root, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrTestFailed
+			}
+			return root, nil
+		}
+	case OpRemove:
+		return remove(root, path)
+	case OpMove, OpCopy:
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		value, err := get(root, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		if op.Op == OpCopy {
+			raw, _ := json.Marshal(value)
+			value, _ = decode(raw)
+			return add(root, path, value)
+		}
+		if len(path) > len(from) && slices.Equal(path[:len(from)], from) {
+			return nil, fmt.Errorf("cannot move a value into one of its children")
+		}
+		if root, err = remove(root, from); err != nil {
+			return nil, err
+		}
+		return add(root, path, value)
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", op.Op)
+	}
+}
+
+func add(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return modify(root, path, func(parent any, key string) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			p[key] = value
+			return p, nil
+		case []any:
+			index := len(p)
+			if key != "-" {
+				var err error
+				if index, err = arrayIndex(key, len(p)+1); err != nil {
+					return nil, err
+				}
+			}
+			return slices.Insert(p, index, value), nil
+		default:
+			return nil, fmt.Errorf("cannot add %q to a scalar", key)
+		}
+	})
+}
+
+func remove(root any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("cannot remove the whole document")
+	}
+	return modify(root, path, func(parent any, key string) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			if _, ok := p[key]; !ok {
+				return nil, fmt.Errorf("key %q does not exist", key)
+			}
+			delete(p, key)
+			return p, nil
+		case []any:
+			index, err := arrayIndex(key, len(p))
+			if err != nil {
+				return nil, err
+			}
+			return slices.Delete(p, index, index+1), nil
+		default:
+			return nil, fmt.Errorf("cannot remove %q from a scalar", key)
+		}
+	})
+}
+
+func replace(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return modify(root, path, func(parent any, key string) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			if _, ok := p[key]; !ok {
+				return nil, fmt.Errorf("key %q does not exist", key)
+			}
+			p[key] = value
+			return p, nil
+		case []any:
+			index, err := arrayIndex(key, len(p))
+			if err != nil {
+				return nil, err
+			}
+			p[index] = value
+			return p, nil
+		default:
+			return nil, fmt.Errorf("cannot replace %q in a scalar", key)
+		}
+	})
+}
+
+func modify(node any, path []string, leaf func(parent any, key string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return leaf(node, path[0])
+	}
+
+	key := path[0]
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[key]
+		if !ok {
+			return nil, fmt.Errorf("key %q does not exist", key)
+		}
+		updated, err := modify(child, path[1:], leaf)
+		if err != nil {
+			return nil, err
+		}
+		n[key] = updated
+		return n, nil
+	case []any:
+		index, err := arrayIndex(key, len(n))
+		if err != nil {
+			return nil, err
+		}
+		updated, err := modify(n[index], path[1:], leaf)
+		if err != nil {
+			return nil, err
+		}
+		n[index] = updated
+		return n, nil
+	default:
+		return nil, fmt.Errorf("cannot traverse into a scalar at %q", key)
+	}
+}
+
+func get(node any, path []string) (any, error) {
+	for _, key := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[key]
+			if !ok {
+				return nil, fmt.Errorf("key %q does not exist", key)
+			}
+			node = child
+		case []any:
+			index, err := arrayIndex(key, len(n))
+			if err != nil {
+				return nil, err
+			}
+			node = n[index]
+		default:
+			return nil, fmt.Errorf("cannot traverse into a scalar at %q", key)
+		}
+	}
+	return node, nil
+}
+
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func arrayIndex(token string, limit int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if index >= limit {
+		return 0, fmt.Errorf("array index %d out of range", index)
+	}
+	return index, nil
+}
+
+func decode(raw []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func marshalValue(v any) json.RawMessage {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return json.RawMessage("null")
+	}
+	return raw
+}
@@ -0,0 +1,89 @@
+package labels
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+type Requirement struct {
+	Key    string
+	Value  string
+	Negate bool
+}
+
+type Selector []Requirement
+
+func Parse(value string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(item, "=")
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label %q: must be key=value", item)
+		}
+		labels[key] = val
+	}
+	return labels, nil
+}
+
+func Format(labels map[string]string) string {
+	items := make([]string, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		items = append(items, key+"="+labels[key])
+	}
+	return strings.Join(items, ",")
+}
+
+func ParseSelector(value string) (Selector, error) {
+	var selector Selector
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		negate := false
+		key, val, ok := strings.Cut(item, "!=")
+		if ok {
+			negate = true
+		} else {
+			key, val, ok = strings.Cut(item, "=")
+		}
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid selector requirement %q: must be key=value or key!=value", item)
+		}
+		selector = append(selector, Requirement{Key: key, Value: val, Negate: negate})
+	}
+	if len(selector) == 0 {
+		return nil, fmt.Errorf("selector is empty")
+	}
+	return selector, nil
+}
+
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		value, ok := labels[req.Key]
+		if req.Negate == (ok && value == req.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Selector) String() string {
+	items := make([]string, len(s))
+	for i, req := range s {
+		op := "="
+		if req.Negate {
+			op = "!="
+		}
+		items[i] = req.Key + op + req.Value
+	}
+	return strings.Join(items, ",")
+}
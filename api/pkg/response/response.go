@@ -1,8 +1,13 @@
 package response
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+
+	"github.com/adityawiryaa/api/pkg/configformat"
 )
 
 type APIResponse struct {
@@ -20,13 +25,16 @@ type APIError struct {
 }
 
 func Success(c *gin.Context, status int, data any) {
-	c.JSON(status, APIResponse{
-		RequestID: uuid.New().String(),
-		Status:    status,
-		Success:   true,
-		Message:   "success",
-		Data:      data,
-	})
+	c.JSON(status, successBody(status, data))
+}
+
+func SuccessAs(c *gin.Context, status int, format string, data any) error {
+	raw, err := configformat.Marshal(format, successBody(status, data))
+	if err != nil {
+		return err
+	}
+	c.Data(status, configformat.ContentType(format), raw)
+	return nil
 }
 
 func Error(c *gin.Context, status int, code string, message string) {
@@ -41,3 +49,34 @@ func Error(c *gin.Context, status int, code string, message string) {
 		},
 	})
 }
+
+func successBody(status int, data any) APIResponse {
+	return APIResponse{
+		RequestID: uuid.New().String(),
+		Status:    status,
+		Success:   true,
+		Message:   "success",
+		Data:      data,
+	}
+}
+
+func (r *APIResponse) DecodeData(target any) error {
+	raw, err := json.Marshal(r.Data)
+	if err != nil {
+		return fmt.Errorf("encoding response data: %w", err)
+	}
+	if err := json.Unmarshal(raw, target); err != nil {
+		return fmt.Errorf("decoding response data: %w", err)
+	}
+	return nil
+}
+
+func (r *APIResponse) Err() error {
+	if r.Success {
+		return nil
+	}
+	if r.Error != nil {
+		return fmt.Errorf("%s: %s", r.Error.Code, r.Error.Message)
+	}
+	return fmt.Errorf("request failed: %s", r.Message)
+}
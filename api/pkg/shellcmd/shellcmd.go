@@ -0,0 +1,53 @@
+package shellcmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const maxOutput = 4 << 10
+
+func Run(ctx context.Context, command string, timeout time.Duration, stdin io.Reader, env ...string) (string, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = stdin
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	output := truncate(strings.TrimSpace(out.String()))
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return output, fmt.Errorf("%q timed out after %s", command, timeout)
+	}
+	if err != nil {
+		if output != "" {
+			return output, fmt.Errorf("%q: %w: %s", command, err, output)
+		}
+		return output, fmt.Errorf("%q: %w", command, err)
+	}
+	return output, nil
+}
+
+func truncate(s string) string {
+	if len(s) <= maxOutput {
+		return s
+	}
+	return s[:maxOutput] + "...(truncated)"
+}
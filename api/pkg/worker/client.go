@@ -3,9 +3,17 @@ package worker
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
+	"github.com/adityawiryaa/api/domain/dto"
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/pkg/compression"
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
 	"github.com/adityawiryaa/api/pkg/httpclient"
 	"github.com/adityawiryaa/api/pkg/response"
 )
@@ -22,6 +30,10 @@ func NewClient(baseURL string, timeout time.Duration) *Client {
 	}
 }
 
+func (c *Client) SetCompression(cfg compression.Config) {
+	c.httpClient.SetCompression(cfg)
+}
+
 func (c *Client) PushConfig(ctx context.Context, cfg *entity.Config) error {
 	resp, err := c.httpClient.Post(ctx, c.baseURL+"/config", cfg, nil)
 	if err != nil {
@@ -39,3 +51,192 @@ func (c *Client) PushConfig(ctx context.Context, cfg *entity.Config) error {
 
 	return nil
 }
+
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+func (c *Client) ConfigVersion(ctx context.Context) (int64, error) {
+	resp, err := c.httpClient.Get(ctx, c.baseURL+"/config", nil)
+	if err != nil {
+		return 0, fmt.Errorf("getting worker config: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return 0, nil
+	}
+
+	var cfg dto.ConfigDTO
+	if err := decodeAPIData(resp, &cfg); err != nil {
+		return 0, err
+	}
+	return cfg.Version, nil
+}
+
+func (c *Client) GetConfig(ctx context.Context) (*dto.ConfigDTO, error) {
+	resp, err := c.httpClient.Get(ctx, c.baseURL+"/config", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting worker config: %w", err)
+	}
+
+	var cfg dto.ConfigDTO
+	if err := decodeAPIData(resp, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Client) TriggerHit(ctx context.Context, req *request.HitRequest) (*usecases.EnqueueHitsResponse, error) {
+	var resp *http.Response
+	var err error
+	if req == nil {
+		resp, err = c.httpClient.Get(ctx, c.baseURL+"/hit", nil)
+	} else {
+		resp, err = c.httpClient.Post(ctx, c.baseURL+"/hit", req, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("triggering hit: %w", err)
+	}
+
+	var result struct {
+		usecases.EnqueueHitResponse
+		Tasks []usecases.EnqueueHitResponse `json:"tasks"`
+	}
+	if err := decodeAPIData(resp, &result); err != nil {
+		return nil, err
+	}
+	if result.Tasks == nil && result.TaskID != "" {
+		return &usecases.EnqueueHitsResponse{Tasks: []usecases.EnqueueHitResponse{result.EnqueueHitResponse}, ImplicitJob: true}, nil
+	}
+	return &usecases.EnqueueHitsResponse{Tasks: result.Tasks}, nil
+}
+
+func (c *Client) TriggerJob(ctx context.Context, job string, req *request.HitRequest) (*usecases.EnqueueHitResponse, error) {
+	if req == nil {
+		req = &request.HitRequest{}
+	}
+	resp, err := c.httpClient.Post(ctx, c.baseURL+"/hit/"+url.PathEscape(job), req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("triggering job %s: %w", job, err)
+	}
+
+	var result usecases.EnqueueHitResponse
+	if err := decodeAPIData(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) ListJobs(ctx context.Context) ([]usecases.HitJobResponse, error) {
+	resp, err := c.httpClient.Get(ctx, c.baseURL+"/jobs", nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing jobs: %w", err)
+	}
+
+	var jobs []usecases.HitJobResponse
+	if err := decodeAPIData(resp, &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (c *Client) GetJobResult(ctx context.Context, job string) (*usecases.HitResultResponse, error) {
+	resp, err := c.httpClient.Get(ctx, c.baseURL+"/jobs/"+url.PathEscape(job), nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting job result: %w", err)
+	}
+
+	var result usecases.HitResultResponse
+	if err := decodeAPIData(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) GetHitResult(ctx context.Context, taskID string) (*usecases.HitResultResponse, error) {
+	resp, err := c.httpClient.Get(ctx, c.baseURL+"/hit/"+url.PathEscape(taskID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting hit result: %w", err)
+	}
+
+	var result usecases.HitResultResponse
+	if err := decodeAPIData(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) SubmitBatch(ctx context.Context, req *request.HitBatchRequest) (*usecases.EnqueueBatchResponse, error) {
+	resp, err := c.httpClient.Post(ctx, c.baseURL+"/hits/batch", req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("submitting batch: %w", err)
+	}
+
+	var result usecases.EnqueueBatchResponse
+	if err := decodeAPIData(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) GetBatch(ctx context.Context, batchID string, page, perPage int) (*usecases.HitBatchResponse, error) {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if perPage > 0 {
+		query.Set("per_page", strconv.Itoa(perPage))
+	}
+	target := c.baseURL + "/hits/batch/" + url.PathEscape(batchID)
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	resp, err := c.httpClient.Get(ctx, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting batch: %w", err)
+	}
+
+	var result usecases.HitBatchResponse
+	if err := decodeAPIData(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *Client) ListSchedules(ctx context.Context) ([]hitqueue.ScheduleInfo, error) {
+	resp, err := c.httpClient.Get(ctx, c.baseURL+"/schedules", nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing schedules: %w", err)
+	}
+
+	var schedules []hitqueue.ScheduleInfo
+	if err := decodeAPIData(resp, &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+func (c *Client) QueueStats(ctx context.Context) (*hitqueue.QueueStats, error) {
+	resp, err := c.httpClient.Get(ctx, c.baseURL+"/queue", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting queue stats: %w", err)
+	}
+
+	var stats hitqueue.QueueStats
+	if err := decodeAPIData(resp, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func decodeAPIData(resp *http.Response, target any) error {
+	var apiResp response.APIResponse
+	if err := httpclient.DecodeResponse(resp, &apiResp); err != nil {
+		return err
+	}
+	if err := apiResp.Err(); err != nil {
+		return err
+	}
+	return apiResp.DecodeData(target)
+}
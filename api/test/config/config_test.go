@@ -0,0 +1,297 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/internal/config"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadAgentConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		file         string
+		env          map[string]string
+		args         []string
+		wantErr      []string
+		wantPoll     time.Duration
+		wantJitter   float64
+		wantWorkers  []string
+		wantHostname string
+	}{
+		{
+			name:         "defaults",
+			wantPoll:     30 * time.Second,
+			wantJitter:   0.1,
+			wantWorkers:  []string{"http://localhost:6002"},
+			wantHostname: "agent-01",
+		},
+		{
+			name: "file values",
+			file: `
+agent_hostname: edge-1
+poll_interval_seconds: 45
+worker_urls:
+  - http://w1:6002
+  - http://w2:6002
+`,
+			wantPoll:     45 * time.Second,
+			wantJitter:   0.1,
+			wantWorkers:  []string{"http://w1:6002", "http://w2:6002"},
+			wantHostname: "edge-1",
+		},
+		{
+			name:         "env overrides file and flag overrides env",
+			file:         "poll_interval_seconds: 45\npoll_jitter: 0.2\nagent_hostname: from-file\n",
+			env:          map[string]string{"POLL_INTERVAL_SECONDS": "60", "POLL_JITTER": "0.3"},
+			args:         []string{"-poll-interval-seconds", "90"},
+			wantPoll:     90 * time.Second,
+			wantJitter:   0.3,
+			wantWorkers:  []string{"http://localhost:6002"},
+			wantHostname: "from-file",
+		},
+		{
+			name:    "typo in interval",
+			env:     map[string]string{"POLL_INTERVAL_SECONDS": "3o"},
+			wantErr: []string{`POLL_INTERVAL_SECONDS: invalid value "3o" (from env): must be an integer`},
+		},
+		{
+			name:    "zero interval",
+			args:    []string{"-reconcile-interval-seconds=0"},
+			wantErr: []string{"RECONCILE_INTERVAL_SECONDS", "(from flag)", "must be at least 1"},
+		},
+		{
+			name: "all errors reported",
+			file: "agent_port: 70000\npoll_jitter: 2\n",
+			env:  map[string]string{"CONTROLLER_URL": "localhost:6001"},
+			wantErr: []string{
+				"AGENT_PORT", "(from config file)",
+				"POLL_JITTER", "must be between 0 and 1",
+				"CONTROLLER_URL", "http(s) URL",
+			},
+		},
+		{
+			name:         "bundle-only agent",
+			env:          map[string]string{"CONTROLLER_URL": "none", "BUNDLE_DIR": "/var/lib/agent/bundles", "BUNDLE_PUBLIC_KEY_FILE": "/etc/agent/bundle.pub"},
+			wantPoll:     30 * time.Second,
+			wantJitter:   0.1,
+			wantWorkers:  []string{"http://localhost:6002"},
+			wantHostname: "agent-01",
+		},
+		{
+			name:    "no controller and no bundles",
+			env:     map[string]string{"CONTROLLER_URL": "none"},
+			wantErr: []string{"CONTROLLER_URL", "is required unless BUNDLE_DIR is set"},
+		},
+		{
+			name:    "bundle dir without public key",
+			env:     map[string]string{"BUNDLE_DIR": "/var/lib/agent/bundles"},
+			wantErr: []string{"BUNDLE_PUBLIC_KEY_FILE", "is required when BUNDLE_DIR is set"},
+		},
+		{
+			name:    "relay port shared with agent port",
+			env:     map[string]string{"RELAY_PORT": "8081"},
+			wantErr: []string{"RELAY_PORT", "must differ from AGENT_PORT"},
+		},
+		{
+			name:    "invalid labels",
+			env:     map[string]string{"AGENT_LABELS": "site=north,edge"},
+			wantErr: []string{"AGENT_LABELS", `invalid label "edge"`},
+		},
+		{
+			name:    "unknown file key",
+			file:    "poll_intervall_seconds: 10\n",
+			wantErr: []string{`unknown setting "poll_intervall_seconds"`},
+		},
+		{
+			name:    "unexpected arguments",
+			args:    []string{"extra"},
+			wantErr: []string{"unexpected arguments"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			t.Setenv("CONFIG_FILE", "")
+			if tt.file != "" {
+				t.Setenv("CONFIG_FILE", writeConfigFile(t, "agent.yaml", tt.file))
+			}
+
+			cfg, err := config.LoadAgentConfig(tt.args)
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("error %q does not mention %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.PollInterval != tt.wantPoll || cfg.PollJitter != tt.wantJitter {
+				t.Errorf("poll = %s/%g, want %s/%g", cfg.PollInterval, cfg.PollJitter, tt.wantPoll, tt.wantJitter)
+			}
+			if !slices.Equal(cfg.WorkerURLs, tt.wantWorkers) {
+				t.Errorf("workers = %v, want %v", cfg.WorkerURLs, tt.wantWorkers)
+			}
+			if cfg.Hostname != tt.wantHostname {
+				t.Errorf("hostname = %q, want %q", cfg.Hostname, tt.wantHostname)
+			}
+		})
+	}
+}
+
+func TestAgentConfigRestartRequired(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	path := writeConfigFile(t, "agent.yaml", "agent_port: 8081\npoll_interval_seconds: 30\n")
+	current, err := config.LoadAgentConfig([]string{"-config", path})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	_ = os.WriteFile(path, []byte("agent_port: 9091\npoll_interval_seconds: 10\n"), 0o600)
+	next, err := config.LoadAgentConfig([]string{"-config", path})
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+
+	if next.PollInterval != 10*time.Second {
+		t.Errorf("reloaded poll interval = %s, want 10s", next.PollInterval)
+	}
+	if got := current.RestartRequired(next); !slices.Equal(got, []string{"AGENT_PORT"}) {
+		t.Errorf("restart required = %v, want [AGENT_PORT]", got)
+	}
+}
+
+func TestLoadControllerConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		args     []string
+		wantErr  string
+		wantArgs []string
+		wantPort string
+	}{
+		{
+			name:     "subcommand after flags",
+			args:     []string{"-controller-port", "7001", "migrate", "up"},
+			wantArgs: []string{"migrate", "up"},
+			wantPort: "7001",
+		},
+		{
+			name:    "invalid conflict policy",
+			env:     map[string]string{"SYNC_CONFLICT_POLICY": "merge"},
+			wantErr: "must be one of reject, overwrite",
+		},
+		{
+			name:    "invalid bool",
+			env:     map[string]string{"SYNC_GIT_PULL": "yes please"},
+			wantErr: "SYNC_GIT_PULL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONFIG_FILE", "")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, args, err := config.LoadControllerConfig(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.Port != tt.wantPort || !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("port = %s, args = %v, want %s, %v", cfg.Port, args, tt.wantPort, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestLoadWorkerConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          string
+		wantErr       string
+		wantAdvertise string
+		wantRedis     string
+	}{
+		{
+			name:          "advertise url follows port",
+			file:          "worker_port: 7002\nredis_host: cache\n",
+			wantAdvertise: "http://localhost:7002",
+			wantRedis:     "cache:6379",
+		},
+		{
+			name:    "negative redis db",
+			file:    "redis_db: -1\n",
+			wantErr: "REDIS_DB",
+		},
+		{
+			name:    "zero heartbeat",
+			file:    "agent_heartbeat_seconds: 0\n",
+			wantErr: "AGENT_HEARTBEAT_SECONDS",
+		},
+		{
+			name:    "unsupported compression encoding",
+			file:    "compression_encodings: [gzip, br]\n",
+			wantErr: `COMPRESSION_ENCODINGS: invalid value "gzip,br" (from config file)`,
+		},
+		{
+			name:    "non-positive request body limit",
+			file:    "compression_max_request_bytes: 0\n",
+			wantErr: "COMPRESSION_MAX_REQUEST_BYTES",
+		},
+		{
+			name:    "unknown schedule timezone",
+			file:    "schedule_timezone: Mars/Olympus\n",
+			wantErr: "SCHEDULE_TIMEZONE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CONFIG_FILE", writeConfigFile(t, "worker.yml", tt.file))
+
+			cfg, err := config.LoadWorkerConfig(nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.AdvertiseURL != tt.wantAdvertise || cfg.Redis.Addr() != tt.wantRedis {
+				t.Errorf("advertise = %s, redis = %s", cfg.AdvertiseURL, cfg.Redis.Addr())
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package config_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/adityawiryaa/api/internal/config"
+)
+
+func TestReloadOnSignal(t *testing.T) {
+	tests := []struct {
+		name        string
+		loadErr     error
+		restart     []string
+		wantApplied bool
+		wantLogs    []string
+	}{
+		{
+			name:        "applies the reloaded config",
+			wantApplied: true,
+		},
+		{
+			name:        "logs keys that need a restart",
+			restart:     []string{"CONTROLLER_PORT"},
+			wantApplied: true,
+			wantLogs:    []string{"config reload: CONTROLLER_PORT changed, restart required to apply it"},
+		},
+		{
+			name:     "keeps the current config when loading fails",
+			loadErr:  errors.New("SYNC_INTERVAL_SECONDS: must be an integer"),
+			wantLogs: []string{"config reload: failed, keeping current settings:\nSYNC_INTERVAL_SECONDS: must be an integer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			log.SetOutput(&logs)
+			defer log.SetOutput(os.Stderr)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			signals := make(chan os.Signal)
+			done := make(chan struct{})
+			var applied []string
+			go func() {
+				defer close(done)
+				config.ReloadOnSignal(ctx, signals, "current",
+					func() (string, error) { return "next", tt.loadErr },
+					func(current, next string) []string {
+						if current != "current" || next != "next" {
+							t.Errorf("restartRequired(%q, %q), want current and next", current, next)
+						}
+						return tt.restart
+					},
+					func(next string) { applied = append(applied, next) })
+			}()
+
+			signals <- syscall.SIGHUP
+			cancel()
+			<-done
+
+			if got := len(applied) == 1 && applied[0] == "next"; got != tt.wantApplied {
+				t.Errorf("applied = %v, want applied %v", applied, tt.wantApplied)
+			}
+			for _, want := range tt.wantLogs {
+				if !strings.Contains(logs.String(), want) {
+					t.Errorf("logs = %q, want %q", logs.String(), want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/adityawiryaa/api/internal/config"
+	delivery "github.com/adityawiryaa/api/internal/delivery/http/controller"
+	"github.com/adityawiryaa/api/internal/repository"
+	"github.com/adityawiryaa/api/internal/repository/commands"
+	"github.com/adityawiryaa/api/internal/repository/queries"
+	controlleruc "github.com/adityawiryaa/api/internal/usecases/controller"
+	"github.com/adityawiryaa/api/pkg/compression"
+	"github.com/adityawiryaa/api/pkg/response"
+)
+
+func newBundleController(t *testing.T) http.Handler {
+	t.Helper()
+	db, err := config.NewDB(filepath.Join(t.TempDir(), "controller.db"))
+	if err != nil {
+		t.Fatalf("opening db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := repository.Migrate(db); err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+
+	agentQuery := queries.NewAgentQuery(db)
+	configQuery := queries.NewConfigQuery(db)
+	bundleQuery := queries.NewBundleQuery(db)
+	commandQueueQuery := queries.NewCommandQueueQuery(db)
+	commandUC := controlleruc.NewCommandUsecase(commands.NewAgentCommand(db), commands.NewConfigCommand(db), configQuery,
+		commands.NewBundleCommand(db), bundleQuery, agentQuery, commands.NewCommandQueueCommand(db), commandQueueQuery)
+	queryUC := controlleruc.NewQueryUsecase(configQuery, agentQuery, bundleQuery, commandQueueQuery, nil)
+	return delivery.SetupRouter(delivery.NewHandler(commandUC, queryUC), "api-key", compression.DefaultConfig())
+}
+
+func serve(t *testing.T, router http.Handler, method, path string, headers map[string]string, body []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	req.Header.Set("X-API-Key", "api-key")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestBundleYAMLRoundTrip(t *testing.T) {
+	source := newBundleController(t)
+	for _, body := range []string{
+		`{"data":{"url":"https://a","site":"north"},"poll_interval_seconds":15}`,
+		`{"data":{"url":"https://b","site":"north"},"poll_interval_seconds":30}`,
+	} {
+		if rec := serve(t, source, http.MethodPost, "/config", nil, []byte(body)); rec.Code != http.StatusCreated {
+			t.Fatalf("publish: %d %s", rec.Code, rec.Body.String())
+		}
+	}
+	register := `{"hostname":"edge-1","ip_address":"10.0.0.5","port":8081}`
+	if rec := serve(t, source, http.MethodPost, "/register", nil, []byte(register)); rec.Code != http.StatusOK && rec.Code != http.StatusCreated {
+		t.Fatalf("register: %d %s", rec.Code, rec.Body.String())
+	}
+
+	exported := serve(t, source, http.MethodGet, "/export", map[string]string{"Accept": "application/yaml"}, nil)
+	if exported.Code != http.StatusOK {
+		t.Fatalf("export: %d %s", exported.Code, exported.Body.String())
+	}
+	if got := exported.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/yaml") {
+		t.Fatalf("export content type = %q", got)
+	}
+	if got := exported.Header().Get("Content-Disposition"); !strings.HasSuffix(got, ".yaml") {
+		t.Errorf("export disposition = %q", got)
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(exported.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("export is not yaml: %v\n%s", err, exported.Body.String())
+	}
+
+	target := newBundleController(t)
+	imported := serve(t, target, http.MethodPost, "/import?mode=replay", map[string]string{"Content-Type": "application/yaml"}, exported.Body.Bytes())
+	if imported.Code != http.StatusCreated {
+		t.Fatalf("import: %d %s", imported.Code, imported.Body.String())
+	}
+	var result struct {
+		ConfigsImported int   `json:"configs_imported"`
+		AgentsImported  int   `json:"agents_imported"`
+		LatestVersion   int64 `json:"latest_version"`
+	}
+	decodeData(t, imported.Body.Bytes(), &result)
+	if result.ConfigsImported != 2 || result.AgentsImported != 1 || result.LatestVersion != 2 {
+		t.Fatalf("import result = %+v", result)
+	}
+
+	for version, want := range map[string]string{"1": "https://a", "2": "https://b"} {
+		rec := serve(t, target, http.MethodGet, "/config/"+version, nil, nil)
+		var cfg struct {
+			PollIntervalSeconds int               `json:"poll_interval_seconds"`
+			Data                map[string]string `json:"data"`
+		}
+		decodeData(t, rec.Body.Bytes(), &cfg)
+		if cfg.Data["url"] != want || cfg.Data["site"] != "north" || cfg.PollIntervalSeconds == 0 {
+			t.Errorf("version %s = %+v, want url %s", version, cfg, want)
+		}
+	}
+
+	reexported := serve(t, target, http.MethodGet, "/export", nil, nil)
+	if got := reexported.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/json") {
+		t.Errorf("default export content type = %q", got)
+	}
+}
+
+func TestBundleSchemaVersion(t *testing.T) {
+	migrations, err := repository.LoadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := migrations[len(migrations)-1].Version
+	router := newBundleController(t)
+
+	exported := serve(t, router, http.MethodGet, "/export", nil, nil)
+	var bundle map[string]any
+	if err := json.Unmarshal(exported.Body.Bytes(), &bundle); err != nil {
+		t.Fatalf("export: %v\n%s", err, exported.Body.String())
+	}
+	if got := bundle["schema_version"]; got != float64(latest) {
+		t.Fatalf("schema_version = %v, want %d", got, latest)
+	}
+
+	tests := []struct {
+		name       string
+		schema     int64
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "same schema", schema: latest, wantStatus: http.StatusCreated},
+		{name: "older schema", schema: latest - 1, wantStatus: http.StatusCreated},
+		{name: "newer schema", schema: latest + 1, wantStatus: http.StatusBadRequest, wantCode: "UNSUPPORTED_SCHEMA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := fmt.Sprintf(`{"format_version":1,"schema_version":%d,"configs":[{"version":1,"data":{"url":"https://a"}}]}`, tt.schema)
+			rec := serve(t, newBundleController(t), http.MethodPost, "/import", map[string]string{"Content-Type": "application/json"}, []byte(body))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("import = %d %s, want %d", rec.Code, rec.Body.String(), tt.wantStatus)
+			}
+			if tt.wantCode != "" && !strings.Contains(rec.Body.String(), tt.wantCode) {
+				t.Errorf("body = %s, want %s", rec.Body.String(), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestBundleFormatErrors(t *testing.T) {
+	router := newBundleController(t)
+
+	if rec := serve(t, router, http.MethodGet, "/export", map[string]string{"Accept": "application/toml"}, nil); rec.Code != http.StatusNotAcceptable {
+		t.Errorf("toml export = %d, want 406", rec.Code)
+	}
+	if rec := serve(t, router, http.MethodPost, "/import", map[string]string{"Content-Type": "application/toml"}, []byte("format_version = 1")); rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("toml import = %d, want 415", rec.Code)
+	}
+	if rec := serve(t, router, http.MethodPost, "/import", map[string]string{"Content-Type": "application/yaml"}, []byte("configs: [")); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid yaml import = %d, want 400", rec.Code)
+	}
+}
+
+func decodeData(t *testing.T, raw []byte, target any) {
+	t.Helper()
+	var resp response.APIResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		t.Fatalf("decoding response: %v\n%s", err, raw)
+	}
+	if err := resp.Err(); err != nil {
+		t.Fatalf("response error: %v", err)
+	}
+	if err := resp.DecodeData(target); err != nil {
+		t.Fatalf("decoding data: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	delivery "github.com/adityawiryaa/api/internal/delivery/http/controller"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	controlleruc "github.com/adityawiryaa/api/internal/usecases/controller"
+	"github.com/adityawiryaa/api/pkg/compression"
+)
+
+func newConfigRouter(t *testing.T) http.Handler {
+	t.Helper()
+	agents := memory.NewAgentRegistry()
+	store := memory.NewConfigStore()
+	store.Set(&entity.Config{ID: "cfg-1", Version: 1, PollIntervalSeconds: 20, Data: map[string]string{"site": "north"}})
+	configs := memory.NewConfigMirror(store, 4)
+	commands := memory.NewCommandQueue()
+
+	commandUC := controlleruc.NewCommandUsecase(agents, configs, configs, nil, nil, agents, commands, commands)
+	queryUC := controlleruc.NewQueryUsecase(configs, agents, nil, commands, nil)
+	return delivery.SetupRouter(delivery.NewHandler(commandUC, queryUC), "api-key", compression.DefaultConfig())
+}
+
+func TestConfigFormatNegotiation(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		accept      string
+		wantStatus  int
+		wantType    string
+		wantVersion bool
+	}{
+		{name: "json by default", path: "/config", wantStatus: http.StatusOK, wantType: "application/json", wantVersion: true},
+		{name: "wildcard is json", path: "/config", accept: "*/*", wantStatus: http.StatusOK, wantType: "application/json", wantVersion: true},
+		{name: "yaml", path: "/config", accept: "application/yaml", wantStatus: http.StatusOK, wantType: "application/yaml", wantVersion: true},
+		{name: "x-yaml by version", path: "/config/1", accept: "application/x-yaml", wantStatus: http.StatusOK, wantType: "application/yaml", wantVersion: true},
+		{name: "toml by version", path: "/config/1", accept: "application/toml", wantStatus: http.StatusOK, wantType: "application/toml", wantVersion: true},
+		{name: "unsupported format", path: "/config", accept: "text/html", wantStatus: http.StatusNotAcceptable, wantType: "application/json"},
+		{name: "unsupported format by version", path: "/config/1", accept: "application/xml", wantStatus: http.StatusNotAcceptable, wantType: "application/json"},
+		{name: "errors stay json", path: "/config/abc", accept: "application/yaml", wantStatus: http.StatusBadRequest, wantType: "application/json"},
+	}
+
+	router := newConfigRouter(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-API-Key", "api-key")
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if got := rec.Header().Get("Content-Type"); !strings.HasPrefix(got, tt.wantType) {
+				t.Fatalf("content type = %q, want %q", got, tt.wantType)
+			}
+
+			var body struct {
+				Success bool `json:"success" yaml:"success" toml:"success"`
+				Data    struct {
+					Version int64 `json:"version" yaml:"version" toml:"version"`
+				} `json:"data" yaml:"data" toml:"data"`
+			}
+			var err error
+			switch {
+			case strings.HasPrefix(tt.wantType, "application/yaml"):
+				err = yaml.Unmarshal(rec.Body.Bytes(), &body)
+			case strings.HasPrefix(tt.wantType, "application/toml"):
+				err = toml.Unmarshal(rec.Body.Bytes(), &body)
+			default:
+				err = json.Unmarshal(rec.Body.Bytes(), &body)
+			}
+			if err != nil {
+				t.Fatalf("decoding %s body: %v\n%s", tt.wantType, err, rec.Body.String())
+			}
+			if body.Success != tt.wantVersion || (tt.wantVersion && body.Data.Version != 1) {
+				t.Errorf("body = %+v", body)
+			}
+		})
+	}
+}
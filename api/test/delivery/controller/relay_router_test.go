@@ -0,0 +1,99 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	delivery "github.com/adityawiryaa/api/internal/delivery/http/controller"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	controlleruc "github.com/adityawiryaa/api/internal/usecases/controller"
+	"github.com/adityawiryaa/api/pkg/compression"
+	controllerclient "github.com/adityawiryaa/api/pkg/controller"
+)
+
+func newRelay(t *testing.T, store *memory.ConfigStore) (*controllerclient.Client, *memory.AgentRegistry) {
+	t.Helper()
+	agents := memory.NewAgentRegistry()
+	configs := memory.NewConfigMirror(store, 4)
+	commands := memory.NewCommandQueue()
+
+	commandUC := controlleruc.NewCommandUsecase(agents, configs, configs, nil, nil, agents, commands, commands)
+	queryUC := controlleruc.NewQueryUsecase(configs, agents, nil, commands, nil)
+	router := delivery.SetupRelayRouter(delivery.NewHandler(commandUC, queryUC), "relay-key", compression.DefaultConfig())
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return controllerclient.NewClient(srv.URL, "relay-key", time.Second), agents
+}
+
+func TestRelayRouter(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewConfigStore()
+	client, agents := newRelay(t, store)
+
+	if _, err := client.FetchConfig(ctx, "", 0); err == nil {
+		t.Fatal("expected error before the relay has a config")
+	}
+
+	store.Set(&entity.Config{ID: "cfg-1", Version: 1, PollIntervalSeconds: 20, Data: map[string]string{"mode": "a", "site": "north"}})
+	reg, err := client.Register(ctx, &entity.RegistrationRequest{Hostname: "edge-1", IPAddress: "10.0.0.5", Port: 8081})
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if reg.AgentID == "" {
+		t.Fatal("relay did not assign an agent ID")
+	}
+
+	fetch, err := client.FetchConfig(ctx, reg.AgentID, 0)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if fetch.Config == nil || fetch.Config.Version != 1 || fetch.Config.Data["site"] != "north" {
+		t.Fatalf("fetch = %+v, want version 1", fetch)
+	}
+
+	fetch, err = client.FetchConfig(ctx, reg.AgentID, 1)
+	if err != nil || fetch.Changed {
+		t.Fatalf("unchanged fetch = %+v, %v", fetch, err)
+	}
+
+	store.Set(&entity.Config{ID: "cfg-2", Version: 2, PollIntervalSeconds: 20, Data: map[string]string{"mode": "b", "site": "north"}})
+	fetch, err = client.FetchConfig(ctx, reg.AgentID, 1)
+	if err != nil {
+		t.Fatalf("fetch patch: %v", err)
+	}
+	if fetch.Patch == nil || fetch.Patch.BaseVersion != 1 || fetch.Patch.Version != 2 {
+		t.Fatalf("fetch = %+v, want patch 1 -> 2", fetch)
+	}
+
+	if err := client.Report(ctx, reg.AgentID, &request.AgentReportRequest{ConfigVersion: 2}); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	agent, err := agents.FindByID(ctx, reg.AgentID)
+	if err != nil || agent.Report == nil || agent.Report.ConfigVersion != 2 {
+		t.Fatalf("relay agent = %+v, %v", agent, err)
+	}
+
+	err = client.Report(ctx, "unknown", &request.AgentReportRequest{})
+	if !errors.Is(err, usecases.ErrAgentNotFound) {
+		t.Errorf("report for unknown agent err = %v, want %v", err, usecases.ErrAgentNotFound)
+	}
+
+	commands, err := client.ClaimCommands(ctx, reg.AgentID)
+	if err != nil || len(commands) != 0 {
+		t.Errorf("claim = %v, %v, want no commands", commands, err)
+	}
+}
+
+func TestRelayRouterRejectsConfigWrites(t *testing.T) {
+	client, _ := newRelay(t, memory.NewConfigStore())
+	if _, err := client.PublishConfig(context.Background(), &request.UpdateConfigRequest{Data: map[string]string{"mode": "a"}}); err == nil {
+		t.Error("expected config writes to be rejected by the relay")
+	}
+}
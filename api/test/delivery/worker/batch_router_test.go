@@ -0,0 +1,253 @@
+package worker_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	delivery "github.com/adityawiryaa/api/internal/delivery/http/worker"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	workeruc "github.com/adityawiryaa/api/internal/usecases/worker"
+	"github.com/adityawiryaa/api/pkg/compression"
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+	"github.com/adityawiryaa/api/pkg/response"
+	workerclient "github.com/adityawiryaa/api/pkg/worker"
+)
+
+type fakeQueue struct {
+	payloads []*hitqueue.ExecuteHitPayload
+}
+
+func (f *fakeQueue) EnqueueExecuteHit(payload *hitqueue.ExecuteHitPayload) error {
+	f.payloads = append(f.payloads, payload)
+	return nil
+}
+
+func (f *fakeQueue) EnqueueExecuteHits(payloads []*hitqueue.ExecuteHitPayload) (int, error) {
+	f.payloads = append(f.payloads, payloads...)
+	return len(payloads), nil
+}
+
+type fakeBatchStore struct {
+	batches map[string]*hitqueue.HitBatch
+	results map[string]*hitqueue.HitResult
+}
+
+func (f *fakeBatchStore) SaveBatch(_ context.Context, batch *hitqueue.HitBatch) error {
+	f.batches[batch.ID] = batch
+	return nil
+}
+
+func (f *fakeBatchStore) GetBatch(_ context.Context, id string) (*hitqueue.HitBatch, error) {
+	return f.batches[id], nil
+}
+
+func (f *fakeBatchStore) GetResult(_ context.Context, taskID string) (*hitqueue.HitResult, error) {
+	return f.results[taskID], nil
+}
+
+func (f *fakeBatchStore) GetLatestResult(_ context.Context, _ string) (*hitqueue.HitResult, error) {
+	return nil, nil
+}
+
+func (f *fakeBatchStore) GetResults(_ context.Context, taskIDs []string) ([]*hitqueue.HitResult, error) {
+	results := make([]*hitqueue.HitResult, len(taskIDs))
+	for i, id := range taskIDs {
+		results[i] = f.results[id]
+	}
+	return results, nil
+}
+
+func newWorkerServer(t *testing.T) (*httptest.Server, *fakeQueue, *fakeBatchStore) {
+	t.Helper()
+	queue := &fakeQueue{}
+	batches := &fakeBatchStore{batches: make(map[string]*hitqueue.HitBatch), results: make(map[string]*hitqueue.HitResult)}
+	store := memory.NewConfigStore()
+
+	commandUC := workeruc.NewCommandUsecaseWithEnqueuer(nil, store, queue, nil, batches)
+	queryUC := workeruc.NewQueryUsecase(store, batches, nil, nil, batches)
+	router := delivery.SetupRouter(delivery.NewHandler(commandUC, queryUC), "key", compression.DefaultConfig())
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv, queue, batches
+}
+
+func multipartBody(t *testing.T, filename, content string) (string, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write([]byte(content))
+	writer.Close()
+	return writer.FormDataContentType(), &buf
+}
+
+func TestSubmitBatchFormats(t *testing.T) {
+	csvContentType, csvUpload := multipartBody(t, "urls.csv", "url,method\nhttps://a.test,POST\nhttps://b.test,\n")
+	ndjsonContentType, ndjsonUpload := multipartBody(t, "urls.jsonl", "{\"url\": \"https://a.test\"}\n")
+	_, missingFile := multipartBody(t, "urls.csv", "")
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        *bytes.Buffer
+		wantStatus  int
+		wantCode    string
+		wantTotal   int
+	}{
+		{
+			name:        "inline json",
+			contentType: "application/json",
+			body:        bytes.NewBufferString(`{"hits": [{"url": "https://a.test"}, {"url": "https://b.test", "method": "DELETE"}]}`),
+			wantStatus:  http.StatusAccepted,
+			wantTotal:   2,
+		},
+		{
+			name:        "raw csv",
+			contentType: "text/csv",
+			body:        bytes.NewBufferString("url\nhttps://a.test\nhttps://b.test\nhttps://c.test\n"),
+			wantStatus:  http.StatusAccepted,
+			wantTotal:   3,
+		},
+		{
+			name:        "raw ndjson",
+			contentType: "application/x-ndjson",
+			body:        bytes.NewBufferString("{\"url\": \"https://a.test\"}\n"),
+			wantStatus:  http.StatusAccepted,
+			wantTotal:   1,
+		},
+		{
+			name:        "csv upload",
+			contentType: csvContentType,
+			body:        csvUpload,
+			wantStatus:  http.StatusAccepted,
+			wantTotal:   2,
+		},
+		{
+			name:        "ndjson upload",
+			contentType: ndjsonContentType,
+			body:        ndjsonUpload,
+			wantStatus:  http.StatusAccepted,
+			wantTotal:   1,
+		},
+		{
+			name:        "upload without file field",
+			contentType: "multipart/form-data; boundary=nothing",
+			body:        missingFile,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "INVALID_REQUEST",
+		},
+		{
+			name:        "unsupported type",
+			contentType: "text/plain",
+			body:        bytes.NewBufferString("https://a.test"),
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantCode:    "UNSUPPORTED_FORMAT",
+		},
+		{
+			name:        "malformed csv",
+			contentType: "text/csv",
+			body:        bytes.NewBufferString("url,unknown\nhttps://a.test,x\n"),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "INVALID_REQUEST",
+		},
+		{
+			name:        "invalid hit",
+			contentType: "application/json",
+			body:        bytes.NewBufferString(`{"hits": [{"url": "not a url"}]}`),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "INVALID_BATCH",
+		},
+		{
+			name:        "empty batch",
+			contentType: "application/json",
+			body:        bytes.NewBufferString(`{"hits": []}`),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    "INVALID_BATCH",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, queue, _ := newWorkerServer(t)
+
+			resp, err := http.Post(srv.URL+"/hits/batch", tt.contentType, tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			var apiResp response.APIResponse
+			if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%+v)", resp.StatusCode, tt.wantStatus, apiResp.Error)
+			}
+			if tt.wantCode != "" {
+				if apiResp.Error == nil || apiResp.Error.Code != tt.wantCode {
+					t.Errorf("error = %+v, want %s", apiResp.Error, tt.wantCode)
+				}
+				return
+			}
+
+			var result usecases.EnqueueBatchResponse
+			if err := apiResp.DecodeData(&result); err != nil {
+				t.Fatal(err)
+			}
+			if result.BatchID == "" || result.Total != tt.wantTotal || len(queue.payloads) != tt.wantTotal {
+				t.Errorf("result = %+v, enqueued %d", result, len(queue.payloads))
+			}
+		})
+	}
+}
+
+func TestBatchStatusRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	srv, queue, batches := newWorkerServer(t)
+	client := workerclient.NewClient(srv.URL, time.Second)
+
+	submitted, err := client.SubmitBatch(ctx, &request.HitBatchRequest{Hits: []request.BatchHitSpec{
+		{URL: "https://a.test"}, {URL: "https://b.test"}, {URL: "https://c.test"},
+	}})
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	first := queue.payloads[0].TaskID
+	batches.results[first] = &hitqueue.HitResult{TaskID: first, Status: "completed", StatusCode: 204}
+
+	status, err := client.GetBatch(ctx, submitted.BatchID, 1, 2)
+	if err != nil {
+		t.Fatalf("get batch: %v", err)
+	}
+	if status.Total != 3 || status.Progress != 33.3 || status.Pages != 2 || len(status.Tasks) != 2 {
+		t.Fatalf("status = %+v", status)
+	}
+	if status.Tasks[0].StatusCode != 204 || status.Tasks[1].Status != "pending" || status.Tasks[1].URL != "https://b.test" {
+		t.Errorf("tasks = %+v", status.Tasks)
+	}
+
+	if _, err := client.GetBatch(ctx, "missing", 0, 0); err == nil {
+		t.Error("expected error for an unknown batch")
+	}
+
+	resp, err := http.Get(srv.URL + "/hits/batch/" + submitted.BatchID + "?page=zero")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("bad page status = %d, want 400", resp.StatusCode)
+	}
+}
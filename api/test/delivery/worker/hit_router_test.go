@@ -0,0 +1,107 @@
+package worker_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	delivery "github.com/adityawiryaa/api/internal/delivery/http/worker"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	workeruc "github.com/adityawiryaa/api/internal/usecases/worker"
+	"github.com/adityawiryaa/api/pkg/compression"
+	"github.com/adityawiryaa/api/pkg/response"
+	workerclient "github.com/adityawiryaa/api/pkg/worker"
+)
+
+func TestHitResponseShape(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      map[string]string
+		wantTasks []string
+		wantList  bool
+	}{
+		{
+			name:      "single url keeps the single task shape",
+			data:      map[string]string{"url": "https://example.com"},
+			wantTasks: []string{"default"},
+		},
+		{
+			name:      "named jobs return a task list",
+			data:      map[string]string{"jobs.a.url": "https://a.test", "jobs.b.url": "https://b.test"},
+			wantTasks: []string{"a", "b"},
+			wantList:  true,
+		},
+		{
+			name:      "an explicit default job is still a list",
+			data:      map[string]string{"jobs.default.url": "https://example.com"},
+			wantTasks: []string{"default"},
+			wantList:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, method := range []string{http.MethodGet, http.MethodPost} {
+			t.Run(tt.name+" "+method, func(t *testing.T) {
+				store := memory.NewConfigStore()
+				store.Set(&entity.Config{Version: 1, Data: tt.data})
+				queue := &fakeQueue{}
+				commandUC := workeruc.NewCommandUsecaseWithEnqueuer(nil, store, queue, nil, nil)
+				queryUC := workeruc.NewQueryUsecase(store, nil, nil, nil, nil)
+				srv := httptest.NewServer(delivery.SetupRouter(delivery.NewHandler(commandUC, queryUC), "key", compression.DefaultConfig()))
+				defer srv.Close()
+
+				var body *strings.Reader
+				if method == http.MethodPost {
+					body = strings.NewReader(`{}`)
+				} else {
+					body = strings.NewReader("")
+				}
+				req, _ := http.NewRequest(method, srv.URL+"/hit", body)
+				req.Header.Set("Content-Type", "application/json")
+				resp, err := http.DefaultClient.Do(req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer resp.Body.Close()
+				if resp.StatusCode != http.StatusAccepted {
+					t.Fatalf("status = %d, want 202", resp.StatusCode)
+				}
+
+				var apiResp response.APIResponse
+				if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+					t.Fatal(err)
+				}
+				data, _ := apiResp.Data.(map[string]any)
+				_, hasTasks := data["tasks"]
+				_, hasTaskID := data["task_id"]
+				if hasTasks != tt.wantList || hasTaskID == tt.wantList {
+					t.Fatalf("data = %v, want list shape %v", data, tt.wantList)
+				}
+
+				client := workerclient.NewClient(srv.URL, time.Second)
+				var hitReq *request.HitRequest
+				if method == http.MethodPost {
+					hitReq = &request.HitRequest{}
+				}
+				triggered, err := client.TriggerHit(context.Background(), hitReq)
+				if err != nil {
+					t.Fatalf("trigger: %v", err)
+				}
+				if len(triggered.Tasks) != len(tt.wantTasks) {
+					t.Fatalf("tasks = %+v, want jobs %v", triggered.Tasks, tt.wantTasks)
+				}
+				for i, task := range triggered.Tasks {
+					if task.Job != tt.wantTasks[i] || task.TaskID == "" {
+						t.Errorf("task %d = %+v, want job %s", i, task, tt.wantTasks[i])
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package middleware_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/adityawiryaa/api/internal/middleware"
+	"github.com/adityawiryaa/api/pkg/compression"
+	"github.com/gin-gonic/gin"
+)
+
+func TestCompressionResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	large := strings.Repeat("a", 2048)
+
+	tests := []struct {
+		name         string
+		cfg          compression.Config
+		accept       string
+		body         string
+		wantEncoding string
+	}{
+		{
+			name:         "zstd preferred",
+			cfg:          compression.DefaultConfig(),
+			accept:       "gzip, zstd",
+			body:         large,
+			wantEncoding: compression.Zstd,
+		},
+		{
+			name:         "gzip only client",
+			cfg:          compression.DefaultConfig(),
+			accept:       "gzip",
+			body:         large,
+			wantEncoding: compression.Gzip,
+		},
+		{
+			name:   "below threshold",
+			cfg:    compression.DefaultConfig(),
+			accept: "gzip, zstd",
+			body:   "small",
+		},
+		{
+			name: "no accept encoding",
+			cfg:  compression.DefaultConfig(),
+			body: large,
+		},
+		{
+			name:   "disabled",
+			cfg:    compression.Config{},
+			accept: "gzip, zstd",
+			body:   large,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(middleware.Compression(tt.cfg))
+			r.GET("/test", func(c *gin.Context) {
+				c.Header("Vary", "Accept")
+				c.String(http.StatusOK, tt.body)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept-Encoding", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if tt.wantEncoding != "" && !slices.Contains(w.Header().Values("Vary"), "Accept-Encoding") {
+				t.Errorf("Vary = %q, want Accept-Encoding added", w.Header().Values("Vary"))
+			}
+
+			body := io.Reader(w.Body)
+			if tt.wantEncoding != "" {
+				reader, err := compression.NewReader(tt.wantEncoding, w.Body)
+				if err != nil {
+					t.Fatalf("reader: %v", err)
+				}
+				defer reader.Close()
+				body = reader
+			}
+			got, err := io.ReadAll(body)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if string(got) != tt.body {
+				t.Errorf("body length = %d, want %d", len(got), len(tt.body))
+			}
+		})
+	}
+}
+
+func TestCompressionRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	payload := []byte(`{"url":"https://example.com"}`)
+	gzipped, _ := compression.Compress(compression.Gzip, payload)
+	zstded, _ := compression.Compress(compression.Zstd, payload)
+
+	tests := []struct {
+		name       string
+		encoding   string
+		body       []byte
+		wantStatus int
+	}{
+		{name: "plain", body: payload, wantStatus: http.StatusOK},
+		{name: "gzip", encoding: compression.Gzip, body: gzipped, wantStatus: http.StatusOK},
+		{name: "zstd", encoding: compression.Zstd, body: zstded, wantStatus: http.StatusOK},
+		{name: "corrupt gzip", encoding: compression.Gzip, body: payload, wantStatus: http.StatusBadRequest},
+		{name: "unsupported", encoding: "br", body: payload, wantStatus: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(middleware.Compression(compression.DefaultConfig()))
+			r.POST("/test", func(c *gin.Context) {
+				got, err := io.ReadAll(c.Request.Body)
+				if err != nil || !bytes.Equal(got, payload) {
+					c.Status(http.StatusBadRequest)
+					return
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader(tt.body))
+			if tt.encoding != "" {
+				req.Header.Set("Content-Encoding", tt.encoding)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCompressionRequestLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	payload := bytes.Repeat([]byte("a"), 4096)
+
+	tests := []struct {
+		name       string
+		encoding   string
+		limit      int
+		wantStatus int
+	}{
+		{name: "gzip within limit", encoding: compression.Gzip, limit: 4096, wantStatus: http.StatusOK},
+		{name: "zstd within limit", encoding: compression.Zstd, limit: 4096, wantStatus: http.StatusOK},
+		{name: "oversized gzip", encoding: compression.Gzip, limit: 4095, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "oversized zstd", encoding: compression.Zstd, limit: 1024, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compressed, err := compression.Compress(tt.encoding, payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg := compression.DefaultConfig()
+			cfg.MaxRequestSize = tt.limit
+
+			r := gin.New()
+			r.Use(middleware.Compression(cfg))
+			r.POST("/test", func(c *gin.Context) {
+				got, err := io.ReadAll(c.Request.Body)
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					c.Status(http.StatusRequestEntityTooLarge)
+					return
+				}
+				if err != nil || !bytes.Equal(got, payload) {
+					c.Status(http.StatusBadRequest)
+					return
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader(compressed))
+			req.Header.Set("Content-Encoding", tt.encoding)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCompressionRequestDecoderClosed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	compressed, _ := compression.Compress(compression.Zstd, []byte(`{"url":"https://example.com"}`))
+
+	var body io.Reader
+	r := gin.New()
+	r.Use(middleware.Compression(compression.DefaultConfig()))
+	r.POST("/test", func(c *gin.Context) {
+		body = c.Request.Body
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader(compressed))
+	req.Header.Set("Content-Encoding", compression.Zstd)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	if body == nil {
+		t.Fatal("handler did not run")
+	}
+	if _, err := body.Read(make([]byte, 1)); err == nil {
+		t.Error("request body still readable after the request, want the decoder closed")
+	}
+}
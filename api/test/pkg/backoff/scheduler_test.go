@@ -0,0 +1,145 @@
+package backoff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+func TestJitter(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		fraction float64
+		wantMin  time.Duration
+		wantMax  time.Duration
+	}{
+		{
+			name:     "no jitter",
+			interval: 10 * time.Second,
+			wantMin:  10 * time.Second,
+			wantMax:  10 * time.Second,
+		},
+		{
+			name:     "ten percent",
+			interval: 10 * time.Second,
+			fraction: 0.1,
+			wantMin:  9 * time.Second,
+			wantMax:  11 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				got := backoff.Jitter(tt.interval, tt.fraction)
+				if got < tt.wantMin || got > tt.wantMax {
+					t.Fatalf("Jitter() = %v, want between %v and %v", got, tt.wantMin, tt.wantMax)
+				}
+			}
+		})
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	cfg := backoff.Config{MaxInterval: 80 * time.Second, Multiplier: 2.0, MaxRetries: 10}
+
+	tests := []struct {
+		name     string
+		jitter   float64
+		run      func(s *backoff.Scheduler) time.Duration
+		wantMin  time.Duration
+		wantMax  time.Duration
+		failures int
+	}{
+		{
+			name:    "success uses interval",
+			run:     func(s *backoff.Scheduler) time.Duration { return s.Success() },
+			wantMin: 10 * time.Second,
+			wantMax: 10 * time.Second,
+		},
+		{
+			name: "consecutive failures back off",
+			run: func(s *backoff.Scheduler) time.Duration {
+				s.Failure()
+				s.Failure()
+				return s.Failure()
+			},
+			wantMin:  40 * time.Second,
+			wantMax:  60 * time.Second,
+			failures: 3,
+		},
+		{
+			name: "backoff is capped",
+			run: func(s *backoff.Scheduler) time.Duration {
+				for range 9 {
+					s.Failure()
+				}
+				return s.Failure()
+			},
+			wantMin:  80 * time.Second,
+			wantMax:  80 * time.Second,
+			failures: 10,
+		},
+		{
+			name: "jitter never exceeds the cap",
+			run: func(s *backoff.Scheduler) time.Duration {
+				s.Failure()
+				s.Failure()
+				s.Failure()
+				return s.Failure()
+			},
+			wantMin:  80 * time.Second,
+			wantMax:  80 * time.Second,
+			failures: 4,
+		},
+		{
+			name: "success resets failures",
+			run: func(s *backoff.Scheduler) time.Duration {
+				s.Failure()
+				s.Failure()
+				return s.Success()
+			},
+			wantMin: 10 * time.Second,
+			wantMax: 10 * time.Second,
+		},
+		{
+			name:     "retry-after is honored",
+			run:      func(s *backoff.Scheduler) time.Duration { return s.RetryAfter(2 * time.Minute) },
+			wantMin:  2 * time.Minute,
+			wantMax:  2 * time.Minute,
+			failures: 1,
+		},
+		{
+			name:     "retry-after is not capped",
+			jitter:   0.1,
+			run:      func(s *backoff.Scheduler) time.Duration { return s.RetryAfter(10 * time.Minute) },
+			wantMin:  10 * time.Minute,
+			wantMax:  11 * time.Minute,
+			failures: 1,
+		},
+		{
+			name: "interval hint",
+			run: func(s *backoff.Scheduler) time.Duration {
+				s.SetInterval(5 * time.Second)
+				return s.Success()
+			},
+			wantMin: 5 * time.Second,
+			wantMax: 5 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := backoff.NewScheduler(10*time.Second, tt.jitter, cfg)
+			got := tt.run(s)
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("wait = %v, want between %v and %v", got, tt.wantMin, tt.wantMax)
+			}
+			if s.Failures() != tt.failures {
+				t.Errorf("failures = %d, want %d", s.Failures(), tt.failures)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package bundlesign_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adityawiryaa/api/pkg/bundlesign"
+)
+
+func writeKeys(t *testing.T, dir string) (keyPath, pubPath string) {
+	t.Helper()
+	privatePEM, publicPEM, err := bundlesign.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	keyPath, pubPath = filepath.Join(dir, "signing.key"), filepath.Join(dir, "signing.pub")
+	if err := os.WriteFile(keyPath, privatePEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pubPath, publicPEM, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return keyPath, pubPath
+}
+
+func TestSignVerify(t *testing.T) {
+	keyPath, pubPath := writeKeys(t, t.TempDir())
+	_, otherPubPath := writeKeys(t, t.TempDir())
+
+	signer, err := bundlesign.LoadSigner(keyPath)
+	if err != nil {
+		t.Fatalf("load signer: %v", err)
+	}
+	verifier, err := bundlesign.LoadVerifier(pubPath)
+	if err != nil {
+		t.Fatalf("load verifier: %v", err)
+	}
+	other, err := bundlesign.LoadVerifier(otherPubPath)
+	if err != nil {
+		t.Fatalf("load verifier: %v", err)
+	}
+	if signer.KeyID() != verifier.KeyID() || signer.KeyID() == other.KeyID() {
+		t.Fatalf("key ids: signer %s, verifier %s, other %s", signer.KeyID(), verifier.KeyID(), other.KeyID())
+	}
+
+	payload := []byte(`{"config":{"version":3}}`)
+	signature := signer.Sign(payload)
+
+	tests := []struct {
+		name     string
+		verifier *bundlesign.Verifier
+		keyID    string
+		payload  []byte
+		wantErr  bool
+	}{
+		{name: "valid", verifier: verifier, keyID: signer.KeyID(), payload: payload},
+		{name: "valid without key id", verifier: verifier, payload: payload},
+		{name: "tampered payload", verifier: verifier, keyID: signer.KeyID(), payload: []byte(`{"config":{"version":4}}`), wantErr: true},
+		{name: "other key", verifier: other, payload: payload, wantErr: true},
+		{name: "key id mismatch", verifier: other, keyID: signer.KeyID(), payload: payload, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.verifier.Verify(tt.keyID, tt.payload, signature)
+			if tt.wantErr && !errors.Is(err, bundlesign.ErrInvalidSignature) {
+				t.Errorf("err = %v, want ErrInvalidSignature", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadKeyErrors(t *testing.T) {
+	keyPath, pubPath := writeKeys(t, t.TempDir())
+
+	if _, err := bundlesign.LoadSigner(pubPath); err == nil {
+		t.Error("expected error loading a public key as signing key")
+	}
+	if _, err := bundlesign.LoadVerifier(keyPath); err == nil {
+		t.Error("expected error loading a private key as public key")
+	}
+	if _, err := bundlesign.LoadVerifier(filepath.Join(t.TempDir(), "missing.pub")); err == nil {
+		t.Error("expected error for missing key file")
+	}
+}
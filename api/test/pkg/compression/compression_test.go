@@ -0,0 +1,98 @@
+package compression_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/adityawiryaa/api/pkg/compression"
+)
+
+func TestNegotiate(t *testing.T) {
+	offered := []string{compression.Zstd, compression.Gzip}
+
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "no header", accept: "", want: ""},
+		{name: "server preference on tie", accept: "gzip, zstd", want: compression.Zstd},
+		{name: "client quality wins", accept: "zstd;q=0.5, gzip", want: compression.Gzip},
+		{name: "only gzip", accept: "gzip, deflate, br", want: compression.Gzip},
+		{name: "refused encodings", accept: "zstd;q=0, gzip;q=0", want: ""},
+		{name: "wildcard", accept: "*", want: compression.Zstd},
+		{name: "wildcard with exclusion", accept: "*, zstd;q=0", want: compression.Gzip},
+		{name: "unsupported only", accept: "br", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compression.Negotiate(tt.accept, offered); got != tt.want {
+				t.Errorf("Negotiate(%q) = %q, want %q", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"url":"https://example.com"}`), 100)
+
+	for _, encoding := range []string{compression.Gzip, compression.Zstd} {
+		t.Run(encoding, func(t *testing.T) {
+			compressed, err := compression.Compress(encoding, data)
+			if err != nil {
+				t.Fatalf("compress: %v", err)
+			}
+			if len(compressed) >= len(data) {
+				t.Errorf("compressed size %d is not smaller than %d", len(compressed), len(data))
+			}
+
+			r, err := compression.NewReader(encoding, bytes.NewReader(compressed))
+			if err != nil {
+				t.Fatalf("reader: %v", err)
+			}
+			defer r.Close()
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Error("round trip changed the data")
+			}
+		})
+	}
+
+	if _, err := compression.Compress("br", data); !errors.Is(err, compression.ErrUnsupportedEncoding) {
+		t.Errorf("err = %v, want ErrUnsupportedEncoding", err)
+	}
+}
+
+func TestParseEncodings(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "default order", value: "zstd,gzip", want: []string{"zstd", "gzip"}},
+		{name: "normalized and deduplicated", value: " GZIP , gzip,zstd", want: []string{"gzip", "zstd"}},
+		{name: "empty disables", value: "", want: nil},
+		{name: "none disables", value: "none", want: nil},
+		{name: "unsupported", value: "gzip,br", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compression.ParseEncodings(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseEncodings(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package configdiff_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/adityawiryaa/api/pkg/configdiff"
+	"github.com/adityawiryaa/api/pkg/jsonpatch"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldData map[string]string
+		newData map[string]string
+		want    []configdiff.Change
+	}{
+		{
+			name:    "identical",
+			oldData: map[string]string{"a": "1"},
+			newData: map[string]string{"a": "1"},
+			want:    nil,
+		},
+		{
+			name:    "added removed and changed sorted by key",
+			oldData: map[string]string{"b": "1", "c": "old"},
+			newData: map[string]string{"a": "new", "c": "new"},
+			want: []configdiff.Change{
+				{Key: "a", Op: configdiff.OpAdded, NewValue: "new"},
+				{Key: "b", Op: configdiff.OpRemoved, OldValue: "1"},
+				{Key: "c", Op: configdiff.OpChanged, OldValue: "old", NewValue: "new"},
+			},
+		},
+		{
+			name:    "nil maps",
+			oldData: nil,
+			newData: map[string]string{"a": "1"},
+			want:    []configdiff.Change{{Key: "a", Op: configdiff.OpAdded, NewValue: "1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := configdiff.Diff(tt.oldData, tt.newData)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldData map[string]string
+		newData map[string]string
+		wantOps int
+	}{
+		{
+			name:    "identical",
+			oldData: map[string]string{"a": "1"},
+			newData: map[string]string{"a": "1"},
+		},
+		{
+			name:    "added removed and changed",
+			oldData: map[string]string{"b": "1", "c": "old"},
+			newData: map[string]string{"a": "new", "c": "new"},
+			wantOps: 3,
+		},
+		{
+			name:    "keys with slashes and tildes",
+			oldData: map[string]string{"db/host": "a", "x~y": "1"},
+			newData: map[string]string{"db/host": "b", "x~y": "2", "": "empty key"},
+			wantOps: 3,
+		},
+		{
+			name:    "from empty",
+			newData: map[string]string{"a": "1"},
+			wantOps: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := configdiff.JSONPatch(tt.oldData, tt.newData)
+			if len(patch) != tt.wantOps {
+				t.Errorf("ops = %d, want %d: %+v", len(patch), tt.wantOps, patch)
+			}
+
+			base := tt.oldData
+			if base == nil {
+				base = map[string]string{}
+			}
+			doc, _ := json.Marshal(base)
+			patched, err := jsonpatch.Apply(doc, patch)
+			if err != nil {
+				t.Fatalf("apply: %v", err)
+			}
+			var got map[string]string
+			if err := json.Unmarshal(patched, &got); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if configdiff.Hash(got) != configdiff.Hash(tt.newData) {
+				t.Errorf("patched = %v, want %v", got, tt.newData)
+			}
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	a := configdiff.Hash(map[string]string{"a": "1", "b": "2"})
+	b := configdiff.Hash(map[string]string{"b": "2", "a": "1"})
+	if a != b {
+		t.Errorf("hash depends on key order: %s != %s", a, b)
+	}
+	if a == configdiff.Hash(map[string]string{"a": "1", "b": "3"}) {
+		t.Error("different data produced the same hash")
+	}
+	if configdiff.Hash(nil) != configdiff.Hash(map[string]string{}) {
+		t.Error("nil and empty data should hash the same")
+	}
+}
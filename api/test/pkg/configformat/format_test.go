@@ -0,0 +1,183 @@
+package configformat_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/adityawiryaa/api/pkg/configformat"
+)
+
+func TestUnmarshalConfigDocument(t *testing.T) {
+	want := map[string]string{"url": "https://example.com", "retries": "3", "enabled": "true"}
+
+	tests := []struct {
+		name         string
+		format       string
+		body         string
+		wantInterval int
+	}{
+		{
+			name:         "json",
+			format:       configformat.FormatJSON,
+			body:         `{"data":{"url":"https://example.com","retries":3,"enabled":true},"poll_interval_seconds":15}`,
+			wantInterval: 15,
+		},
+		{
+			name:         "yaml",
+			format:       configformat.FormatYAML,
+			body:         "data:\n  url: https://example.com\n  retries: 3\n  enabled: true\npoll_interval_seconds: 15\n",
+			wantInterval: 15,
+		},
+		{
+			name:         "toml",
+			format:       configformat.FormatTOML,
+			body:         "poll_interval_seconds = 15\n\n[data]\nurl = \"https://example.com\"\nretries = 3\nenabled = true\n",
+			wantInterval: 15,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc configformat.ConfigDocument
+			if err := configformat.Unmarshal(tt.format, []byte(tt.body), &doc); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := doc.StringData(); !reflect.DeepEqual(got, want) {
+				t.Errorf("data = %v, want %v", got, want)
+			}
+			if doc.PollIntervalSeconds != tt.wantInterval {
+				t.Errorf("poll_interval_seconds = %d, want %d", doc.PollIntervalSeconds, tt.wantInterval)
+			}
+		})
+	}
+}
+
+func TestUnmarshalKeepsLargeNumbers(t *testing.T) {
+	want := map[string]string{
+		"port":   "12345678",
+		"size":   "10000000000",
+		"id":     "9007199254740993",
+		"ratio":  "0.25",
+		"nested": `{"limit":12345678}`,
+	}
+
+	tests := []struct {
+		name   string
+		format string
+		body   string
+	}{
+		{
+			name:   "json",
+			format: configformat.FormatJSON,
+			body:   `{"data":{"port":12345678,"size":10000000000,"id":9007199254740993,"ratio":0.25,"nested":{"limit":12345678}}}`,
+		},
+		{
+			name:   "yaml",
+			format: configformat.FormatYAML,
+			body:   "data:\n  port: 12345678\n  size: 10000000000\n  id: 9007199254740993\n  ratio: 0.25\n  nested:\n    limit: 12345678\n",
+		},
+		{
+			name:   "toml",
+			format: configformat.FormatTOML,
+			body:   "[data]\nport = 12345678\nsize = 10000000000\nid = 9007199254740993\nratio = 0.25\n\n[data.nested]\nlimit = 12345678\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc configformat.ConfigDocument
+			if err := configformat.Unmarshal(tt.format, []byte(tt.body), &doc); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if got := doc.StringData(); !reflect.DeepEqual(got, want) {
+				t.Errorf("data = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestStringifyFloats(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{value: float64(12345678), want: "12345678"},
+		{value: float64(1e10), want: "10000000000"},
+		{value: 0.5, want: "0.5"},
+		{value: int64(42), want: "42"},
+		{value: true, want: "true"},
+	}
+	for _, tt := range tests {
+		if got := configformat.Stringify(tt.value); got != tt.want {
+			t.Errorf("Stringify(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMarshalUsesJSONTags(t *testing.T) {
+	v := struct {
+		Version int64             `json:"version"`
+		Data    map[string]string `json:"data"`
+		Empty   string            `json:"empty,omitempty"`
+	}{Version: 3, Data: map[string]string{"k": "v"}}
+
+	tests := []struct {
+		format   string
+		contains []string
+	}{
+		{format: configformat.FormatYAML, contains: []string{"version: 3", "k: v"}},
+		{format: configformat.FormatTOML, contains: []string{"version = 3", "k = 'v'"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			raw, err := configformat.Marshal(tt.format, v)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			out := string(raw)
+			for _, s := range tt.contains {
+				if !strings.Contains(out, s) {
+					t.Errorf("output %q does not contain %q", out, s)
+				}
+			}
+			if strings.Contains(out, "empty") {
+				t.Errorf("output %q should omit empty field", out)
+			}
+		})
+	}
+}
+
+func TestFromContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        string
+		wantErr     bool
+	}{
+		{contentType: "", want: configformat.FormatJSON},
+		{contentType: "application/json; charset=utf-8", want: configformat.FormatJSON},
+		{contentType: "application/yaml", want: configformat.FormatYAML},
+		{contentType: "application/x-yaml", want: configformat.FormatYAML},
+		{contentType: "application/toml", want: configformat.FormatTOML},
+		{contentType: "text/plain", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			got, err := configformat.FromContentType(tt.contentType)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("format = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
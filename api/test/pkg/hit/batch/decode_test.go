@@ -0,0 +1,161 @@
+package batch_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adityawiryaa/api/pkg/hit/batch"
+)
+
+func TestFormatOf(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		filename    string
+		want        string
+		wantErr     bool
+	}{
+		{name: "json content type", contentType: "application/json; charset=utf-8", want: batch.FormatJSON},
+		{name: "csv content type", contentType: "text/csv", want: batch.FormatCSV},
+		{name: "ndjson content type", contentType: "application/x-ndjson", want: batch.FormatNDJSON},
+		{name: "extension wins for generic upload", contentType: "application/octet-stream", filename: "urls.csv", want: batch.FormatCSV},
+		{name: "jsonl extension", filename: "urls.JSONL", want: batch.FormatNDJSON},
+		{name: "no content type defaults to json", want: batch.FormatJSON},
+		{name: "unsupported", contentType: "text/plain", wantErr: true},
+		{name: "unsupported file", filename: "urls.txt", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := batch.FormatOf(tt.contentType, tt.filename)
+			if tt.wantErr {
+				if !errors.Is(err, batch.ErrUnsupportedFormat) {
+					t.Fatalf("err = %v, want ErrUnsupportedFormat", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("FormatOf = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		input      string
+		wantURLs   []string
+		wantErr    string
+		wantMethod string
+		wantHeader string
+		wantQuery  string
+		wantBody   string
+	}{
+		{
+			name:       "json object",
+			format:     batch.FormatJSON,
+			input:      `{"hits": [{"url": "https://a.test", "method": "POST", "headers": {"X-Trace": "1"}, "body": {"a": 1}, "body_type": "json"}, {"url": "https://b.test"}]}`,
+			wantURLs:   []string{"https://a.test", "https://b.test"},
+			wantMethod: "POST",
+			wantHeader: "1",
+			wantBody:   `{"a": 1}`,
+		},
+		{
+			name:     "json array",
+			format:   batch.FormatJSON,
+			input:    `[{"url": "https://a.test"}]`,
+			wantURLs: []string{"https://a.test"},
+		},
+		{
+			name:       "ndjson skips blank lines",
+			format:     batch.FormatNDJSON,
+			input:      "{\"url\": \"https://a.test\", \"method\": \"PUT\", \"query\": {\"page\": \"2\"}}\n\n{\"url\": \"https://b.test\"}\n",
+			wantURLs:   []string{"https://a.test", "https://b.test"},
+			wantMethod: "PUT",
+			wantQuery:  "2",
+		},
+		{
+			name:    "ndjson bad line",
+			format:  batch.FormatNDJSON,
+			input:   "{\"url\": \"https://a.test\"}\nnot json\n",
+			wantErr: "line 2",
+		},
+		{
+			name:       "csv with header and query columns",
+			format:     batch.FormatCSV,
+			input:      "url,method,headers.X-Trace,query.page,body\nhttps://a.test,POST,1,2,hello\nhttps://b.test,,,,\n",
+			wantURLs:   []string{"https://a.test", "https://b.test"},
+			wantMethod: "POST",
+			wantHeader: "1",
+			wantQuery:  "2",
+			wantBody:   `"hello"`,
+		},
+		{
+			name:     "csv json body",
+			format:   batch.FormatCSV,
+			input:    "url,body_type,body\nhttps://a.test,json,\"{\"\"a\"\": 1}\"\n",
+			wantURLs: []string{"https://a.test"},
+			wantBody: `{"a": 1}`,
+		},
+		{
+			name:     "csv form body",
+			format:   batch.FormatCSV,
+			input:    "url,body_type,body\nhttps://a.test,form,a=1&b=two\n",
+			wantURLs: []string{"https://a.test"},
+			wantBody: `{"a":"1","b":"two"}`,
+		},
+		{
+			name:    "csv unknown column",
+			format:  batch.FormatCSV,
+			input:   "url,colour\nhttps://a.test,red\n",
+			wantErr: `"colour"`,
+		},
+		{
+			name:    "csv ragged row",
+			format:  batch.FormatCSV,
+			input:   "url,method\nhttps://a.test\n",
+			wantErr: "wrong number of fields",
+		},
+		{
+			name:   "empty csv",
+			format: batch.FormatCSV,
+			input:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := batch.Decode(tt.format, strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(hits) != len(tt.wantURLs) {
+				t.Fatalf("hits = %+v, want %d", hits, len(tt.wantURLs))
+			}
+			for i, url := range tt.wantURLs {
+				if hits[i].URL != url {
+					t.Errorf("hits[%d].URL = %q, want %q", i, hits[i].URL, url)
+				}
+			}
+			if len(hits) == 0 {
+				return
+			}
+			first := hits[0]
+			if first.Method != tt.wantMethod || first.Headers["X-Trace"] != tt.wantHeader || first.Query["page"] != tt.wantQuery {
+				t.Errorf("first hit = %+v", first)
+			}
+			if string(first.Body) != tt.wantBody {
+				t.Errorf("body = %s, want %s", first.Body, tt.wantBody)
+			}
+		})
+	}
+}
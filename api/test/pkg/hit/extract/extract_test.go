@@ -0,0 +1,182 @@
+package extract_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/adityawiryaa/api/pkg/hit/extract"
+)
+
+const page = `<!DOCTYPE html>
+<html><head><title> Shop </title></head>
+<body>
+  <h1 class="title">Spring <em>sale</em></h1>
+  <a class="next" href="/page/2">next</a>
+  <ul>
+    <li class="product" data-sku="a1"><h2>Kettle</h2><span class="price">19.99</span><a href="/p/a1">view</a></li>
+    <li class="product" data-sku="b2"><h2>Toaster</h2><a href="/p/b2">view</a></li>
+  </ul>
+</body></html>`
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		rules       []extract.Rule
+		want        string
+		wantErr     error
+	}{
+		{
+			name:  "text is trimmed",
+			body:  page,
+			rules: []extract.Rule{{Name: "title", Selector: "title"}},
+			want:  `{"title":"Shop"}`,
+		},
+		{
+			name:  "attr and html modes",
+			body:  page,
+			rules: []extract.Rule{{Name: "next", Selector: "a.next", Mode: "attr", Attr: "href"}, {Name: "heading", Selector: "h1", Mode: "html"}},
+			want:  `{"heading":"Spring <em>sale</em>","next":"/page/2"}`,
+		},
+		{
+			name:  "single takes the first match",
+			body:  page,
+			rules: []extract.Rule{{Name: "first", Selector: "li h2"}},
+			want:  `{"first":"Kettle"}`,
+		},
+		{
+			name:  "list of values",
+			body:  page,
+			rules: []extract.Rule{{Name: "skus", Selector: "li.product", Mode: "attr", Attr: "data-sku", List: true}},
+			want:  `{"skus":["a1","b2"]}`,
+		},
+		{
+			name: "nested item group",
+			body: page,
+			rules: []extract.Rule{{Name: "products", Selector: "li.product", List: true, Fields: []extract.Rule{
+				{Name: "name", Selector: "h2"},
+				{Name: "price", Selector: ".price"},
+				{Name: "sku", Mode: "attr", Attr: "data-sku"},
+				{Name: "links", Selector: "a", Mode: "attr", Attr: "href", List: true},
+			}}},
+			want: `{"products":[{"links":["/p/a1"],"name":"Kettle","price":"19.99","sku":"a1"},{"links":["/p/b2"],"name":"Toaster","price":null,"sku":"b2"}]}`,
+		},
+		{
+			name:  "missing matches",
+			body:  page,
+			rules: []extract.Rule{{Name: "none", Selector: ".missing"}, {Name: "many", Selector: ".missing", List: true}, {Name: "alt", Selector: "h1", Mode: "attr", Attr: "alt"}},
+			want:  `{"alt":null,"many":[],"none":null}`,
+		},
+		{
+			name:  "html fragment",
+			body:  `<div><p class="msg">hi</p></div>`,
+			rules: []extract.Rule{{Name: "msg", Selector: "p.msg"}},
+			want:  `{"msg":"hi"}`,
+		},
+		{
+			name:    "json response",
+			body:    `{"title": "not html"}`,
+			rules:   []extract.Rule{{Name: "title", Selector: "title"}},
+			wantErr: extract.ErrNotHTML,
+		},
+		{
+			name:    "binary response",
+			body:    "\x89PNG\r\n\x1a\n<",
+			rules:   []extract.Rule{{Name: "title", Selector: "title"}},
+			wantErr: extract.ErrNotHTML,
+		},
+		{
+			name:        "html content type",
+			contentType: "text/html; charset=utf-8",
+			body:        page,
+			rules:       []extract.Rule{{Name: "title", Selector: "title"}},
+			want:        `{"title":"Shop"}`,
+		},
+		{
+			name:        "xhtml content type",
+			contentType: "application/xhtml+xml",
+			body:        `<html xmlns="http://www.w3.org/1999/xhtml"><body><p>hi</p></body></html>`,
+			rules:       []extract.Rule{{Name: "msg", Selector: "p"}},
+			want:        `{"msg":"hi"}`,
+		},
+		{
+			name:        "markup served as json",
+			contentType: "application/json",
+			body:        page,
+			rules:       []extract.Rule{{Name: "title", Selector: "title"}},
+			wantErr:     extract.ErrNotHTML,
+		},
+		{
+			name:        "markup served as plain text",
+			contentType: "text/plain; charset=utf-8",
+			body:        `<p class="msg">hi</p>`,
+			rules:       []extract.Rule{{Name: "msg", Selector: "p.msg"}},
+			wantErr:     extract.ErrNotHTML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extract.Extract(tt.contentType, []byte(tt.body), tt.rules)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !json.Valid(got) || string(got) != tt.want {
+				t.Errorf("extracted = %s\nwant        %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []extract.Rule
+		wantErr string
+	}{
+		{name: "valid", rules: []extract.Rule{{Name: "a", Selector: "div > p:nth-child(2)", Mode: "html"}}},
+		{name: "missing top-level selector", rules: []extract.Rule{{Name: "a"}}, wantErr: "a: selector is required"},
+		{name: "bad selector", rules: []extract.Rule{{Name: "a", Selector: "div[", Mode: "text"}}, wantErr: `a: selector "div["`},
+		{name: "unknown mode", rules: []extract.Rule{{Name: "a", Selector: "p", Mode: "json"}}, wantErr: `mode "json"`},
+		{name: "attr mode without attr", rules: []extract.Rule{{Name: "a", Selector: "a", Mode: "attr"}}, wantErr: "needs an attr"},
+		{name: "attr with text mode", rules: []extract.Rule{{Name: "a", Selector: "a", Mode: "text", Attr: "href"}}, wantErr: "attr is only used"},
+		{
+			name:    "group with mode",
+			rules:   []extract.Rule{{Name: "g", Selector: "li", Mode: "html", Fields: []extract.Rule{{Name: "x", Selector: "p"}}}},
+			wantErr: "group with fields",
+		},
+		{
+			name:    "nested errors carry the path",
+			rules:   []extract.Rule{{Name: "g", Selector: "li", Fields: []extract.Rule{{Name: "x", Selector: "p", Mode: "attr"}}}},
+			wantErr: "g.x: mode attr needs an attr",
+		},
+		{
+			name:  "nested field without selector",
+			rules: []extract.Rule{{Name: "g", Selector: "li", Fields: []extract.Rule{{Name: "x", Mode: "attr", Attr: "id"}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := extract.Validate(tt.rules)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package queue_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hibiken/asynq"
+
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+)
+
+type fakeEnqueuer struct {
+	failAt int
+	tasks  []string
+	calls  int
+}
+
+func (f *fakeEnqueuer) Enqueue(task *asynq.Task, _ ...asynq.Option) (*asynq.TaskInfo, error) {
+	f.calls++
+	if f.failAt >= 0 && len(f.tasks) == f.failAt {
+		return nil, errors.New("redis unavailable")
+	}
+	var payload hitqueue.ExecuteHitPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return nil, err
+	}
+	f.tasks = append(f.tasks, payload.TaskID)
+	return &asynq.TaskInfo{ID: payload.TaskID}, nil
+}
+
+func hitPayloads(n int) []*hitqueue.ExecuteHitPayload {
+	payloads := make([]*hitqueue.ExecuteHitPayload, n)
+	for i := range payloads {
+		payloads[i] = &hitqueue.ExecuteHitPayload{TaskID: fmt.Sprintf("task-%d", i), Method: "GET", URL: "https://example.com"}
+	}
+	return payloads
+}
+
+func TestEnqueueExecuteHits(t *testing.T) {
+	tests := []struct {
+		name         string
+		payloads     int
+		failAt       int
+		wantEnqueued int
+		wantErrTask  string
+	}{
+		{name: "all enqueued", payloads: 3, failAt: -1, wantEnqueued: 3},
+		{name: "empty", payloads: 0, failAt: -1, wantEnqueued: 0},
+		{name: "stops at the first failure", payloads: 4, failAt: 2, wantEnqueued: 2, wantErrTask: "task-2"},
+		{name: "first task fails", payloads: 3, failAt: 0, wantEnqueued: 0, wantErrTask: "task-0"},
+		{name: "last task fails", payloads: 3, failAt: 2, wantEnqueued: 2, wantErrTask: "task-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enqueuer := &fakeEnqueuer{failAt: tt.failAt}
+			client := hitqueue.NewClientWithEnqueuer(enqueuer)
+
+			enqueued, err := client.EnqueueExecuteHits(hitPayloads(tt.payloads))
+			if enqueued != tt.wantEnqueued {
+				t.Errorf("enqueued = %d, want %d", enqueued, tt.wantEnqueued)
+			}
+			if tt.wantErrTask == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), "task "+tt.wantErrTask+":") || !strings.Contains(err.Error(), "redis unavailable") {
+				t.Fatalf("err = %v, want failure of %s", err, tt.wantErrTask)
+			}
+
+			want := hitPayloads(tt.wantEnqueued)
+			if len(enqueuer.tasks) != len(want) {
+				t.Fatalf("queued tasks = %v, want the first %d", enqueuer.tasks, tt.wantEnqueued)
+			}
+			for i, id := range enqueuer.tasks {
+				if id != want[i].TaskID {
+					t.Errorf("queued task %d = %s, want %s", i, id, want[i].TaskID)
+				}
+			}
+			if wantCalls := min(tt.payloads, tt.wantEnqueued+1); enqueuer.calls != wantCalls {
+				t.Errorf("enqueue calls = %d, want %d", enqueuer.calls, wantCalls)
+			}
+		})
+	}
+}
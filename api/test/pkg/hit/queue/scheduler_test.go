@@ -0,0 +1,211 @@
+package queue_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+)
+
+type fakeRegistrar struct {
+	next        int
+	entries     map[string]string
+	registered  []string
+	unregisters []string
+	registerErr error
+}
+
+func newFakeRegistrar() *fakeRegistrar {
+	return &fakeRegistrar{entries: make(map[string]string)}
+}
+
+func (f *fakeRegistrar) Register(cronspec string, task *asynq.Task, _ ...asynq.Option) (string, error) {
+	if f.registerErr != nil {
+		return "", f.registerErr
+	}
+	f.next++
+	id := fmt.Sprintf("entry-%d", f.next)
+	f.entries[id] = cronspec
+	f.registered = append(f.registered, id)
+	return id, nil
+}
+
+func (f *fakeRegistrar) Unregister(entryID string) error {
+	if _, ok := f.entries[entryID]; !ok {
+		return errors.New("no scheduler entry found")
+	}
+	delete(f.entries, entryID)
+	f.unregisters = append(f.unregisters, entryID)
+	return nil
+}
+
+func scheduleEntry(job, spec, url string) hitqueue.ScheduleEntry {
+	return hitqueue.ScheduleEntry{
+		Job:     job,
+		Spec:    spec,
+		Payload: &hitqueue.ExecuteHitPayload{Method: "GET", URL: url},
+	}
+}
+
+func TestSchedulerSync(t *testing.T) {
+	tests := []struct {
+		name           string
+		initial        []hitqueue.ScheduleEntry
+		next           []hitqueue.ScheduleEntry
+		wantErr        bool
+		wantEntries    int
+		wantRegistered int
+		wantRemoved    int
+		wantErrorJob   string
+	}{
+		{
+			name:           "unchanged schedules are kept",
+			initial:        []hitqueue.ScheduleEntry{scheduleEntry("a", "@every 1m", "http://a")},
+			next:           []hitqueue.ScheduleEntry{scheduleEntry("a", "@every 1m", "http://a")},
+			wantEntries:    1,
+			wantRegistered: 1,
+		},
+		{
+			name:           "changed spec is re-registered",
+			initial:        []hitqueue.ScheduleEntry{scheduleEntry("a", "@every 1m", "http://a")},
+			next:           []hitqueue.ScheduleEntry{scheduleEntry("a", "*/5 * * * *", "http://a")},
+			wantEntries:    1,
+			wantRegistered: 2,
+			wantRemoved:    1,
+		},
+		{
+			name:           "changed payload is re-registered",
+			initial:        []hitqueue.ScheduleEntry{scheduleEntry("a", "@every 1m", "http://a")},
+			next:           []hitqueue.ScheduleEntry{scheduleEntry("a", "@every 1m", "http://b")},
+			wantEntries:    1,
+			wantRegistered: 2,
+			wantRemoved:    1,
+		},
+		{
+			name: "removed job is unregistered",
+			initial: []hitqueue.ScheduleEntry{
+				scheduleEntry("a", "@every 1m", "http://a"),
+				scheduleEntry("b", "@hourly", "http://b"),
+			},
+			next:           []hitqueue.ScheduleEntry{scheduleEntry("b", "@hourly", "http://b")},
+			wantEntries:    1,
+			wantRegistered: 2,
+			wantRemoved:    1,
+		},
+		{
+			name:           "invalid spec replaces a registered entry",
+			initial:        []hitqueue.ScheduleEntry{scheduleEntry("a", "@every 1m", "http://a")},
+			next:           []hitqueue.ScheduleEntry{scheduleEntry("a", "every minute", "http://a")},
+			wantErr:        true,
+			wantEntries:    1,
+			wantRegistered: 1,
+			wantRemoved:    1,
+			wantErrorJob:   "a",
+		},
+		{
+			name:    "invalid payload is reported without registering",
+			initial: nil,
+			next: []hitqueue.ScheduleEntry{
+				{Job: "a", Spec: "@every 1m", Err: errors.New("missing url")},
+				scheduleEntry("b", "@daily", "http://b"),
+			},
+			wantErr:        true,
+			wantEntries:    2,
+			wantRegistered: 1,
+			wantErrorJob:   "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registrar := newFakeRegistrar()
+			scheduler := hitqueue.NewSchedulerWithRegistrar(registrar, time.UTC, time.Now)
+
+			if err := scheduler.Sync(tt.initial); err != nil {
+				t.Fatalf("initial sync: %v", err)
+			}
+			err := scheduler.Sync(tt.next)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(registrar.registered) != tt.wantRegistered {
+				t.Errorf("registered = %v, want %d registrations", registrar.registered, tt.wantRegistered)
+			}
+			if len(registrar.unregisters) != tt.wantRemoved {
+				t.Errorf("unregistered = %v, want %d removals", registrar.unregisters, tt.wantRemoved)
+			}
+
+			entries := scheduler.Entries()
+			if len(entries) != tt.wantEntries {
+				t.Fatalf("entries = %+v, want %d", entries, tt.wantEntries)
+			}
+			active := 0
+			for _, entry := range entries {
+				if entry.Error != "" {
+					if entry.Job != tt.wantErrorJob || entry.EntryID != "" || entry.NextRunAt != nil {
+						t.Errorf("unexpected failed entry %+v", entry)
+					}
+					continue
+				}
+				if _, ok := registrar.entries[entry.EntryID]; !ok {
+					t.Errorf("entry %+v is not registered", entry)
+				}
+				active++
+			}
+			if active != len(registrar.entries) {
+				t.Errorf("active entries = %d, registrar holds %d", active, len(registrar.entries))
+			}
+		})
+	}
+}
+
+func TestSchedulerEntries(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 7, 0, 0, time.UTC)
+	registrar := newFakeRegistrar()
+	scheduler := hitqueue.NewSchedulerWithRegistrar(registrar, time.UTC, func() time.Time { return now })
+
+	err := scheduler.Sync([]hitqueue.ScheduleEntry{
+		scheduleEntry("ping", "*/15 * * * *", "http://ping"),
+		scheduleEntry("health", "@every 30s", "http://health"),
+	})
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	entries := scheduler.Entries()
+	jobs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		jobs = append(jobs, entry.Job)
+	}
+	if !slices.Equal(jobs, []string{"health", "ping"}) {
+		t.Fatalf("jobs = %v, want sorted [health ping]", jobs)
+	}
+
+	want := map[string]time.Time{
+		"health": now.Add(30 * time.Second),
+		"ping":   time.Date(2026, 3, 1, 10, 15, 0, 0, time.UTC),
+	}
+	for _, entry := range entries {
+		if entry.NextRunAt == nil || !entry.NextRunAt.Equal(want[entry.Job]) {
+			t.Errorf("%s next run = %v, want %v", entry.Job, entry.NextRunAt, want[entry.Job])
+		}
+		if entry.Method != "GET" || entry.URL != "http://"+entry.Job {
+			t.Errorf("%s method/url = %s %s", entry.Job, entry.Method, entry.URL)
+		}
+	}
+
+	registrar.registerErr = errors.New("redis down")
+	if err := scheduler.Sync([]hitqueue.ScheduleEntry{scheduleEntry("ping", "@hourly", "http://ping")}); err == nil {
+		t.Fatal("expected registration error")
+	}
+	entries = scheduler.Entries()
+	if len(entries) != 1 || entries[0].Error == "" || len(registrar.entries) != 0 {
+		t.Errorf("entries = %+v, registrar = %v", entries, registrar.entries)
+	}
+}
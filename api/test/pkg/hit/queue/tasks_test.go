@@ -0,0 +1,84 @@
+package queue_test
+
+import (
+	"maps"
+	"testing"
+
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+)
+
+func TestExecuteHitPayloadRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		payload     hitqueue.ExecuteHitPayload
+		wantURL     string
+		wantErr     bool
+		wantHeaders map[string]string
+		wantBody    string
+	}{
+		{
+			name:    "plain get",
+			payload: hitqueue.ExecuteHitPayload{URL: "https://example.com/api?b=2&a=1", Method: "GET"},
+			wantURL: "https://example.com/api?b=2&a=1",
+		},
+		{
+			name: "query merged into url",
+			payload: hitqueue.ExecuteHitPayload{
+				URL:   "https://example.com/api?page=1&sort=asc",
+				Query: map[string]string{"page": "3", "q": "a b"},
+			},
+			wantURL: "https://example.com/api?page=3&q=a+b&sort=asc",
+		},
+		{
+			name: "content type added unless a header sets it",
+			payload: hitqueue.ExecuteHitPayload{
+				URL:         "https://example.com",
+				Headers:     map[string]string{"x-trace": "1"},
+				Body:        "{}",
+				ContentType: "application/json",
+			},
+			wantURL:     "https://example.com",
+			wantHeaders: map[string]string{"X-Trace": "1", "Content-Type": "application/json"},
+			wantBody:    "{}",
+		},
+		{
+			name: "explicit content type header wins",
+			payload: hitqueue.ExecuteHitPayload{
+				URL:         "https://example.com",
+				Headers:     map[string]string{"content-type": "text/csv"},
+				ContentType: "application/json",
+			},
+			wantURL:     "https://example.com",
+			wantHeaders: map[string]string{"Content-Type": "text/csv"},
+		},
+		{
+			name:    "invalid url with query",
+			payload: hitqueue.ExecuteHitPayload{URL: "://bad", Query: map[string]string{"a": "1"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, err := tt.payload.RequestURL()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if url != tt.wantURL {
+				t.Errorf("url = %s, want %s", url, tt.wantURL)
+			}
+			if headers := tt.payload.RequestHeaders(); !maps.Equal(headers, tt.wantHeaders) {
+				t.Errorf("headers = %v, want %v", headers, tt.wantHeaders)
+			}
+			if body := string(tt.payload.RequestBody()); body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
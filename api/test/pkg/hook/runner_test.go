@@ -0,0 +1,160 @@
+package hook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/pkg/hook"
+)
+
+func TestNewRunner(t *testing.T) {
+	tests := []struct {
+		name    string
+		defs    []entity.HookDefinition
+		wantErr bool
+	}{
+		{
+			name: "exec and http hooks",
+			defs: []entity.HookDefinition{
+				{Name: "reload", Command: "true"},
+				{Name: "notify", URL: "https://example.com/hook"},
+			},
+		},
+		{
+			name:    "missing name",
+			defs:    []entity.HookDefinition{{Command: "true"}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate name",
+			defs: []entity.HookDefinition{
+				{Name: "reload", Command: "true"},
+				{Name: "reload", Command: "false"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "both command and url",
+			defs:    []entity.HookDefinition{{Name: "both", Command: "true", URL: "https://example.com"}},
+			wantErr: true,
+		},
+		{
+			name:    "neither command nor url",
+			defs:    []entity.HookDefinition{{Name: "empty"}},
+			wantErr: true,
+		},
+		{
+			name:    "non http url",
+			defs:    []entity.HookDefinition{{Name: "ftp", URL: "ftp://example.com"}},
+			wantErr: true,
+		},
+		{
+			name:    "negative retries",
+			defs:    []entity.HookDefinition{{Name: "reload", Command: "true", Retries: -1}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, err := hook.NewRunner(tt.defs)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, def := range runner.Hooks() {
+				if def.TimeoutSeconds <= 0 || def.RetryBackoffSeconds <= 0 {
+					t.Errorf("hook %s defaults not applied: %+v", def.Name, def)
+				}
+			}
+		})
+	}
+}
+
+func TestRunnerExec(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out.json")
+	tests := []struct {
+		name    string
+		command string
+		wantErr bool
+	}{
+		{name: "config on stdin", command: "cat > " + out},
+		{name: "version in env", command: `test "$CONFIG_VERSION" = 7`},
+		{name: "non zero exit", command: "exit 3", wantErr: true},
+		{name: "timeout", command: "sleep 5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			def := entity.HookDefinition{Name: "exec", Command: tt.command, TimeoutSeconds: 1}
+			runner, err := hook.NewRunner([]entity.HookDefinition{def})
+			if err != nil {
+				t.Fatalf("new runner: %v", err)
+			}
+
+			err = runner.Run(context.Background(), runner.Hooks()[0], &entity.Config{Version: 7, Data: map[string]string{"k": "v"}})
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	raw, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read hook output: %v", err)
+	}
+	var cfg entity.Config
+	if err := json.Unmarshal(raw, &cfg); err != nil || cfg.Version != 7 || cfg.Data["k"] != "v" {
+		t.Errorf("stdin config = %s (err %v)", raw, err)
+	}
+}
+
+func TestRunnerHTTP(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "2xx succeeds", status: http.StatusNoContent},
+		{name: "5xx fails", status: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got entity.Config
+			var gotVersion, gotToken string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotVersion = r.Header.Get("X-Config-Version")
+				gotToken = r.Header.Get("Authorization")
+				_ = json.NewDecoder(r.Body).Decode(&got)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			def := entity.HookDefinition{Name: "notify", URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer t"}}
+			runner, err := hook.NewRunner([]entity.HookDefinition{def})
+			if err != nil {
+				t.Fatalf("new runner: %v", err)
+			}
+
+			err = runner.Run(context.Background(), runner.Hooks()[0], &entity.Config{Version: 3})
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Version != 3 || gotVersion != "3" || gotToken != "Bearer t" {
+				t.Errorf("request = version %d, header %q, auth %q", got.Version, gotVersion, gotToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package httpclient_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/pkg/compression"
+	"github.com/adityawiryaa/api/pkg/httpclient"
+)
+
+func TestClientCompression(t *testing.T) {
+	large := map[string]string{"data": strings.Repeat("x", 4096)}
+	small := map[string]string{"data": "x"}
+
+	tests := []struct {
+		name             string
+		cfg              compression.Config
+		body             map[string]string
+		serverRejects    bool
+		posts            int
+		wantRequestEnc   []string
+		wantAcceptHeader string
+	}{
+		{
+			name:             "large body compressed with preferred encoding",
+			cfg:              compression.DefaultConfig(),
+			body:             large,
+			posts:            1,
+			wantRequestEnc:   []string{compression.Zstd},
+			wantAcceptHeader: "zstd, gzip",
+		},
+		{
+			name:             "small body sent plain",
+			cfg:              compression.DefaultConfig(),
+			body:             small,
+			posts:            1,
+			wantRequestEnc:   []string{""},
+			wantAcceptHeader: "zstd, gzip",
+		},
+		{
+			name:             "server without compression support",
+			cfg:              compression.Config{Encodings: []string{compression.Gzip}, MinSize: 16},
+			body:             large,
+			serverRejects:    true,
+			posts:            2,
+			wantRequestEnc:   []string{compression.Gzip, "", ""},
+			wantAcceptHeader: "gzip",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var encodings []string
+			var accept string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				encoding := r.Header.Get("Content-Encoding")
+				encodings = append(encodings, encoding)
+				accept = r.Header.Get("Accept-Encoding")
+				if encoding != "" && tt.serverRejects {
+					w.WriteHeader(http.StatusUnsupportedMediaType)
+					return
+				}
+				body := io.Reader(r.Body)
+				if encoding != "" {
+					reader, err := compression.NewReader(encoding, r.Body)
+					if err != nil {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					body = reader
+				}
+				raw, _ := io.ReadAll(body)
+
+				respEncoding := compression.Negotiate(accept, []string{compression.Gzip})
+				if respEncoding == "" {
+					w.Write(raw)
+					return
+				}
+				w.Header().Set("Content-Encoding", respEncoding)
+				writer, _ := compression.NewWriter(respEncoding, w)
+				writer.Write(raw)
+				writer.Close()
+			}))
+			defer srv.Close()
+
+			client := httpclient.New(5 * time.Second)
+			client.SetCompression(tt.cfg)
+
+			for range tt.posts {
+				resp, err := client.Post(context.Background(), srv.URL, tt.body, nil)
+				if err != nil {
+					t.Fatalf("post: %v", err)
+				}
+				var got map[string]string
+				if err := httpclient.DecodeResponse(resp, &got); err != nil {
+					t.Fatalf("decode: %v", err)
+				}
+				if got["data"] != tt.body["data"] {
+					t.Errorf("echoed body length = %d, want %d", len(got["data"]), len(tt.body["data"]))
+				}
+			}
+
+			if strings.Join(encodings, ",") != strings.Join(tt.wantRequestEnc, ",") {
+				t.Errorf("request encodings = %q, want %q", encodings, tt.wantRequestEnc)
+			}
+			if accept != tt.wantAcceptHeader {
+				t.Errorf("Accept-Encoding = %q, want %q", accept, tt.wantAcceptHeader)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/adityawiryaa/api/pkg/jsonpatch"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "add object member",
+			doc:   `{"foo":"bar"}`,
+			patch: `[{"op":"add","path":"/baz","value":"qux"}]`,
+			want:  `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:  "add array element",
+			doc:   `{"foo":["bar","baz"]}`,
+			patch: `[{"op":"add","path":"/foo/1","value":"qux"}]`,
+			want:  `{"foo":["bar","qux","baz"]}`,
+		},
+		{
+			name:  "append to array",
+			doc:   `{"foo":["bar"]}`,
+			patch: `[{"op":"add","path":"/foo/-","value":{"n":1}}]`,
+			want:  `{"foo":["bar",{"n":1}]}`,
+		},
+		{
+			name:  "remove object member",
+			doc:   `{"baz":"qux","foo":"bar"}`,
+			patch: `[{"op":"remove","path":"/baz"}]`,
+			want:  `{"foo":"bar"}`,
+		},
+		{
+			name:  "remove array element",
+			doc:   `{"foo":["bar","qux","baz"]}`,
+			patch: `[{"op":"remove","path":"/foo/1"}]`,
+			want:  `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:  "replace value",
+			doc:   `{"baz":"qux","foo":"bar"}`,
+			patch: `[{"op":"replace","path":"/baz","value":"boo"}]`,
+			want:  `{"baz":"boo","foo":"bar"}`,
+		},
+		{
+			name:  "move value",
+			doc:   `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch: `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			want:  `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:  "copy value",
+			doc:   `{"a":{"b":1}}`,
+			patch: `[{"op":"copy","from":"/a","path":"/c"}]`,
+			want:  `{"a":{"b":1},"c":{"b":1}}`,
+		},
+		{
+			name:  "test then replace",
+			doc:   `{"n":10}`,
+			patch: `[{"op":"test","path":"/n","value":10},{"op":"replace","path":"/n","value":11}]`,
+			want:  `{"n":11}`,
+		},
+		{
+			name:  "escaped keys",
+			doc:   `{"a/b":"1","m~n":"2"}`,
+			patch: `[{"op":"replace","path":"/a~1b","value":"x"},{"op":"remove","path":"/m~0n"}]`,
+			want:  `{"a/b":"x"}`,
+		},
+		{
+			name:  "replace whole document",
+			doc:   `{"a":1}`,
+			patch: `[{"op":"replace","path":"","value":{"b":2}}]`,
+			want:  `{"b":2}`,
+		},
+		{
+			name:    "remove missing key",
+			doc:     `{"foo":"bar"}`,
+			patch:   `[{"op":"remove","path":"/baz"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "replace missing key",
+			doc:     `{"foo":"bar"}`,
+			patch:   `[{"op":"replace","path":"/baz","value":"x"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "add to missing parent",
+			doc:     `{"foo":"bar"}`,
+			patch:   `[{"op":"add","path":"/baz/bat","value":"qux"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "array index out of range",
+			doc:     `{"foo":["bar"]}`,
+			patch:   `[{"op":"add","path":"/foo/2","value":"x"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid pointer",
+			doc:     `{"foo":"bar"}`,
+			patch:   `[{"op":"add","path":"foo","value":"x"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown operation",
+			doc:     `{"foo":"bar"}`,
+			patch:   `[{"op":"merge","path":"/foo","value":"x"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "move into own child",
+			doc:     `{"a":{"b":{}}}`,
+			patch:   `[{"op":"move","from":"/a","path":"/a/b/c"}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch []jsonpatch.Operation
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatalf("decode patch: %v", err)
+			}
+
+			got, err := jsonpatch.Apply([]byte(tt.doc), patch)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("result = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTestFailure(t *testing.T) {
+	patch := []jsonpatch.Operation{{Op: jsonpatch.OpTest, Path: "/foo", Value: json.RawMessage(`"other"`)}}
+	_, err := jsonpatch.Apply([]byte(`{"foo":"bar"}`), patch)
+	if !errors.Is(err, jsonpatch.ErrTestFailed) {
+		t.Errorf("err = %v, want ErrTestFailed", err)
+	}
+}
@@ -0,0 +1,74 @@
+package labels_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/adityawiryaa/api/pkg/labels"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: map[string]string{}},
+		{name: "pairs with spaces", value: "site=north, env = prod", want: map[string]string{"site": "north", "env": "prod"}},
+		{name: "empty value", value: "canary=", want: map[string]string{"canary": ""}},
+		{name: "missing value", value: "site", wantErr: true},
+		{name: "missing key", value: "=north", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := labels.Parse(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !maps.Equal(got, tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelector(t *testing.T) {
+	agentLabels := map[string]string{"site": "north", "env": "prod"}
+
+	tests := []struct {
+		name      string
+		selector  string
+		wantMatch bool
+		wantText  string
+		wantErr   bool
+	}{
+		{name: "single match", selector: "site=north", wantMatch: true, wantText: "site=north"},
+		{name: "all must match", selector: "site=north,env=dev", wantMatch: false, wantText: "site=north,env=dev"},
+		{name: "not equal", selector: "site=north, env!=dev", wantMatch: true, wantText: "site=north,env!=dev"},
+		{name: "not equal on same value", selector: "env!=prod", wantMatch: false, wantText: "env!=prod"},
+		{name: "not equal on missing label", selector: "tier!=edge", wantMatch: true, wantText: "tier!=edge"},
+		{name: "missing label", selector: "tier=edge", wantMatch: false, wantText: "tier=edge"},
+		{name: "empty", selector: " , ", wantErr: true},
+		{name: "no operator", selector: "site", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := labels.ParseSelector(tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := selector.Matches(agentLabels); got != tt.wantMatch {
+				t.Errorf("Matches() = %v, want %v", got, tt.wantMatch)
+			}
+			if selector.String() != tt.wantText {
+				t.Errorf("String() = %q, want %q", selector.String(), tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adityawiryaa/api/internal/repository/file"
+)
+
+func TestBundleDir(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"002-site.json": `{"b":1}`,
+		"001-site.json": `{"a":1}`,
+		".partial.json": `{`,
+		"notes.txt":     "ignore me",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "nested.json"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	bundles := file.NewBundleDir(dir)
+	pending, err := bundles.Pending()
+	if err != nil {
+		t.Fatalf("pending: %v", err)
+	}
+	if len(pending) != 2 || pending[0].Name != "001-site.json" || pending[1].Name != "002-site.json" {
+		t.Fatalf("pending = %+v, want the two json bundles in name order", pending)
+	}
+	if string(pending[0].Data) != `{"a":1}` {
+		t.Errorf("data = %s", pending[0].Data)
+	}
+
+	if err := bundles.Accept("001-site.json"); err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	if err := bundles.Reject("002-site.json"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	for _, path := range []string{"applied/001-site.json", "rejected/002-site.json"} {
+		if _, err := os.Stat(filepath.Join(dir, path)); err != nil {
+			t.Errorf("expected %s: %v", path, err)
+		}
+	}
+
+	pending, err = bundles.Pending()
+	if err != nil || len(pending) != 0 {
+		t.Errorf("pending after processing = %+v, %v", pending, err)
+	}
+
+	if _, err := file.NewBundleDir(filepath.Join(dir, "missing")).Pending(); err == nil {
+		t.Error("expected error for a missing directory")
+	}
+}
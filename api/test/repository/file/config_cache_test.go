@@ -0,0 +1,96 @@
+package file_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/internal/repository/file"
+)
+
+func TestConfigCache(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(t *testing.T, path string)
+		wantNil     bool
+		wantErr     bool
+		wantVersion int64
+	}{
+		{
+			name:    "missing file returns nil",
+			setup:   func(t *testing.T, path string) {},
+			wantNil: true,
+		},
+		{
+			name: "save and load round trip",
+			setup: func(t *testing.T, path string) {
+				cache := file.NewConfigCache(path)
+				if err := cache.Save(&entity.Config{Version: 4, Data: map[string]string{"url": "https://example.com"}}); err != nil {
+					t.Fatalf("save: %v", err)
+				}
+			},
+			wantVersion: 4,
+		},
+		{
+			name: "newer save replaces older",
+			setup: func(t *testing.T, path string) {
+				cache := file.NewConfigCache(path)
+				_ = cache.Save(&entity.Config{Version: 1})
+				_ = cache.Save(&entity.Config{Version: 2})
+			},
+			wantVersion: 2,
+		},
+		{
+			name: "tampered file fails integrity check",
+			setup: func(t *testing.T, path string) {
+				cache := file.NewConfigCache(path)
+				_ = cache.Save(&entity.Config{Version: 1, Data: map[string]string{"url": "https://a"}})
+				raw, _ := os.ReadFile(path)
+				_ = os.WriteFile(path, []byte(strings.Replace(string(raw), "https://a", "https://b", 1)), 0o600)
+			},
+			wantErr: true,
+		},
+		{
+			name: "corrupt file",
+			setup: func(t *testing.T, path string) {
+				_ = os.WriteFile(path, []byte("{not json"), 0o600)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "nested", "cache.json")
+			_ = os.MkdirAll(filepath.Dir(path), 0o755)
+			tt.setup(t, path)
+
+			cfg, err := file.NewConfigCache(path).Load()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantNil {
+				if cfg != nil {
+					t.Errorf("expected nil config, got version %d", cfg.Version)
+				}
+				return
+			}
+			if cfg == nil || cfg.Version != tt.wantVersion {
+				t.Fatalf("config = %+v, want version %d", cfg, tt.wantVersion)
+			}
+
+			entries, _ := os.ReadDir(filepath.Dir(path))
+			if len(entries) != 1 {
+				t.Errorf("expected only the cache file in dir, found %d entries", len(entries))
+			}
+		})
+	}
+}
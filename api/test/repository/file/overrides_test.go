@@ -0,0 +1,73 @@
+package file_test
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adityawiryaa/api/internal/repository/file"
+)
+
+func TestOverrideFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "missing file means no overrides",
+			file: "overrides.yaml",
+		},
+		{
+			name:    "yaml values are stringified",
+			file:    "overrides.yaml",
+			content: "url: https://pinned.example.com\nretries: 3\ndebug: true\n",
+			want:    map[string]string{"url": "https://pinned.example.com", "retries": "3", "debug": "true"},
+		},
+		{
+			name:    "json file",
+			file:    "overrides.json",
+			content: `{"url": "https://pinned.example.com"}`,
+			want:    map[string]string{"url": "https://pinned.example.com"},
+		},
+		{
+			name:    "empty file",
+			file:    "overrides.yaml",
+			content: "# nothing pinned\n",
+		},
+		{
+			name:    "invalid yaml",
+			file:    "overrides.yaml",
+			content: "url: [unclosed\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+					t.Fatalf("write: %v", err)
+				}
+			}
+
+			got, err := file.NewOverrideFile(path).Load()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("overrides = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
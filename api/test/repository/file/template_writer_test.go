@@ -0,0 +1,185 @@
+package file_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/internal/repository/file"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+}
+
+func TestTemplateWriter(t *testing.T) {
+	tests := []struct {
+		name        string
+		template    string
+		mode        string
+		checkCmd    string
+		existing    string
+		data        map[string]string
+		wantErr     bool
+		wantContent string
+		wantMode    os.FileMode
+		wantReload  bool
+	}{
+		{
+			name:        "renders data with helpers",
+			template:    "v{{.Version}} url={{getv \"url\"}} port={{getv \"port\" \"80\"}}{{range keys \"feature.\"}} {{.}}{{end}}\n",
+			mode:        "0640",
+			data:        map[string]string{"url": "https://example.com", "feature.a": "on", "feature.b": "off"},
+			wantContent: "v3 url=https://example.com port=80 feature.a feature.b\n",
+			wantMode:    0o640,
+			wantReload:  true,
+		},
+		{
+			name:        "check command sees the staged file",
+			template:    "ok={{getv \"url\"}}\n",
+			checkCmd:    "grep -q ok= {{.src}}",
+			data:        map[string]string{"url": "x"},
+			wantContent: "ok=x\n",
+			wantMode:    0o644,
+			wantReload:  true,
+		},
+		{
+			name:        "failing check keeps the old file",
+			template:    "new={{getv \"url\"}}\n",
+			checkCmd:    "exit 1",
+			existing:    "old\n",
+			data:        map[string]string{"url": "x"},
+			wantErr:     true,
+			wantContent: "old\n",
+			wantMode:    0o644,
+		},
+		{
+			name:        "missing key fails without writing",
+			template:    "{{getv \"missing\"}}",
+			existing:    "old\n",
+			wantErr:     true,
+			wantContent: "old\n",
+			wantMode:    0o644,
+		},
+		{
+			name:        "unchanged content skips reload",
+			template:    "same\n",
+			existing:    "same\n",
+			wantContent: "same\n",
+			wantMode:    0o644,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "app.conf.tmpl")
+			dest := filepath.Join(dir, "out", "app.conf")
+			marker := filepath.Join(dir, "reloaded")
+			writeFile(t, src, tt.template)
+			if tt.existing != "" {
+				if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				writeFile(t, dest, tt.existing)
+			}
+
+			writer, err := file.NewTemplateWriter([]entity.TemplateResource{{
+				Src:       src,
+				Dest:      dest,
+				Mode:      tt.mode,
+				CheckCmd:  tt.checkCmd,
+				ReloadCmd: "touch " + marker,
+			}}, 5*time.Second)
+			if err != nil {
+				t.Fatalf("new writer: %v", err)
+			}
+
+			err = writer.Render(context.Background(), &entity.Config{Version: 3, Data: tt.data})
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			content, err := os.ReadFile(dest)
+			if err != nil {
+				t.Fatalf("reading dest: %v", err)
+			}
+			if string(content) != tt.wantContent {
+				t.Errorf("content = %q, want %q", content, tt.wantContent)
+			}
+			info, _ := os.Stat(dest)
+			if info.Mode().Perm() != tt.wantMode {
+				t.Errorf("mode = %o, want %o", info.Mode().Perm(), tt.wantMode)
+			}
+			if _, err := os.Stat(marker); (err == nil) != tt.wantReload {
+				t.Errorf("reloaded = %v, want %v", err == nil, tt.wantReload)
+			}
+
+			entries, _ := os.ReadDir(filepath.Dir(dest))
+			for _, entry := range entries {
+				if strings.Contains(entry.Name(), ".tmp-") {
+					t.Errorf("staged file %s left behind", entry.Name())
+				}
+			}
+		})
+	}
+}
+
+func TestNewTemplateWriterValidation(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.tmpl")
+	broken := filepath.Join(dir, "broken.tmpl")
+	writeFile(t, valid, "{{getv \"url\"}}")
+	writeFile(t, broken, "{{getv \"url\"")
+
+	tests := []struct {
+		name     string
+		resource entity.TemplateResource
+	}{
+		{name: "missing dest", resource: entity.TemplateResource{Src: valid}},
+		{name: "invalid mode", resource: entity.TemplateResource{Src: valid, Dest: filepath.Join(dir, "out"), Mode: "0999"}},
+		{name: "template syntax error", resource: entity.TemplateResource{Src: broken, Dest: filepath.Join(dir, "out")}},
+		{name: "missing template", resource: entity.TemplateResource{Src: filepath.Join(dir, "nope.tmpl"), Dest: filepath.Join(dir, "out")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := file.NewTemplateWriter([]entity.TemplateResource{tt.resource}, time.Second); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadTemplateResources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "templates.yaml")
+	writeFile(t, path, `templates:
+  - src: /etc/agent/app.conf.tmpl
+    dest: /etc/app/app.conf
+    mode: "0600"
+    check_cmd: app --check {{.src}}
+    reload_cmd: systemctl reload app
+`)
+
+	resources, err := file.LoadTemplateResources(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := entity.TemplateResource{
+		Src:       "/etc/agent/app.conf.tmpl",
+		Dest:      "/etc/app/app.conf",
+		Mode:      "0600",
+		CheckCmd:  "app --check {{.src}}",
+		ReloadCmd: "systemctl reload app",
+	}
+	if len(resources) != 1 || resources[0] != want {
+		t.Errorf("resources = %+v, want [%+v]", resources, want)
+	}
+}
@@ -0,0 +1,48 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+)
+
+func TestAgentRegistry(t *testing.T) {
+	ctx := context.Background()
+	registry := memory.NewAgentRegistry()
+	base := time.Now()
+
+	for i, id := range []string{"b", "a"} {
+		if err := registry.Save(ctx, &entity.Agent{ID: id, CreatedAt: base.Add(time.Duration(i) * time.Second)}); err != nil {
+			t.Fatalf("save %s: %v", id, err)
+		}
+	}
+
+	reportedAt := base.Add(time.Minute)
+	if err := registry.SaveReport(ctx, "a", &entity.AgentReport{ConfigVersion: 3, ReportedAt: reportedAt}); err != nil {
+		t.Fatalf("save report: %v", err)
+	}
+	if err := registry.SaveReport(ctx, "missing", &entity.AgentReport{}); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("report for unknown agent err = %v, want %v", err, repository.ErrNotFound)
+	}
+
+	agent, err := registry.FindByID(ctx, "a")
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if agent.Report == nil || agent.Report.ConfigVersion != 3 || !agent.UpdatedAt.Equal(reportedAt) {
+		t.Errorf("agent = %+v, want report version 3 updated at %s", agent, reportedAt)
+	}
+	if _, err := registry.FindByID(ctx, "missing"); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("find unknown agent err = %v, want %v", err, repository.ErrNotFound)
+	}
+
+	agents, _ := registry.ListAgents(ctx)
+	if len(agents) != 2 || agents[0].ID != "b" || agents[1].ID != "a" {
+		t.Errorf("agents not ordered by creation: %+v", agents)
+	}
+}
@@ -0,0 +1,94 @@
+package memory_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+)
+
+func TestAgentStateRecordPoll(t *testing.T) {
+	tests := []struct {
+		name         string
+		changed      bool
+		err          error
+		wantResult   string
+		wantFailures int64
+		wantErrors   int
+	}{
+		{
+			name:       "config changed",
+			changed:    true,
+			wantResult: valueobject.PollResultChanged,
+		},
+		{
+			name:       "not modified",
+			wantResult: valueobject.PollResultUnchanged,
+		},
+		{
+			name:         "poll failed",
+			err:          errors.New("connection refused"),
+			wantResult:   valueobject.PollResultError,
+			wantFailures: 1,
+			wantErrors:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := memory.NewAgentState()
+			s.RecordPoll(tt.changed, tt.err)
+
+			poll := s.Poll()
+			if poll.LastResult != tt.wantResult {
+				t.Errorf("result = %q, want %q", poll.LastResult, tt.wantResult)
+			}
+			if poll.Polls != 1 || poll.Failures != tt.wantFailures {
+				t.Errorf("polls = %d, failures = %d, want 1 and %d", poll.Polls, poll.Failures, tt.wantFailures)
+			}
+			if poll.LastChangeAt.IsZero() == tt.changed {
+				t.Errorf("last change at = %v, changed = %v", poll.LastChangeAt, tt.changed)
+			}
+			if got := len(s.RecentErrors()); got != tt.wantErrors {
+				t.Errorf("recent errors = %d, want %d", got, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestAgentStateRecentErrorsBounded(t *testing.T) {
+	s := memory.NewAgentState()
+	for i := range 60 {
+		s.RecordError("worker", fmt.Errorf("failure %d", i))
+	}
+
+	errs := s.RecentErrors()
+	if len(errs) != 50 {
+		t.Fatalf("recent errors = %d, want 50", len(errs))
+	}
+	if errs[0].Message != "failure 59" || errs[len(errs)-1].Message != "failure 10" {
+		t.Errorf("order = %q ... %q, want newest first", errs[0].Message, errs[len(errs)-1].Message)
+	}
+}
+
+func TestAgentStateInvalidate(t *testing.T) {
+	s := memory.NewAgentState()
+	s.SetRegistered("agent-2")
+
+	s.Invalidate("agent-1", "stale agent id")
+	if !s.IsRegistered() {
+		t.Fatal("invalidating a previous agent id must not drop the current registration")
+	}
+
+	s.Invalidate("agent-2", "agent not found")
+	if s.IsRegistered() {
+		t.Fatal("expected registration to be invalidated")
+	}
+	select {
+	case <-s.Invalidated():
+	default:
+		t.Fatal("expected invalidation signal")
+	}
+}
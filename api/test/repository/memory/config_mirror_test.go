@@ -0,0 +1,98 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+)
+
+func TestConfigMirror(t *testing.T) {
+	tests := []struct {
+		name         string
+		versions     []int64
+		depth        int
+		wantLatest   int64
+		wantErr      error
+		wantVersions []int64
+	}{
+		{
+			name:    "empty store",
+			depth:   4,
+			wantErr: repository.ErrNotFound,
+		},
+		{
+			name:         "records every observed version",
+			versions:     []int64{1, 2, 3},
+			depth:        4,
+			wantLatest:   3,
+			wantVersions: []int64{1, 2, 3},
+		},
+		{
+			name:         "history is bounded",
+			versions:     []int64{1, 2, 3, 4},
+			depth:        2,
+			wantLatest:   4,
+			wantVersions: []int64{3, 4},
+		},
+		{
+			name:         "rollback drops newer versions",
+			versions:     []int64{1, 2, 3, 2},
+			depth:        4,
+			wantLatest:   2,
+			wantVersions: []int64{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := memory.NewConfigStore()
+			mirror := memory.NewConfigMirror(store, tt.depth)
+
+			for _, version := range tt.versions {
+				store.Set(&entity.Config{Version: version})
+				if _, err := mirror.GetLatestConfig(ctx); err != nil {
+					t.Fatalf("latest after version %d: %v", version, err)
+				}
+			}
+
+			latest, err := mirror.GetLatestConfig(ctx)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if latest.Version != tt.wantLatest {
+				t.Errorf("latest = %d, want %d", latest.Version, tt.wantLatest)
+			}
+
+			configs, _ := mirror.ListConfigs(ctx)
+			if len(configs) != len(tt.wantVersions) {
+				t.Fatalf("history = %d configs, want %v", len(configs), tt.wantVersions)
+			}
+			for i, cfg := range configs {
+				if cfg.Version != tt.wantVersions[i] {
+					t.Errorf("history[%d] = %d, want %d", i, cfg.Version, tt.wantVersions[i])
+				}
+				if _, err := mirror.GetConfigByVersion(ctx, cfg.Version); err != nil {
+					t.Errorf("version %d: %v", cfg.Version, err)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigMirrorIsReadOnly(t *testing.T) {
+	mirror := memory.NewConfigMirror(memory.NewConfigStore(), 1)
+	if err := mirror.SaveConfig(context.Background(), &entity.Config{Version: 1}); !errors.Is(err, repository.ErrReadOnly) {
+		t.Errorf("save err = %v, want %v", err, repository.ErrReadOnly)
+	}
+	if _, err := mirror.GetConfigByVersion(context.Background(), 1); !errors.Is(err, repository.ErrNotFound) {
+		t.Errorf("version err = %v, want %v", err, repository.ErrNotFound)
+	}
+}
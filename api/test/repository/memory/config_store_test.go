@@ -1,6 +1,7 @@
 package memory_test
 
 import (
+	"maps"
 	"sync"
 	"testing"
 
@@ -77,3 +78,56 @@ func TestConfigStoreConcurrency(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestConfigStoreOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *entity.Config
+		overrides map[string]string
+		wantNil   bool
+		wantData  map[string]string
+	}{
+		{
+			name:    "no config",
+			wantNil: true,
+		},
+		{
+			name:     "no overrides returns config as is",
+			config:   &entity.Config{Version: 1, Data: map[string]string{"url": "https://a"}},
+			wantData: map[string]string{"url": "https://a"},
+		},
+		{
+			name:      "overrides replace and add keys",
+			config:    &entity.Config{Version: 1, Data: map[string]string{"url": "https://a", "timeout": "5"}},
+			overrides: map[string]string{"url": "https://pinned", "debug": "true"},
+			wantData:  map[string]string{"url": "https://pinned", "timeout": "5", "debug": "true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			if tt.config != nil {
+				store.Set(tt.config)
+			}
+			store.SetOverrides(tt.overrides)
+
+			got := store.Effective()
+			if tt.wantNil {
+				if got != nil {
+					t.Errorf("expected nil config, got %+v", got)
+				}
+				return
+			}
+			if !maps.Equal(got.Data, tt.wantData) {
+				t.Errorf("effective data = %v, want %v", got.Data, tt.wantData)
+			}
+			if got.Version != tt.config.Version {
+				t.Errorf("effective version = %d, want %d", got.Version, tt.config.Version)
+			}
+			if tt.config.Data["url"] != "https://a" {
+				t.Errorf("base config was modified: %v", tt.config.Data)
+			}
+		})
+	}
+}
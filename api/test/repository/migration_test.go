@@ -0,0 +1,122 @@
+package repository_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/adityawiryaa/api/internal/config"
+	"github.com/adityawiryaa/api/internal/repository"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := config.NewDB(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("opening db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, name).Scan(&count)
+	if err != nil {
+		t.Fatalf("checking table %s: %v", name, err)
+	}
+	return count > 0
+}
+
+func TestMigrate(t *testing.T) {
+	migrations, err := repository.LoadMigrations()
+	if err != nil {
+		t.Fatalf("loading migrations: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		run         func(db *sql.DB) error
+		wantApplied int
+		wantTables  map[string]bool
+	}{
+		{
+			name:        "up applies all migrations",
+			run:         repository.MigrateUp,
+			wantApplied: len(migrations),
+			wantTables:  map[string]bool{"agents": true, "configs": true, "agent_commands": true},
+		},
+		{
+			name: "up is idempotent",
+			run: func(db *sql.DB) error {
+				if err := repository.MigrateUp(db); err != nil {
+					return err
+				}
+				return repository.MigrateUp(db)
+			},
+			wantApplied: len(migrations),
+			wantTables:  map[string]bool{"agents": true, "configs": true, "agent_commands": true},
+		},
+		{
+			name: "down reverts everything",
+			run: func(db *sql.DB) error {
+				if err := repository.MigrateUp(db); err != nil {
+					return err
+				}
+				return repository.MigrateDown(db, len(migrations))
+			},
+			wantApplied: 0,
+			wantTables:  map[string]bool{"agents": false, "configs": false, "agent_commands": false},
+		},
+		{
+			name: "up on legacy schema",
+			run: func(db *sql.DB) error {
+				if _, err := db.Exec(`CREATE TABLE agents (
+					id TEXT PRIMARY KEY, hostname TEXT NOT NULL, ip_address TEXT NOT NULL,
+					port INTEGER NOT NULL, status TEXT NOT NULL DEFAULT 'active',
+					created_at DATETIME NOT NULL, updated_at DATETIME NOT NULL)`); err != nil {
+					return err
+				}
+				return repository.MigrateUp(db)
+			},
+			wantApplied: len(migrations),
+			wantTables:  map[string]bool{"agents": true, "configs": true, "agent_commands": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openTestDB(t)
+			if err := tt.run(db); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			states, err := repository.MigrationStatus(db)
+			if err != nil {
+				t.Fatalf("status: %v", err)
+			}
+			applied := 0
+			for _, s := range states {
+				if s.Applied {
+					applied++
+				}
+			}
+			if applied != tt.wantApplied {
+				t.Errorf("applied = %d, want %d", applied, tt.wantApplied)
+			}
+			for table, want := range tt.wantTables {
+				if got := tableExists(t, db, table); got != want {
+					t.Errorf("table %s exists = %v, want %v", table, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateDownInvalidSteps(t *testing.T) {
+	db := openTestDB(t)
+	if err := repository.MigrateDown(db, 0); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
@@ -0,0 +1,172 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+type mockTemplateRenderer struct {
+	renderFunc func(ctx context.Context, cfg *entity.Config) error
+}
+
+func (m *mockTemplateRenderer) Render(ctx context.Context, cfg *entity.Config) error {
+	return m.renderFunc(ctx, cfg)
+}
+
+func TestApplyConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		renderErr    error
+		pushErr      error
+		noWorkers    bool
+		wantErr      bool
+		wantRendered bool
+		wantErrors   int
+	}{
+		{
+			name:         "forwards and renders",
+			wantRendered: true,
+		},
+		{
+			name:         "templates without workers",
+			noWorkers:    true,
+			wantRendered: true,
+		},
+		{
+			name:         "template failure is recorded",
+			renderErr:    errors.New("check failed"),
+			wantErr:      true,
+			wantRendered: true,
+			wantErrors:   1,
+		},
+		{
+			name:         "worker failure still renders templates",
+			pushErr:      errors.New("connection refused"),
+			wantErr:      true,
+			wantRendered: true,
+			wantErrors:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			store.Set(&entity.Config{Version: 2, Data: map[string]string{"k": "v"}})
+			state := memory.NewAgentState()
+
+			var rendered *entity.Config
+			templates := &mockTemplateRenderer{
+				renderFunc: func(_ context.Context, cfg *entity.Config) error {
+					rendered = cfg
+					return tt.renderErr
+				},
+			}
+			worker := &mockWorkerClient{
+				pushFunc: func(_ context.Context, _ *entity.Config) error { return tt.pushErr },
+			}
+
+			pool := newWorkerPool(worker)
+			if tt.noWorkers {
+				pool = newWorkerPool()
+			}
+
+			uc := agent.NewCommandUsecase(nil, pool, store, state, memory.NewWorkerStatusStore(), nil, templates, nil, nil, nil, nil, nil, backoff.DefaultConfig())
+			err := uc.ApplyConfig(context.Background())
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (rendered != nil) != tt.wantRendered {
+				t.Errorf("rendered = %v, want %v", rendered != nil, tt.wantRendered)
+			}
+			if rendered != nil && rendered.Version != 2 {
+				t.Errorf("rendered version = %d, want 2", rendered.Version)
+			}
+			if got := len(state.RecentErrors()); got != tt.wantErrors {
+				t.Errorf("recent errors = %d, want %d", got, tt.wantErrors)
+			}
+		})
+	}
+}
+
+type mockConfigCache struct {
+	saved   []int64
+	saveErr error
+}
+
+func (m *mockConfigCache) Load() (*entity.Config, error) {
+	return nil, nil
+}
+
+func (m *mockConfigCache) Save(cfg *entity.Config) error {
+	m.saved = append(m.saved, cfg.Version)
+	return m.saveErr
+}
+
+func TestApplyConfigPersistsConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		pushErr    error
+		noWorkers  bool
+		saveErr    error
+		wantErr    bool
+		wantErrors int
+	}{
+		{
+			name: "every push succeeds",
+		},
+		{
+			name:       "every push fails",
+			pushErr:    errors.New("connection refused"),
+			wantErr:    true,
+			wantErrors: 2,
+		},
+		{
+			name:      "no workers",
+			noWorkers: true,
+			wantErr:   true,
+		},
+		{
+			name:       "cache failure is recorded",
+			saveErr:    errors.New("disk full"),
+			wantErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			store.Set(&entity.Config{Version: 5, Data: map[string]string{"k": "v"}})
+			state := memory.NewAgentState()
+			cache := &mockConfigCache{saveErr: tt.saveErr}
+
+			pool := newWorkerPool()
+			if !tt.noWorkers {
+				push := func(_ context.Context, _ *entity.Config) error { return tt.pushErr }
+				pool = newWorkerPool(
+					&mockWorkerClient{url: "http://worker-a.test", pushFunc: push},
+					&mockWorkerClient{url: "http://worker-b.test", pushFunc: push},
+				)
+			}
+
+			uc := agent.NewCommandUsecase(nil, pool, store, state, memory.NewWorkerStatusStore(), cache, nil, nil, nil, nil, nil, nil, backoff.DefaultConfig())
+			err := uc.ApplyConfig(context.Background())
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(cache.saved) != 1 || cache.saved[0] != 5 {
+				t.Errorf("saved versions = %v, want [5]", cache.saved)
+			}
+			if got := len(state.RecentErrors()); got != tt.wantErrors {
+				t.Errorf("recent errors = %d, want %d", got, tt.wantErrors)
+			}
+		})
+	}
+}
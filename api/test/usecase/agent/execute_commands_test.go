@@ -0,0 +1,169 @@
+package agent_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+func TestExecuteCommands(t *testing.T) {
+	const workerURL = "http://worker-a.test"
+
+	tests := []struct {
+		name           string
+		cmd            entity.AgentCommand
+		pushErr        error
+		wantStatus     string
+		wantResultKey  string
+		wantPushes     int
+		wantDrained    bool
+		wantRegistered bool
+	}{
+		{
+			name:           "reload re-pushes the current config",
+			cmd:            entity.AgentCommand{ID: "c1", Type: valueobject.CommandReload},
+			wantStatus:     valueobject.CommandStatusSucceeded,
+			wantResultKey:  "version",
+			wantPushes:     1,
+			wantRegistered: true,
+		},
+		{
+			name:           "reload reports push failures",
+			cmd:            entity.AgentCommand{ID: "c2", Type: valueobject.CommandReload},
+			pushErr:        errors.New("connection refused"),
+			wantStatus:     valueobject.CommandStatusFailed,
+			wantPushes:     1,
+			wantRegistered: true,
+		},
+		{
+			name:           "drain excludes the worker from pushes",
+			cmd:            entity.AgentCommand{ID: "c3", Type: valueobject.CommandDrain, Args: map[string]string{"url": workerURL + "/"}},
+			wantStatus:     valueobject.CommandStatusSucceeded,
+			wantResultKey:  "drained",
+			wantDrained:    true,
+			wantRegistered: true,
+		},
+		{
+			name:           "drain of an unknown worker fails",
+			cmd:            entity.AgentCommand{ID: "c4", Type: valueobject.CommandDrain, Args: map[string]string{"url": "http://unknown.test"}},
+			wantStatus:     valueobject.CommandStatusFailed,
+			wantRegistered: true,
+		},
+		{
+			name:           "reregister invalidates the registration after acknowledging",
+			cmd:            entity.AgentCommand{ID: "c5", Type: valueobject.CommandReregister},
+			wantStatus:     valueobject.CommandStatusSucceeded,
+			wantResultKey:  "previous_agent_id",
+			wantRegistered: false,
+		},
+		{
+			name:           "diagnostics returns the agent status",
+			cmd:            entity.AgentCommand{ID: "c6", Type: valueobject.CommandDiagnostics},
+			wantStatus:     valueobject.CommandStatusSucceeded,
+			wantResultKey:  "registration",
+			wantRegistered: true,
+		},
+		{
+			name:           "unknown command fails",
+			cmd:            entity.AgentCommand{ID: "c7", Type: "explode"},
+			wantStatus:     valueobject.CommandStatusFailed,
+			wantRegistered: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			store.Set(&entity.Config{Version: 7})
+			state := memory.NewAgentState()
+			state.SetRegistered("agent-1")
+
+			pushes := 0
+			worker := &mockWorkerClient{
+				url: workerURL,
+				pushFunc: func(_ context.Context, _ *entity.Config) error {
+					pushes++
+					return tt.pushErr
+				},
+			}
+			pool := newWorkerPool(worker)
+
+			var acked *request.CommandResultRequest
+			var registeredAtAck bool
+			client := &mockControllerClient{
+				claimFunc: func(_ context.Context, agentID string) ([]entity.AgentCommand, error) {
+					if agentID != "agent-1" {
+						t.Errorf("claim agent id = %q, want %q", agentID, "agent-1")
+					}
+					return []entity.AgentCommand{tt.cmd}, nil
+				},
+				completeFunc: func(_ context.Context, _ string, commandID string, req *request.CommandResultRequest) error {
+					if commandID != tt.cmd.ID {
+						t.Errorf("acked command = %q, want %q", commandID, tt.cmd.ID)
+					}
+					acked = req
+					registeredAtAck = state.IsRegistered()
+					return nil
+				},
+			}
+
+			uc := agent.NewCommandUsecase(client, pool, store, state, memory.NewWorkerStatusStore(), nil, nil, nil, nil, nil, nil, nil, backoff.DefaultConfig())
+			if err := uc.ExecuteCommands(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if acked == nil {
+				t.Fatal("expected command to be acknowledged")
+			}
+			if !registeredAtAck {
+				t.Error("expected acknowledgement to be sent before re-registering")
+			}
+			if acked.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q (error: %s)", acked.Status, tt.wantStatus, acked.Error)
+			}
+			if tt.wantStatus == valueobject.CommandStatusFailed && acked.Error == "" {
+				t.Error("expected failed command to carry an error message")
+			}
+			if tt.wantResultKey != "" {
+				var result map[string]any
+				if err := json.Unmarshal(acked.Result, &result); err != nil {
+					t.Fatalf("decoding result: %v", err)
+				}
+				if _, ok := result[tt.wantResultKey]; !ok {
+					t.Errorf("result %s missing key %q", acked.Result, tt.wantResultKey)
+				}
+			}
+			if pushes != tt.wantPushes {
+				t.Errorf("pushes = %d, want %d", pushes, tt.wantPushes)
+			}
+			if drained := len(pool.Clients()) == 0; drained != tt.wantDrained {
+				t.Errorf("drained = %v, want %v", drained, tt.wantDrained)
+			}
+			if state.IsRegistered() != tt.wantRegistered {
+				t.Errorf("registered = %v, want %v", state.IsRegistered(), tt.wantRegistered)
+			}
+		})
+	}
+}
+
+func TestExecuteCommandsSkipsWhenUnregistered(t *testing.T) {
+	client := &mockControllerClient{
+		claimFunc: func(_ context.Context, _ string) ([]entity.AgentCommand, error) {
+			t.Error("unexpected claim while unregistered")
+			return nil, nil
+		},
+	}
+
+	uc := agent.NewCommandUsecase(client, newWorkerPool(), memory.NewConfigStore(), memory.NewAgentState(), memory.NewWorkerStatusStore(), nil, nil, nil, nil, nil, nil, nil, backoff.DefaultConfig())
+	if err := uc.ExecuteCommands(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
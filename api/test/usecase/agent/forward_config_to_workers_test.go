@@ -0,0 +1,152 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+type mockWorkerClient struct {
+	url         string
+	pushFunc    func(ctx context.Context, cfg *entity.Config) error
+	versionFunc func(ctx context.Context) (int64, error)
+}
+
+func (m *mockWorkerClient) BaseURL() string {
+	if m.url == "" {
+		return "http://worker.test"
+	}
+	return m.url
+}
+
+func (m *mockWorkerClient) PushConfig(ctx context.Context, cfg *entity.Config) error {
+	return m.pushFunc(ctx, cfg)
+}
+
+func (m *mockWorkerClient) ConfigVersion(ctx context.Context) (int64, error) {
+	if m.versionFunc == nil {
+		return 0, nil
+	}
+	return m.versionFunc(ctx)
+}
+
+func newWorkerPool(clients ...*mockWorkerClient) *memory.WorkerPool {
+	byURL := make(map[string]*mockWorkerClient, len(clients))
+	for _, client := range clients {
+		byURL[client.BaseURL()] = client
+	}
+	pool := memory.NewWorkerPool(func(url string) usecases.WorkerClient {
+		return byURL[url]
+	}, time.Minute)
+	for _, client := range clients {
+		pool.Add(client.BaseURL(), valueobject.WorkerSourceStatic)
+	}
+	return pool
+}
+
+func TestForwardConfigToWorkers(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *entity.Config
+		pushErr error
+		wantErr bool
+	}{
+		{
+			name:    "successful forward",
+			config:  &entity.Config{Version: 3, Data: map[string]string{"k": "v"}},
+			wantErr: false,
+		},
+		{
+			name:    "no config to forward",
+			config:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "push fails",
+			config:  &entity.Config{Version: 1},
+			pushErr: errors.New("worker unreachable"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			if tt.config != nil {
+				store.Set(tt.config)
+			}
+
+			workerClient := &mockWorkerClient{
+				pushFunc: func(_ context.Context, _ *entity.Config) error {
+					return tt.pushErr
+				},
+			}
+
+			controllerClient := &mockControllerClient{
+				registerFunc: func(_ context.Context, _ *entity.RegistrationRequest) (*entity.RegistrationResponse, error) {
+					return nil, nil
+				},
+				fetchFunc: func(_ context.Context, _ string, _ int64) (*entity.ConfigFetch, error) {
+					return &entity.ConfigFetch{}, nil
+				},
+			}
+
+			cfg := backoff.Config{
+				InitialInterval: 0,
+				MaxInterval:     0,
+				Multiplier:      1,
+				MaxRetries:      1,
+			}
+
+			uc := agent.NewCommandUsecase(controllerClient, newWorkerPool(workerClient), store, memory.NewAgentState(), memory.NewWorkerStatusStore(), nil, nil, nil, nil, nil, nil, nil, cfg)
+			err := uc.ForwardConfigToWorkers(context.Background())
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestForwardConfigToWorkersPartialFailure(t *testing.T) {
+	store := memory.NewConfigStore()
+	store.Set(&entity.Config{Version: 4})
+
+	healthy := &mockWorkerClient{
+		url:      "http://worker-a.test",
+		pushFunc: func(_ context.Context, _ *entity.Config) error { return nil },
+	}
+	broken := &mockWorkerClient{
+		url:      "http://worker-b.test",
+		pushFunc: func(_ context.Context, _ *entity.Config) error { return errors.New("connection refused") },
+	}
+
+	workers := memory.NewWorkerStatusStore()
+	uc := agent.NewCommandUsecase(nil, newWorkerPool(healthy, broken), store, memory.NewAgentState(), workers, nil, nil, nil, nil, nil, nil, nil, backoff.DefaultConfig())
+
+	if err := uc.ForwardConfigToWorkers(context.Background()); err == nil {
+		t.Fatal("expected error for the failing worker, got nil")
+	}
+
+	if status := workers.Get(healthy.url); !status.Healthy || status.AppliedVersion != 4 {
+		t.Errorf("healthy worker status = %+v, want healthy at version 4", status)
+	}
+	if status := workers.Get(broken.url); status.Healthy || status.ConsecutiveFailures != 1 {
+		t.Errorf("broken worker status = %+v, want unhealthy with 1 failure", status)
+	}
+}
@@ -0,0 +1,200 @@
+package agent_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+	"github.com/adityawiryaa/api/pkg/backoff"
+	"github.com/adityawiryaa/api/pkg/bundlesign"
+)
+
+type mockBundleInbox struct {
+	files    []entity.BundleFile
+	accepted []string
+	rejected []string
+}
+
+func (m *mockBundleInbox) Pending() ([]entity.BundleFile, error) {
+	return m.files, nil
+}
+
+func (m *mockBundleInbox) Accept(name string) error {
+	m.accepted = append(m.accepted, name)
+	return nil
+}
+
+func (m *mockBundleInbox) Reject(name string) error {
+	m.rejected = append(m.rejected, name)
+	return nil
+}
+
+func newBundleKeys(t *testing.T) (*bundlesign.Signer, *bundlesign.Verifier) {
+	t.Helper()
+	privatePEM, publicPEM, err := bundlesign.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	keyPath, pubPath := filepath.Join(dir, "signing.key"), filepath.Join(dir, "signing.pub")
+	if err := os.WriteFile(keyPath, privatePEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pubPath, publicPEM, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	signer, err := bundlesign.LoadSigner(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := bundlesign.LoadVerifier(pubPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, verifier
+}
+
+func signBundle(t *testing.T, signer *bundlesign.Signer, name string, target entity.BundleTarget, version int64) entity.BundleFile {
+	t.Helper()
+	payload, err := json.Marshal(entity.AgentBundle{
+		FormatVersion: entity.AgentBundleFormatVersion,
+		IssuedAt:      time.Now(),
+		Target:        target,
+		Config:        &entity.Config{Version: version, Data: map[string]string{"url": "https://bundle"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(entity.SignedBundle{KeyID: signer.KeyID(), Payload: payload, Signature: signer.Sign(payload)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entity.BundleFile{Name: name, Data: data}
+}
+
+func TestImportBundles(t *testing.T) {
+	signer, verifier := newBundleKeys(t)
+	otherSigner, _ := newBundleKeys(t)
+	site := entity.BundleTarget{Selector: "site=north"}
+
+	tampered := signBundle(t, signer, "tampered.json", site, 5)
+	var signed entity.SignedBundle
+	_ = json.Unmarshal(tampered.Data, &signed)
+	signed.Payload = []byte(string(signed.Payload[:len(signed.Payload)-1]) + ` `)
+	tampered.Data, _ = json.Marshal(signed)
+
+	tests := []struct {
+		name         string
+		files        []entity.BundleFile
+		wantVersion  int64
+		wantAccepted []string
+		wantRejected []string
+		wantPushes   int
+	}{
+		{
+			name:         "selector match is applied and forwarded",
+			files:        []entity.BundleFile{signBundle(t, signer, "a.json", site, 5)},
+			wantVersion:  5,
+			wantAccepted: []string{"a.json"},
+			wantPushes:   1,
+		},
+		{
+			name:         "hostname target is applied",
+			files:        []entity.BundleFile{signBundle(t, signer, "a.json", entity.BundleTarget{AgentID: "old-id", Hostname: "edge-1"}, 5)},
+			wantVersion:  5,
+			wantAccepted: []string{"a.json"},
+			wantPushes:   1,
+		},
+		{
+			name: "newer bundles applied in order and stale ones rejected",
+			files: []entity.BundleFile{
+				signBundle(t, signer, "a.json", site, 4),
+				signBundle(t, signer, "b.json", site, 6),
+				signBundle(t, signer, "c.json", site, 6),
+			},
+			wantVersion:  6,
+			wantAccepted: []string{"a.json", "b.json"},
+			wantRejected: []string{"c.json"},
+			wantPushes:   2,
+		},
+		{
+			name:         "older than loaded config",
+			files:        []entity.BundleFile{signBundle(t, signer, "a.json", site, 2)},
+			wantVersion:  3,
+			wantRejected: []string{"a.json"},
+		},
+		{
+			name:         "selector mismatch",
+			files:        []entity.BundleFile{signBundle(t, signer, "a.json", entity.BundleTarget{Selector: "site=south"}, 5)},
+			wantVersion:  3,
+			wantRejected: []string{"a.json"},
+		},
+		{
+			name:         "other agent",
+			files:        []entity.BundleFile{signBundle(t, signer, "a.json", entity.BundleTarget{AgentID: "agent-2", Hostname: "edge-2"}, 5)},
+			wantVersion:  3,
+			wantRejected: []string{"a.json"},
+		},
+		{
+			name:         "signed with another key",
+			files:        []entity.BundleFile{signBundle(t, otherSigner, "a.json", site, 5)},
+			wantVersion:  3,
+			wantRejected: []string{"a.json"},
+		},
+		{
+			name:         "tampered payload",
+			files:        []entity.BundleFile{tampered},
+			wantVersion:  3,
+			wantRejected: []string{"tampered.json"},
+		},
+		{
+			name:         "not a bundle",
+			files:        []entity.BundleFile{{Name: "junk.json", Data: []byte("{")}},
+			wantVersion:  3,
+			wantRejected: []string{"junk.json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			store.Set(&entity.Config{Version: 3})
+			state := memory.NewAgentState()
+			state.SetRegistered("agent-1")
+			state.SetIdentity(entity.AgentIdentity{Hostname: "edge-1", Labels: map[string]string{"site": "north"}})
+
+			pushes := 0
+			worker := &mockWorkerClient{pushFunc: func(_ context.Context, _ *entity.Config) error {
+				pushes++
+				return nil
+			}}
+			inbox := &mockBundleInbox{files: tt.files}
+
+			uc := agent.NewCommandUsecase(nil, newWorkerPool(worker), store, state, memory.NewWorkerStatusStore(), nil, nil, nil, nil, inbox, verifier, nil, backoff.DefaultConfig())
+			err := uc.ImportBundles(context.Background())
+			if (err != nil) != (len(tt.wantRejected) > 0) {
+				t.Errorf("err = %v, want error only when a bundle is rejected", err)
+			}
+
+			if store.Version() != tt.wantVersion {
+				t.Errorf("version = %d, want %d", store.Version(), tt.wantVersion)
+			}
+			if !slices.Equal(inbox.accepted, tt.wantAccepted) || !slices.Equal(inbox.rejected, tt.wantRejected) {
+				t.Errorf("accepted = %v, rejected = %v, want %v, %v", inbox.accepted, inbox.rejected, tt.wantAccepted, tt.wantRejected)
+			}
+			if pushes != tt.wantPushes {
+				t.Errorf("pushes = %d, want %d", pushes, tt.wantPushes)
+			}
+			if len(state.RecentErrors()) != len(tt.wantRejected) {
+				t.Errorf("recent errors = %v, want %d", state.RecentErrors(), len(tt.wantRejected))
+			}
+		})
+	}
+}
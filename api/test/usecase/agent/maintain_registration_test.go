@@ -0,0 +1,96 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+func TestMaintainRegistration(t *testing.T) {
+	tests := []struct {
+		name        string
+		failures    int32
+		invalidate  bool
+		wantCalls   int32
+		wantAgentID string
+	}{
+		{
+			name:        "keeps retrying until the controller is reachable",
+			failures:    3,
+			wantCalls:   4,
+			wantAgentID: "agent-4",
+		},
+		{
+			name:        "re-registers when the agent is rejected",
+			invalidate:  true,
+			wantCalls:   2,
+			wantAgentID: "agent-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			client := &mockControllerClient{
+				registerFunc: func(_ context.Context, _ *entity.RegistrationRequest) (*entity.RegistrationResponse, error) {
+					n := calls.Add(1)
+					if n <= tt.failures {
+						return nil, errors.New("connection refused")
+					}
+					return &entity.RegistrationResponse{AgentID: fmt.Sprintf("agent-%d", n), Status: "active"}, nil
+				},
+			}
+
+			state := memory.NewAgentState()
+			cfg := backoff.Config{
+				InitialInterval: time.Millisecond,
+				MaxInterval:     2 * time.Millisecond,
+				Multiplier:      2.0,
+				MaxRetries:      1,
+			}
+			uc := agent.NewCommandUsecase(client, newWorkerPool(), memory.NewConfigStore(), state, memory.NewWorkerStatusStore(), nil, nil, nil, nil, nil, nil, nil, cfg)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				uc.MaintainRegistration(ctx, &entity.RegistrationRequest{Hostname: "test"})
+				close(done)
+			}()
+
+			waitFor(t, func() bool { return state.IsRegistered() })
+			if tt.invalidate {
+				state.Invalidate(state.AgentID(), "agent not found")
+				waitFor(t, func() bool { return calls.Load() == tt.wantCalls && state.IsRegistered() })
+			}
+
+			cancel()
+			<-done
+
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("register calls = %d, want %d", got, tt.wantCalls)
+			}
+			if got := state.AgentID(); got != tt.wantAgentID {
+				t.Errorf("agent id = %q, want %q", got, tt.wantAgentID)
+			}
+		})
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -2,37 +2,55 @@ package agent_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/usecases"
 	"github.com/adityawiryaa/api/internal/repository/memory"
 	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+	"github.com/adityawiryaa/api/pkg/configdiff"
 )
 
 func TestPollConfig(t *testing.T) {
 	tests := []struct {
 		name         string
 		storeVersion int64
-		fetchCfg     *entity.Config
-		fetchChanged bool
+		fetchResult  *entity.ConfigFetch
 		fetchErr     error
 		wantErr      bool
+		wantChanged  bool
+		wantVersion  int64
 		wantInterval int
 	}{
 		{
 			name:         "no change",
 			storeVersion: 5,
-			fetchChanged: false,
+			fetchResult:  &entity.ConfigFetch{},
 			wantErr:      false,
+			wantVersion:  5,
 			wantInterval: 0,
 		},
+		{
+			name:         "no change with poll interval hint",
+			storeVersion: 5,
+			fetchResult:  &entity.ConfigFetch{PollIntervalSeconds: 60},
+			wantVersion:  5,
+			wantInterval: 60,
+		},
 		{
 			name:         "config updated",
 			storeVersion: 1,
-			fetchCfg:     &entity.Config{Version: 2, PollIntervalSeconds: 15},
-			fetchChanged: true,
+			fetchResult: &entity.ConfigFetch{
+				Config:              &entity.Config{Version: 2, PollIntervalSeconds: 15},
+				Changed:             true,
+				PollIntervalSeconds: 15,
+			},
 			wantErr:      false,
+			wantChanged:  true,
+			wantVersion:  2,
 			wantInterval: 15,
 		},
 		{
@@ -53,13 +71,13 @@ func TestPollConfig(t *testing.T) {
 				registerFunc: func(_ context.Context, _ *entity.RegistrationRequest) (*entity.RegistrationResponse, error) {
 					return nil, nil
 				},
-				fetchFunc: func(_ context.Context, _ int64) (*entity.Config, bool, error) {
-					return tt.fetchCfg, tt.fetchChanged, tt.fetchErr
+				fetchFunc: func(_ context.Context, _ string, _ int64) (*entity.ConfigFetch, error) {
+					return tt.fetchResult, tt.fetchErr
 				},
 			}
 
-			uc := agent.NewQueryUsecase(client, store)
-			interval, err := uc.PollConfig(context.Background())
+			uc := agent.NewQueryUsecase(client, store, memory.NewAgentState(), newWorkerPool(), memory.NewWorkerStatusStore())
+			result, err := uc.PollConfig(context.Background())
 
 			if tt.wantErr {
 				if err == nil {
@@ -71,8 +89,131 @@ func TestPollConfig(t *testing.T) {
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
-			if interval != tt.wantInterval {
-				t.Errorf("interval = %d, want %d", interval, tt.wantInterval)
+			if result.Changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", result.Changed, tt.wantChanged)
+			}
+			if result.PollIntervalSeconds != tt.wantInterval {
+				t.Errorf("interval = %d, want %d", result.PollIntervalSeconds, tt.wantInterval)
+			}
+			if store.Version() != tt.wantVersion {
+				t.Errorf("store version = %d, want %d", store.Version(), tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestPollConfigAgentRejected(t *testing.T) {
+	tests := []struct {
+		name           string
+		fetchErr       error
+		wantRegistered bool
+	}{
+		{
+			name:           "unknown agent invalidates registration",
+			fetchErr:       fmt.Errorf("%w: agent not found", usecases.ErrAgentNotFound),
+			wantRegistered: false,
+		},
+		{
+			name:           "network error keeps registration",
+			fetchErr:       errors.New("connection refused"),
+			wantRegistered: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := memory.NewAgentState()
+			state.SetRegistered("agent-123")
+
+			var gotAgentID string
+			client := &mockControllerClient{
+				fetchFunc: func(_ context.Context, agentID string, _ int64) (*entity.ConfigFetch, error) {
+					gotAgentID = agentID
+					return nil, tt.fetchErr
+				},
+			}
+
+			uc := agent.NewQueryUsecase(client, memory.NewConfigStore(), state, newWorkerPool(), memory.NewWorkerStatusStore())
+			if _, err := uc.PollConfig(context.Background()); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			if gotAgentID != "agent-123" {
+				t.Errorf("agent id = %q, want %q", gotAgentID, "agent-123")
+			}
+			if state.IsRegistered() != tt.wantRegistered {
+				t.Errorf("registered = %v, want %v", state.IsRegistered(), tt.wantRegistered)
+			}
+		})
+	}
+}
+
+func TestPollConfigPatch(t *testing.T) {
+	base := map[string]string{"url": "https://a", "timeout": "5"}
+	next := map[string]string{"url": "https://b", "retries": "2"}
+	patchJSON, _ := json.Marshal(configdiff.JSONPatch(base, next))
+
+	tests := []struct {
+		name         string
+		patch        entity.ConfigPatch
+		wantFallback bool
+	}{
+		{
+			name:  "patch applied to store",
+			patch: entity.ConfigPatch{BaseVersion: 3, Version: 4, Hash: configdiff.Hash(next), Patch: patchJSON},
+		},
+		{
+			name:         "hash mismatch falls back to full fetch",
+			patch:        entity.ConfigPatch{BaseVersion: 3, Version: 4, Hash: "bogus", Patch: patchJSON},
+			wantFallback: true,
+		},
+		{
+			name:         "base version mismatch falls back to full fetch",
+			patch:        entity.ConfigPatch{BaseVersion: 2, Version: 4, Hash: configdiff.Hash(next), Patch: patchJSON},
+			wantFallback: true,
+		},
+		{
+			name:         "invalid patch falls back to full fetch",
+			patch:        entity.ConfigPatch{BaseVersion: 3, Version: 4, Hash: configdiff.Hash(next), Patch: json.RawMessage(`[{"op":"remove","path":"/missing"}]`)},
+			wantFallback: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			store.Set(&entity.Config{Version: 3, Data: base})
+			state := memory.NewAgentState()
+
+			var versions []int64
+			client := &mockControllerClient{
+				fetchFunc: func(_ context.Context, _ string, currentVersion int64) (*entity.ConfigFetch, error) {
+					versions = append(versions, currentVersion)
+					if currentVersion == 0 {
+						return &entity.ConfigFetch{Config: &entity.Config{Version: 4, Data: next}, Changed: true}, nil
+					}
+					return &entity.ConfigFetch{Patch: &tt.patch, Changed: true}, nil
+				},
+			}
+
+			uc := agent.NewQueryUsecase(client, store, state, newWorkerPool(), memory.NewWorkerStatusStore())
+			result, err := uc.PollConfig(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !result.Changed || store.Version() != 4 || configdiff.Hash(store.Get().Data) != configdiff.Hash(next) {
+				t.Errorf("store = %+v, want version 4 with %v", store.Get(), next)
+			}
+
+			wantFetches := 1
+			if tt.wantFallback {
+				wantFetches = 2
+			}
+			if len(versions) != wantFetches {
+				t.Errorf("fetches = %v, want %d", versions, wantFetches)
+			}
+			if tt.wantFallback && len(state.RecentErrors()) != 1 {
+				t.Errorf("recent errors = %v, want the rejected patch", state.RecentErrors())
 			}
 		})
 	}
@@ -0,0 +1,137 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+func TestReconcileWorkers(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        *entity.Config
+		workerVersion int64
+		versionErr    error
+		pushErr       error
+		wantErr       bool
+		wantPushes    int
+		wantInSync    bool
+		wantFailures  int
+	}{
+		{
+			name:       "no config yet",
+			config:     nil,
+			wantPushes: 0,
+		},
+		{
+			name:          "worker in sync",
+			config:        &entity.Config{Version: 3},
+			workerVersion: 3,
+			wantPushes:    0,
+			wantInSync:    true,
+		},
+		{
+			name:          "worker lost its config",
+			config:        &entity.Config{Version: 3},
+			workerVersion: 0,
+			wantPushes:    1,
+			wantInSync:    true,
+		},
+		{
+			name:          "worker on an older version",
+			config:        &entity.Config{Version: 3},
+			workerVersion: 2,
+			wantPushes:    1,
+			wantInSync:    true,
+		},
+		{
+			name:         "worker unreachable",
+			config:       &entity.Config{Version: 3},
+			versionErr:   errors.New("connection refused"),
+			wantErr:      true,
+			wantFailures: 1,
+		},
+		{
+			name:          "re-push fails",
+			config:        &entity.Config{Version: 3},
+			workerVersion: 1,
+			pushErr:       errors.New("worker error"),
+			wantErr:       true,
+			wantPushes:    1,
+			wantFailures:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			if tt.config != nil {
+				store.Set(tt.config)
+			}
+
+			pushes := 0
+			workerClient := &mockWorkerClient{
+				pushFunc: func(_ context.Context, _ *entity.Config) error {
+					pushes++
+					return tt.pushErr
+				},
+				versionFunc: func(_ context.Context) (int64, error) {
+					return tt.workerVersion, tt.versionErr
+				},
+			}
+
+			workers := memory.NewWorkerStatusStore()
+			uc := agent.NewCommandUsecase(nil, newWorkerPool(workerClient), store, memory.NewAgentState(), workers, nil, nil, nil, nil, nil, nil, nil, backoff.DefaultConfig())
+			err := uc.ReconcileWorkers(context.Background())
+
+			if tt.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pushes != tt.wantPushes {
+				t.Errorf("pushes = %d, want %d", pushes, tt.wantPushes)
+			}
+
+			status := workers.Get(workerClient.BaseURL())
+			if status.InSync != tt.wantInSync {
+				t.Errorf("in sync = %v, want %v", status.InSync, tt.wantInSync)
+			}
+			if status.ConsecutiveFailures != tt.wantFailures {
+				t.Errorf("failures = %d, want %d", status.ConsecutiveFailures, tt.wantFailures)
+			}
+		})
+	}
+}
+
+func TestReconcileWorkersBacksOff(t *testing.T) {
+	store := memory.NewConfigStore()
+	store.Set(&entity.Config{Version: 2})
+
+	checks := 0
+	workerClient := &mockWorkerClient{
+		pushFunc: func(_ context.Context, _ *entity.Config) error { return nil },
+		versionFunc: func(_ context.Context) (int64, error) {
+			checks++
+			return 0, errors.New("connection refused")
+		},
+	}
+
+	cfg := backoff.Config{InitialInterval: time.Hour, MaxInterval: time.Hour, Multiplier: 2.0, MaxRetries: 3}
+	uc := agent.NewCommandUsecase(nil, newWorkerPool(workerClient), store, memory.NewAgentState(), memory.NewWorkerStatusStore(), nil, nil, nil, nil, nil, nil, nil, cfg)
+
+	_ = uc.ReconcileWorkers(context.Background())
+	if err := uc.ReconcileWorkers(context.Background()); err != nil {
+		t.Fatalf("expected retry to be deferred, got %v", err)
+	}
+	if checks != 1 {
+		t.Errorf("checks = %d, want 1", checks)
+	}
+}
@@ -0,0 +1,122 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+type mockOverrideSource struct {
+	overrides map[string]string
+	err       error
+}
+
+func (m *mockOverrideSource) Load() (map[string]string, error) {
+	return m.overrides, m.err
+}
+
+func TestRefreshOverrides(t *testing.T) {
+	base := map[string]string{"url": "https://a", "timeout": "5"}
+
+	tests := []struct {
+		name       string
+		active     map[string]string
+		overrides  map[string]string
+		loadErr    error
+		wantErr    bool
+		wantPushed map[string]string
+		wantActive []string
+	}{
+		{
+			name:       "new overrides are merged and pushed",
+			overrides:  map[string]string{"url": "https://pinned"},
+			wantPushed: map[string]string{"url": "https://pinned", "timeout": "5"},
+			wantActive: []string{"url"},
+		},
+		{
+			name:       "unchanged overrides are not pushed again",
+			active:     map[string]string{"url": "https://pinned"},
+			overrides:  map[string]string{"url": "https://pinned"},
+			wantActive: []string{"url"},
+		},
+		{
+			name:       "removed file restores controller config",
+			active:     map[string]string{"url": "https://pinned"},
+			wantPushed: base,
+		},
+		{
+			name:       "load error keeps previous overrides",
+			active:     map[string]string{"url": "https://pinned"},
+			loadErr:    errors.New("parsing overrides.yaml"),
+			wantErr:    true,
+			wantActive: []string{"url"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			store.Set(&entity.Config{Version: 3, Data: base})
+			store.SetOverrides(tt.active)
+			state := memory.NewAgentState()
+			state.SetRegistered("agent-1")
+
+			var pushed *entity.Config
+			worker := &mockWorkerClient{
+				pushFunc: func(_ context.Context, cfg *entity.Config) error {
+					pushed = cfg
+					return nil
+				},
+			}
+			var reported *request.AgentReportRequest
+			client := &mockControllerClient{
+				reportFunc: func(_ context.Context, _ string, req *request.AgentReportRequest) error {
+					reported = req
+					return nil
+				},
+			}
+			source := &mockOverrideSource{overrides: tt.overrides, err: tt.loadErr}
+
+			pool := newWorkerPool(worker)
+			uc := agent.NewCommandUsecase(client, pool, store, state, memory.NewWorkerStatusStore(), nil, nil, nil, source, nil, nil, nil, backoff.DefaultConfig())
+			err := uc.RefreshOverrides(context.Background())
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantPushed == nil {
+				if pushed != nil {
+					t.Errorf("unexpected push: %v", pushed.Data)
+				}
+			} else if pushed == nil || !maps.Equal(pushed.Data, tt.wantPushed) || pushed.Version != 3 {
+				t.Errorf("pushed = %+v, want data %v", pushed, tt.wantPushed)
+			}
+			if !maps.Equal(store.Get().Data, base) {
+				t.Errorf("controller config was modified: %v", store.Get().Data)
+			}
+
+			status := agent.NewQueryUsecase(client, store, state, pool, memory.NewWorkerStatusStore()).Status(context.Background())
+			if got := slices.Sorted(maps.Keys(status.Overrides)); !slices.Equal(got, tt.wantActive) {
+				t.Errorf("status overrides = %v, want %v", got, tt.wantActive)
+			}
+			if status.Config == nil || !maps.Equal(status.Config.Data, store.Effective().Data) {
+				t.Errorf("status config = %+v, want merged data", status.Config)
+			}
+
+			if err := uc.ReportToController(context.Background()); err != nil {
+				t.Fatalf("report: %v", err)
+			}
+			if !slices.Equal(reported.Overrides, tt.wantActive) {
+				t.Errorf("reported overrides = %v, want %v", reported.Overrides, tt.wantActive)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
 	"github.com/adityawiryaa/api/internal/repository/memory"
 	agent "github.com/adityawiryaa/api/internal/usecases/agent"
 	"github.com/adityawiryaa/api/pkg/backoff"
@@ -13,15 +14,39 @@ import (
 
 type mockControllerClient struct {
 	registerFunc func(ctx context.Context, req *entity.RegistrationRequest) (*entity.RegistrationResponse, error)
-	fetchFunc    func(ctx context.Context, currentVersion int64) (*entity.Config, bool, error)
+	fetchFunc    func(ctx context.Context, agentID string, currentVersion int64) (*entity.ConfigFetch, error)
+	reportFunc   func(ctx context.Context, agentID string, req *request.AgentReportRequest) error
+	claimFunc    func(ctx context.Context, agentID string) ([]entity.AgentCommand, error)
+	completeFunc func(ctx context.Context, agentID, commandID string, req *request.CommandResultRequest) error
 }
 
 func (m *mockControllerClient) Register(ctx context.Context, req *entity.RegistrationRequest) (*entity.RegistrationResponse, error) {
 	return m.registerFunc(ctx, req)
 }
 
-func (m *mockControllerClient) FetchConfig(ctx context.Context, currentVersion int64) (*entity.Config, bool, error) {
-	return m.fetchFunc(ctx, currentVersion)
+func (m *mockControllerClient) FetchConfig(ctx context.Context, agentID string, currentVersion int64) (*entity.ConfigFetch, error) {
+	return m.fetchFunc(ctx, agentID, currentVersion)
+}
+
+func (m *mockControllerClient) Report(ctx context.Context, agentID string, req *request.AgentReportRequest) error {
+	if m.reportFunc == nil {
+		return nil
+	}
+	return m.reportFunc(ctx, agentID, req)
+}
+
+func (m *mockControllerClient) ClaimCommands(ctx context.Context, agentID string) ([]entity.AgentCommand, error) {
+	if m.claimFunc == nil {
+		return nil, nil
+	}
+	return m.claimFunc(ctx, agentID)
+}
+
+func (m *mockControllerClient) CompleteCommand(ctx context.Context, agentID, commandID string, req *request.CommandResultRequest) error {
+	if m.completeFunc == nil {
+		return nil
+	}
+	return m.completeFunc(ctx, agentID, commandID, req)
 }
 
 func TestRegisterWithController(t *testing.T) {
@@ -49,8 +74,8 @@ func TestRegisterWithController(t *testing.T) {
 				registerFunc: func(_ context.Context, _ *entity.RegistrationRequest) (*entity.RegistrationResponse, error) {
 					return tt.regResp, tt.regErr
 				},
-				fetchFunc: func(_ context.Context, _ int64) (*entity.Config, bool, error) {
-					return nil, false, nil
+				fetchFunc: func(_ context.Context, _ string, _ int64) (*entity.ConfigFetch, error) {
+					return &entity.ConfigFetch{}, nil
 				},
 			}
 
@@ -68,7 +93,7 @@ func TestRegisterWithController(t *testing.T) {
 			}
 
 			store := memory.NewConfigStore()
-			uc := agent.NewCommandUsecase(client, workerClient, store, cfg)
+			uc := agent.NewCommandUsecase(client, newWorkerPool(workerClient), store, memory.NewAgentState(), memory.NewWorkerStatusStore(), nil, nil, nil, nil, nil, nil, nil, cfg)
 			resp, err := uc.RegisterWithController(context.Background(), &entity.RegistrationRequest{
 				Hostname:  "test-host",
 				IPAddress: "127.0.0.1",
@@ -0,0 +1,99 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+func TestRegisterWorker(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		config     *entity.Config
+		wantErr    bool
+		wantPushes int
+	}{
+		{
+			name:       "new worker receives current config",
+			url:        "http://worker-2.test/",
+			config:     &entity.Config{Version: 7},
+			wantPushes: 1,
+		},
+		{
+			name:       "new worker before any config",
+			url:        "http://worker-2.test",
+			wantPushes: 0,
+		},
+		{
+			name:    "invalid url",
+			url:     "worker-2:6002",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			if tt.config != nil {
+				store.Set(tt.config)
+			}
+
+			pushes := 0
+			pool := memory.NewWorkerPool(func(url string) usecases.WorkerClient {
+				return &mockWorkerClient{
+					url: url,
+					pushFunc: func(_ context.Context, _ *entity.Config) error {
+						pushes++
+						return nil
+					},
+				}
+			}, 0)
+
+			uc := agent.NewCommandUsecase(nil, pool, store, memory.NewAgentState(), memory.NewWorkerStatusStore(), nil, nil, nil, nil, nil, nil, nil, backoff.DefaultConfig())
+			status, err := uc.RegisterWorker(context.Background(), &request.RegisterWorkerRequest{URL: tt.url})
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if status.URL != "http://worker-2.test" || status.Source != valueobject.WorkerSourceRegistered {
+				t.Errorf("status = %+v, want registered http://worker-2.test", status)
+			}
+			if pushes != tt.wantPushes {
+				t.Errorf("pushes = %d, want %d", pushes, tt.wantPushes)
+			}
+		})
+	}
+}
+
+func TestDeregisterWorker(t *testing.T) {
+	static := &mockWorkerClient{url: "http://worker-1.test"}
+	pool := newWorkerPool(static)
+	pool.Add("http://worker-2.test", valueobject.WorkerSourceRegistered)
+
+	uc := agent.NewCommandUsecase(nil, pool, memory.NewConfigStore(), memory.NewAgentState(), memory.NewWorkerStatusStore(), nil, nil, nil, nil, nil, nil, nil, backoff.DefaultConfig())
+
+	if err := uc.DeregisterWorker(context.Background(), "http://worker-2.test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := uc.DeregisterWorker(context.Background(), static.url); !errors.Is(err, usecases.ErrWorkerNotFound) {
+		t.Errorf("deregistering static worker: err = %v, want %v", err, usecases.ErrWorkerNotFound)
+	}
+	if got := len(pool.Endpoints()); got != 1 {
+		t.Errorf("pool size = %d, want 1", got)
+	}
+}
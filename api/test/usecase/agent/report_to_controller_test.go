@@ -0,0 +1,108 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+func TestReportToControllerDownstream(t *testing.T) {
+	reportedAt := time.Now()
+	tests := []struct {
+		name    string
+		agents  []*entity.Agent
+		relay   bool
+		wantIDs []string
+		check   func(t *testing.T, downstream []entity.DownstreamAgent)
+	}{
+		{
+			name:    "plain agent reports no downstream",
+			wantIDs: nil,
+		},
+		{
+			name:  "relay summarizes downstream agents",
+			relay: true,
+			agents: []*entity.Agent{
+				{ID: "edge-1", Hostname: "edge-1", Status: "active", Report: &entity.AgentReport{
+					ConfigVersion: 7,
+					Workers:       []entity.WorkerStatus{{Healthy: true}, {Healthy: false}},
+					ReportedAt:    reportedAt,
+				}},
+				{ID: "edge-2", Hostname: "edge-2", Status: "active"},
+			},
+			wantIDs: []string{"edge-1", "edge-2"},
+			check: func(t *testing.T, downstream []entity.DownstreamAgent) {
+				first := downstream[0]
+				if first.Relay != "relay-1" || first.ConfigVersion != 7 || first.Workers != 2 || first.HealthyWorkers != 1 || !first.ReportedAt.Equal(reportedAt) {
+					t.Errorf("summary = %+v", first)
+				}
+				if downstream[1].ConfigVersion != 0 || !downstream[1].ReportedAt.IsZero() {
+					t.Errorf("unreported agent summary = %+v", downstream[1])
+				}
+			},
+		},
+		{
+			name:  "nested relays are flattened",
+			relay: true,
+			agents: []*entity.Agent{
+				{ID: "site-relay", Report: &entity.AgentReport{
+					Downstream: []entity.DownstreamAgent{{ID: "leaf", Relay: "site-relay", ConfigVersion: 7}},
+				}},
+			},
+			wantIDs: []string{"site-relay", "leaf"},
+			check: func(t *testing.T, downstream []entity.DownstreamAgent) {
+				if downstream[0].Relay != "relay-1" || downstream[1].Relay != "site-relay" {
+					t.Errorf("relays = %q, %q", downstream[0].Relay, downstream[1].Relay)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var downstream repository.AgentRepositoryQuery
+			if tt.relay {
+				registry := memory.NewAgentRegistry()
+				for _, a := range tt.agents {
+					_ = registry.Save(ctx, a)
+				}
+				downstream = registry
+			}
+
+			var got *request.AgentReportRequest
+			client := &mockControllerClient{
+				reportFunc: func(ctx context.Context, agentID string, req *request.AgentReportRequest) error {
+					got = req
+					return nil
+				},
+			}
+			state := memory.NewAgentState()
+			state.SetRegistered("relay-1")
+
+			uc := agent.NewCommandUsecase(client, newWorkerPool(), memory.NewConfigStore(), state, memory.NewWorkerStatusStore(), nil, nil, nil, nil, nil, nil, downstream, backoff.DefaultConfig())
+			if err := uc.ReportToController(ctx); err != nil {
+				t.Fatalf("report: %v", err)
+			}
+
+			if len(got.Downstream) != len(tt.wantIDs) {
+				t.Fatalf("downstream = %+v, want ids %v", got.Downstream, tt.wantIDs)
+			}
+			for i, id := range tt.wantIDs {
+				if got.Downstream[i].ID != id {
+					t.Errorf("downstream[%d] = %s, want %s", i, got.Downstream[i].ID, id)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, got.Downstream)
+			}
+		})
+	}
+}
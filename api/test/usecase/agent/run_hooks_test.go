@@ -0,0 +1,124 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+	"github.com/adityawiryaa/api/pkg/backoff"
+)
+
+type mockHookRunner struct {
+	mu    sync.Mutex
+	hooks []entity.HookDefinition
+	runs  map[string]int
+	fail  map[string]int
+}
+
+func (m *mockHookRunner) Hooks() []entity.HookDefinition {
+	return m.hooks
+}
+
+func (m *mockHookRunner) Run(_ context.Context, hook entity.HookDefinition, _ *entity.Config) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs[hook.Name]++
+	if m.runs[hook.Name] <= m.fail[hook.Name] {
+		return errors.New("hook failed")
+	}
+	return nil
+}
+
+func TestRunHooks(t *testing.T) {
+	tests := []struct {
+		name         string
+		hook         entity.HookDefinition
+		failures     int
+		wantErr      bool
+		wantRuns     int
+		wantApplied  int64
+		wantFailures int
+	}{
+		{
+			name:        "succeeds first time",
+			hook:        entity.HookDefinition{Name: "reload", Command: "true"},
+			wantRuns:    1,
+			wantApplied: 5,
+		},
+		{
+			name:        "succeeds after retry",
+			hook:        entity.HookDefinition{Name: "notify", URL: "http://example.com", Retries: 2},
+			failures:    1,
+			wantRuns:    2,
+			wantApplied: 5,
+		},
+		{
+			name:         "fails after retries exhausted",
+			hook:         entity.HookDefinition{Name: "reload", Command: "false", Retries: 1},
+			failures:     10,
+			wantErr:      true,
+			wantRuns:     2,
+			wantFailures: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			store.Set(&entity.Config{Version: 5})
+			state := memory.NewAgentState()
+			state.SetRegistered("agent-1")
+			runner := &mockHookRunner{
+				hooks: []entity.HookDefinition{tt.hook},
+				runs:  map[string]int{},
+				fail:  map[string]int{tt.hook.Name: tt.failures},
+			}
+
+			var reported *request.AgentReportRequest
+			client := &mockControllerClient{
+				reportFunc: func(_ context.Context, _ string, req *request.AgentReportRequest) error {
+					reported = req
+					return nil
+				},
+			}
+
+			uc := agent.NewCommandUsecase(client, newWorkerPool(), store, state, memory.NewWorkerStatusStore(), nil, nil, runner, nil, nil, nil, nil, backoff.DefaultConfig())
+			err := uc.RunHooks(context.Background())
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if runner.runs[tt.hook.Name] != tt.wantRuns {
+				t.Errorf("runs = %d, want %d", runner.runs[tt.hook.Name], tt.wantRuns)
+			}
+
+			status := state.Hook(tt.hook.Name)
+			if status.AppliedVersion != tt.wantApplied || status.LastVersion != 5 || status.ConsecutiveFailures != tt.wantFailures {
+				t.Errorf("status = %+v", status)
+			}
+			if tt.wantErr && (status.LastError == "" || len(state.RecentErrors()) != 1) {
+				t.Errorf("failure not recorded: status %+v, errors %v", status, state.RecentErrors())
+			}
+
+			if err := uc.ReportToController(context.Background()); err != nil {
+				t.Fatalf("report: %v", err)
+			}
+			if reported == nil || len(reported.Hooks) != 1 || reported.Hooks[0].LastError != status.LastError {
+				t.Errorf("reported hooks = %+v", reported)
+			}
+
+			_ = uc.RunHooks(context.Background())
+			wantRuns := tt.wantRuns
+			if tt.wantErr {
+				wantRuns += tt.hook.Retries + 1
+			}
+			if runner.runs[tt.hook.Name] != wantRuns {
+				t.Errorf("runs after rerun = %d, want %d", runner.runs[tt.hook.Name], wantRuns)
+			}
+		})
+	}
+}
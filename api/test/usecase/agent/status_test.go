@@ -0,0 +1,96 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	agent "github.com/adityawiryaa/api/internal/usecases/agent"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *entity.Config
+		registered   bool
+		fetchErr     error
+		wantReady    bool
+		wantChecks   int
+		wantErrors   int
+		wantPollFail int64
+	}{
+		{
+			name:       "fresh agent is not ready",
+			wantReady:  false,
+			wantChecks: 1,
+		},
+		{
+			name:       "registered agent with config",
+			config:     &entity.Config{Version: 2, Data: map[string]string{"url": "http://example.com"}},
+			registered: true,
+			wantReady:  true,
+		},
+		{
+			name:         "cached config survives poll errors",
+			config:       &entity.Config{Version: 2},
+			registered:   true,
+			fetchErr:     errors.New("connection refused"),
+			wantReady:    true,
+			wantErrors:   1,
+			wantPollFail: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			if tt.config != nil {
+				store.Set(tt.config)
+			}
+			state := memory.NewAgentState()
+			if tt.registered {
+				state.SetRegistered("agent-1")
+			}
+
+			client := &mockControllerClient{
+				fetchFunc: func(_ context.Context, _ string, _ int64) (*entity.ConfigFetch, error) {
+					if tt.fetchErr != nil {
+						return nil, tt.fetchErr
+					}
+					return &entity.ConfigFetch{}, nil
+				},
+			}
+			worker := &mockWorkerClient{url: "http://worker-1.test"}
+
+			uc := agent.NewQueryUsecase(client, store, state, newWorkerPool(worker), memory.NewWorkerStatusStore())
+			_, _ = uc.PollConfig(context.Background())
+
+			readiness := uc.Readiness(context.Background())
+			if readiness.Ready != tt.wantReady || len(readiness.Checks) != tt.wantChecks {
+				t.Errorf("readiness = %+v, want ready=%v with %d failed check(s)", readiness, tt.wantReady, tt.wantChecks)
+			}
+
+			status := uc.Status(context.Background())
+			if status.Ready != tt.wantReady {
+				t.Errorf("status ready = %v, want %v", status.Ready, tt.wantReady)
+			}
+			if status.Registration.Registered != tt.registered {
+				t.Errorf("registered = %v, want %v", status.Registration.Registered, tt.registered)
+			}
+			if (status.Config != nil) != (tt.config != nil) {
+				t.Errorf("config = %+v, want present=%v", status.Config, tt.config != nil)
+			}
+			if status.Poll.Polls != 1 || status.Poll.Failures != tt.wantPollFail {
+				t.Errorf("poll = %+v, want 1 poll and %d failure(s)", status.Poll, tt.wantPollFail)
+			}
+			if len(status.Workers) != 1 || status.Workers[0].URL != worker.url {
+				t.Errorf("workers = %+v, want %s", status.Workers, worker.url)
+			}
+			if len(status.RecentErrors) != tt.wantErrors {
+				t.Errorf("recent errors = %d, want %d", len(status.RecentErrors), tt.wantErrors)
+			}
+		})
+	}
+}
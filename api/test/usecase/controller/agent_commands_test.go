@@ -0,0 +1,216 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	controller "github.com/adityawiryaa/api/internal/usecases/controller"
+)
+
+type mockAgentQuery struct {
+	agents map[string]*entity.Agent
+}
+
+func (m *mockAgentQuery) FindByID(_ context.Context, id string) (*entity.Agent, error) {
+	agent, ok := m.agents[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return agent, nil
+}
+
+func (m *mockAgentQuery) ListAgents(_ context.Context) ([]*entity.Agent, error) {
+	var agents []*entity.Agent
+	for _, agent := range m.agents {
+		agents = append(agents, agent)
+	}
+	return agents, nil
+}
+
+type mockCommandQueue struct {
+	cmds []*entity.AgentCommand
+}
+
+func (m *mockCommandQueue) Enqueue(_ context.Context, cmd *entity.AgentCommand) error {
+	m.cmds = append(m.cmds, cmd)
+	return nil
+}
+
+func (m *mockCommandQueue) MarkDelivered(_ context.Context, agentID string, at time.Time) error {
+	for _, cmd := range m.cmds {
+		if cmd.AgentID == agentID && cmd.Status == valueobject.CommandStatusPending {
+			cmd.Status = valueobject.CommandStatusDelivered
+			cmd.DeliveredAt = at
+		}
+	}
+	return nil
+}
+
+func (m *mockCommandQueue) Complete(_ context.Context, result *entity.AgentCommand) error {
+	for _, cmd := range m.cmds {
+		if cmd.ID == result.ID && cmd.AgentID == result.AgentID {
+			cmd.Status = result.Status
+			cmd.Result = result.Result
+			cmd.Error = result.Error
+			cmd.CompletedAt = result.CompletedAt
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (m *mockCommandQueue) FindByID(_ context.Context, agentID, id string) (*entity.AgentCommand, error) {
+	for _, cmd := range m.cmds {
+		if cmd.ID == id && cmd.AgentID == agentID {
+			return cmd, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *mockCommandQueue) ListByAgent(_ context.Context, agentID, status string) ([]*entity.AgentCommand, error) {
+	var result []*entity.AgentCommand
+	for _, cmd := range m.cmds {
+		if cmd.AgentID == agentID && (status == "" || cmd.Status == status) {
+			result = append(result, cmd)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockCommandQueue) CountOutstanding(_ context.Context, agentID string) (int, error) {
+	n := 0
+	for _, cmd := range m.cmds {
+		if cmd.AgentID == agentID && (cmd.Status == valueobject.CommandStatusPending || cmd.Status == valueobject.CommandStatusDelivered) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestEnqueueCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		agentID string
+		req     *request.EnqueueCommandRequest
+		wantErr error
+	}{
+		{
+			name:    "reload queued",
+			agentID: "agent-1",
+			req:     &request.EnqueueCommandRequest{Type: valueobject.CommandReload},
+		},
+		{
+			name:    "drain with url queued",
+			agentID: "agent-1",
+			req:     &request.EnqueueCommandRequest{Type: valueobject.CommandDrain, Args: map[string]string{"url": "http://worker-1:6002"}},
+		},
+		{
+			name:    "drain without url",
+			agentID: "agent-1",
+			req:     &request.EnqueueCommandRequest{Type: valueobject.CommandDrain},
+			wantErr: usecases.ErrInvalidCommand,
+		},
+		{
+			name:    "unknown type",
+			agentID: "agent-1",
+			req:     &request.EnqueueCommandRequest{Type: "explode"},
+			wantErr: usecases.ErrInvalidCommand,
+		},
+		{
+			name:    "unknown agent",
+			agentID: "agent-2",
+			req:     &request.EnqueueCommandRequest{Type: valueobject.CommandReload},
+			wantErr: usecases.ErrAgentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agents := &mockAgentQuery{agents: map[string]*entity.Agent{"agent-1": {ID: "agent-1"}}}
+			queue := &mockCommandQueue{}
+
+			uc := controller.NewCommandUsecase(nil, nil, nil, nil, nil, agents, queue, queue)
+			cmd, err := uc.EnqueueCommand(context.Background(), tt.agentID, tt.req)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+				if len(queue.cmds) != 0 {
+					t.Errorf("queued %d commands, want 0", len(queue.cmds))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cmd.ID == "" || cmd.Status != valueobject.CommandStatusPending {
+				t.Errorf("command = %+v, want a pending command with an id", cmd)
+			}
+			if len(queue.cmds) != 1 {
+				t.Errorf("queued %d commands, want 1", len(queue.cmds))
+			}
+		})
+	}
+}
+
+func TestCommandLifecycle(t *testing.T) {
+	agents := &mockAgentQuery{agents: map[string]*entity.Agent{"agent-1": {ID: "agent-1"}}}
+	queue := &mockCommandQueue{}
+	commandUC := controller.NewCommandUsecase(nil, nil, nil, nil, nil, agents, queue, queue)
+	queryUC := controller.NewQueryUsecase(nil, agents, nil, queue, nil)
+	ctx := context.Background()
+
+	queued, err := commandUC.EnqueueCommand(ctx, "agent-1", &request.EnqueueCommandRequest{Type: valueobject.CommandDiagnostics})
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if n, _ := queryUC.CountOutstandingCommands(ctx, "agent-1"); n != 1 {
+		t.Errorf("outstanding = %d, want 1", n)
+	}
+
+	claimed, err := commandUC.ClaimCommands(ctx, "agent-1")
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].Status != valueobject.CommandStatusDelivered || claimed[0].DeliveredAt == nil {
+		t.Fatalf("claimed = %+v, want one delivered command", claimed)
+	}
+
+	completed, err := commandUC.CompleteCommand(ctx, "agent-1", queued.ID, &request.CommandResultRequest{
+		Status: valueobject.CommandStatusSucceeded,
+		Result: []byte(`{"ready":true}`),
+	})
+	if err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if completed.Status != valueobject.CommandStatusSucceeded || completed.CompletedAt == nil {
+		t.Errorf("completed = %+v, want succeeded with a completion time", completed)
+	}
+	if n, _ := queryUC.CountOutstandingCommands(ctx, "agent-1"); n != 0 {
+		t.Errorf("outstanding = %d, want 0", n)
+	}
+
+	history, err := queryUC.ListAgentCommands(ctx, "agent-1", "")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(history) != 1 || string(history[0].Result) != `{"ready":true}` {
+		t.Errorf("history = %+v, want the completed command with its result", history)
+	}
+
+	if _, err := commandUC.CompleteCommand(ctx, "agent-1", "missing", &request.CommandResultRequest{Status: valueobject.CommandStatusFailed}); !errors.Is(err, usecases.ErrCommandNotFound) {
+		t.Errorf("complete unknown err = %v, want %v", err, usecases.ErrCommandNotFound)
+	}
+	if _, err := queryUC.ListAgentCommands(ctx, "agent-2", ""); !errors.Is(err, usecases.ErrAgentNotFound) {
+		t.Errorf("list unknown agent err = %v, want %v", err, usecases.ErrAgentNotFound)
+	}
+}
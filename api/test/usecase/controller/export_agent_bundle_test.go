@@ -0,0 +1,141 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/usecases"
+	controller "github.com/adityawiryaa/api/internal/usecases/controller"
+	"github.com/adityawiryaa/api/pkg/bundlesign"
+)
+
+func newBundleKeys(t *testing.T) (*bundlesign.Signer, *bundlesign.Verifier) {
+	t.Helper()
+	privatePEM, publicPEM, err := bundlesign.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	keyPath, pubPath := filepath.Join(dir, "signing.key"), filepath.Join(dir, "signing.pub")
+	if err := os.WriteFile(keyPath, privatePEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pubPath, publicPEM, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	signer, err := bundlesign.LoadSigner(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := bundlesign.LoadVerifier(pubPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, verifier
+}
+
+func TestExportAgentBundle(t *testing.T) {
+	signer, verifier := newBundleKeys(t)
+	latest := &entity.Config{ID: "cfg-7", Version: 7, Data: map[string]string{"url": "https://a"}}
+	agents := &mockAgentQuery{agents: map[string]*entity.Agent{"agent-1": {ID: "agent-1", Hostname: "edge-1"}}}
+
+	tests := []struct {
+		name       string
+		target     entity.BundleTarget
+		noSigner   bool
+		noConfig   bool
+		wantErr    error
+		wantTarget entity.BundleTarget
+	}{
+		{
+			name:       "agent target includes hostname",
+			target:     entity.BundleTarget{AgentID: "agent-1"},
+			wantTarget: entity.BundleTarget{AgentID: "agent-1", Hostname: "edge-1"},
+		},
+		{
+			name:       "selector is normalized",
+			target:     entity.BundleTarget{Selector: " site=north , env!=dev"},
+			wantTarget: entity.BundleTarget{Selector: "site=north,env!=dev"},
+		},
+		{
+			name:    "unknown agent",
+			target:  entity.BundleTarget{AgentID: "agent-9"},
+			wantErr: usecases.ErrAgentNotFound,
+		},
+		{
+			name:    "no target",
+			wantErr: usecases.ErrInvalidBundleTarget,
+		},
+		{
+			name:    "both targets",
+			target:  entity.BundleTarget{AgentID: "agent-1", Selector: "site=north"},
+			wantErr: usecases.ErrInvalidBundleTarget,
+		},
+		{
+			name:    "invalid selector",
+			target:  entity.BundleTarget{Selector: "site"},
+			wantErr: usecases.ErrInvalidBundleTarget,
+		},
+		{
+			name:     "no config yet",
+			target:   entity.BundleTarget{Selector: "site=north"},
+			noConfig: true,
+			wantErr:  usecases.ErrNoConfig,
+		},
+		{
+			name:     "signing not configured",
+			target:   entity.BundleTarget{Selector: "site=north"},
+			noSigner: true,
+			wantErr:  usecases.ErrSigningDisabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := &mockConfigQuery{
+				getLatestFunc: func(_ context.Context) (*entity.Config, error) {
+					if tt.noConfig {
+						return nil, repository.ErrNotFound
+					}
+					return latest, nil
+				},
+			}
+			var bundleSigner usecases.BundleSigner = signer
+			if tt.noSigner {
+				bundleSigner = nil
+			}
+
+			uc := controller.NewQueryUsecase(query, agents, nil, nil, bundleSigner)
+			signed, err := uc.ExportAgentBundle(context.Background(), tt.target)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err := verifier.Verify(signed.KeyID, signed.Payload, signed.Signature); err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+			var bundle entity.AgentBundle
+			if err := json.Unmarshal(signed.Payload, &bundle); err != nil {
+				t.Fatalf("decode payload: %v", err)
+			}
+			if bundle.Target != tt.wantTarget {
+				t.Errorf("target = %+v, want %+v", bundle.Target, tt.wantTarget)
+			}
+			if bundle.FormatVersion != entity.AgentBundleFormatVersion || bundle.Config.Version != 7 || bundle.Config.Data["url"] != "https://a" {
+				t.Errorf("bundle = %+v", bundle)
+			}
+		})
+	}
+}
@@ -2,11 +2,16 @@ package controller_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/usecases"
 	controller "github.com/adityawiryaa/api/internal/usecases/controller"
+	"github.com/adityawiryaa/api/pkg/configdiff"
+	"github.com/adityawiryaa/api/pkg/jsonpatch"
 )
 
 func TestGetConfig(t *testing.T) {
@@ -39,7 +44,7 @@ func TestGetConfig(t *testing.T) {
 				},
 			}
 
-			uc := controller.NewQueryUsecase(query)
+			uc := controller.NewQueryUsecase(query, nil, nil, nil, nil)
 			cfg, err := uc.GetLatestConfig(context.Background())
 
 			if tt.wantErr {
@@ -58,3 +63,75 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetConfigPatch(t *testing.T) {
+	configs := map[int64]*entity.Config{
+		3: {Version: 3, Data: map[string]string{"url": "https://a", "timeout": "5"}},
+		4: {ID: "cfg-4", Version: 4, Data: map[string]string{"url": "https://b", "retries": "2"}, PollIntervalSeconds: 30},
+	}
+
+	tests := []struct {
+		name        string
+		baseVersion int64
+		wantErr     error
+		wantOps     int
+	}{
+		{
+			name:        "patch from known base",
+			baseVersion: 3,
+			wantOps:     3,
+		},
+		{
+			name:        "unknown base",
+			baseVersion: 2,
+			wantErr:     usecases.ErrPatchBaseUnavailable,
+		},
+		{
+			name:        "base newer than latest",
+			baseVersion: 9,
+			wantErr:     usecases.ErrPatchBaseUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := &mockConfigQuery{
+				getLatestFunc: func(_ context.Context) (*entity.Config, error) {
+					return configs[4], nil
+				},
+				getByVersionFunc: func(_ context.Context, version int64) (*entity.Config, error) {
+					if cfg, ok := configs[version]; ok {
+						return cfg, nil
+					}
+					return nil, repository.ErrNotFound
+				},
+			}
+
+			uc := controller.NewQueryUsecase(query, nil, nil, nil, nil)
+			patch, err := uc.GetConfigPatch(context.Background(), tt.baseVersion)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var ops []jsonpatch.Operation
+			if err := json.Unmarshal(patch.Patch, &ops); err != nil {
+				t.Fatalf("decode patch: %v", err)
+			}
+			if len(ops) != tt.wantOps {
+				t.Errorf("ops = %d, want %d", len(ops), tt.wantOps)
+			}
+			if patch.BaseVersion != tt.baseVersion || patch.Version != 4 || patch.ID != "cfg-4" || patch.PollIntervalSeconds != 30 {
+				t.Errorf("patch = %+v", patch)
+			}
+			if patch.Hash != configdiff.Hash(configs[4].Data) {
+				t.Errorf("hash = %s, want hash of latest data", patch.Hash)
+			}
+		})
+	}
+}
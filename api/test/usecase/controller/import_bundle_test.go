@@ -0,0 +1,183 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	domainuc "github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	controller "github.com/adityawiryaa/api/internal/usecases/controller"
+)
+
+type mockBundleCommand struct {
+	importFunc func(ctx context.Context, configs []*entity.Config, agents []*entity.Agent) error
+}
+
+func (m *mockBundleCommand) Import(ctx context.Context, configs []*entity.Config, agents []*entity.Agent) error {
+	return m.importFunc(ctx, configs, agents)
+}
+
+type mockBundleQuery struct {
+	schemaVersion int64
+	err           error
+}
+
+func (m *mockBundleQuery) SchemaVersion(_ context.Context) (int64, error) {
+	return m.schemaVersion, m.err
+}
+
+func TestImportBundle(t *testing.T) {
+	bundle := &entity.ConfigBundle{
+		FormatVersion: entity.BundleFormatVersion,
+		SchemaVersion: 4,
+		Configs: []*entity.Config{
+			{Version: 2, Data: map[string]string{"url": "https://b"}},
+			{Version: 1, Data: map[string]string{"url": "https://a"}},
+		},
+		Agents: []*entity.Agent{{ID: "agent-1", Hostname: "host-1"}},
+	}
+
+	tests := []struct {
+		name         string
+		bundle       *entity.ConfigBundle
+		mode         string
+		latest       *entity.Config
+		importErr    error
+		schemaErr    error
+		wantErr      bool
+		wantErrIs    error
+		wantVersions []int64
+		wantLastURL  string
+	}{
+		{
+			name:         "replay into empty controller",
+			bundle:       bundle,
+			mode:         valueobject.ImportModeReplay,
+			wantVersions: []int64{1, 2},
+			wantLastURL:  "https://b",
+		},
+		{
+			name:         "replay appends after existing history",
+			bundle:       bundle,
+			mode:         valueobject.ImportModeReplay,
+			latest:       &entity.Config{Version: 7},
+			wantVersions: []int64{8, 9},
+			wantLastURL:  "https://b",
+		},
+		{
+			name:         "squash keeps only latest",
+			bundle:       bundle,
+			mode:         valueobject.ImportModeSquash,
+			latest:       &entity.Config{Version: 3},
+			wantVersions: []int64{4},
+			wantLastURL:  "https://b",
+		},
+		{
+			name:    "unknown mode",
+			bundle:  bundle,
+			mode:    "merge",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format",
+			bundle:  &entity.ConfigBundle{FormatVersion: 99},
+			mode:    valueobject.ImportModeReplay,
+			wantErr: true,
+		},
+		{
+			name:         "bundle from an older schema",
+			bundle:       &entity.ConfigBundle{FormatVersion: entity.BundleFormatVersion, SchemaVersion: 2, Configs: bundle.Configs, Agents: bundle.Agents},
+			mode:         valueobject.ImportModeReplay,
+			wantVersions: []int64{1, 2},
+			wantLastURL:  "https://b",
+		},
+		{
+			name:         "bundle without a schema version",
+			bundle:       &entity.ConfigBundle{FormatVersion: entity.BundleFormatVersion, Configs: bundle.Configs, Agents: bundle.Agents},
+			mode:         valueobject.ImportModeSquash,
+			wantVersions: []int64{1},
+			wantLastURL:  "https://b",
+		},
+		{
+			name:      "bundle from a newer schema",
+			bundle:    &entity.ConfigBundle{FormatVersion: entity.BundleFormatVersion, SchemaVersion: 5, Configs: bundle.Configs},
+			mode:      valueobject.ImportModeReplay,
+			wantErr:   true,
+			wantErrIs: domainuc.ErrBundleSchemaTooNew,
+		},
+		{
+			name:      "schema version lookup fails",
+			bundle:    bundle,
+			mode:      valueobject.ImportModeReplay,
+			schemaErr: errors.New("db error"),
+			wantErr:   true,
+		},
+		{
+			name:      "import fails",
+			bundle:    bundle,
+			mode:      valueobject.ImportModeReplay,
+			importErr: errors.New("db error"),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var imported []*entity.Config
+			cmd := &mockBundleCommand{
+				importFunc: func(_ context.Context, configs []*entity.Config, _ []*entity.Agent) error {
+					imported = configs
+					return tt.importErr
+				},
+			}
+			query := &mockConfigQuery{
+				getLatestFunc: func(_ context.Context) (*entity.Config, error) {
+					if tt.latest == nil {
+						return nil, errors.New("no rows")
+					}
+					return tt.latest, nil
+				},
+			}
+
+			schema := &mockBundleQuery{schemaVersion: 4, err: tt.schemaErr}
+			uc := controller.NewCommandUsecase(nil, nil, query, cmd, schema, nil, nil, nil)
+			result, err := uc.ImportBundle(context.Background(), tt.bundle, tt.mode)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Errorf("err = %v, want %v", err, tt.wantErrIs)
+				}
+				if tt.importErr == nil && imported != nil {
+					t.Errorf("imported %d configs, want none", len(imported))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(imported) != len(tt.wantVersions) {
+				t.Fatalf("imported %d configs, want %d", len(imported), len(tt.wantVersions))
+			}
+			for i, v := range tt.wantVersions {
+				if imported[i].Version != v {
+					t.Errorf("config[%d].Version = %d, want %d", i, imported[i].Version, v)
+				}
+			}
+			if got := imported[len(imported)-1].Data["url"]; got != tt.wantLastURL {
+				t.Errorf("latest url = %s, want %s", got, tt.wantLastURL)
+			}
+			if result.LatestVersion != tt.wantVersions[len(tt.wantVersions)-1] {
+				t.Errorf("latest version = %d, want %d", result.LatestVersion, tt.wantVersions[len(tt.wantVersions)-1])
+			}
+			if result.AgentsImported != 1 {
+				t.Errorf("agents imported = %d, want 1", result.AgentsImported)
+			}
+		})
+	}
+}
@@ -11,13 +11,18 @@ import (
 )
 
 type mockAgentCommand struct {
-	saveFunc func(ctx context.Context, agent *entity.Agent) error
+	saveFunc       func(ctx context.Context, agent *entity.Agent) error
+	saveReportFunc func(ctx context.Context, id string, report *entity.AgentReport) error
 }
 
 func (m *mockAgentCommand) Save(ctx context.Context, agent *entity.Agent) error {
 	return m.saveFunc(ctx, agent)
 }
 
+func (m *mockAgentCommand) SaveReport(ctx context.Context, id string, report *entity.AgentReport) error {
+	return m.saveReportFunc(ctx, id, report)
+}
+
 func TestRegisterAgent(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -64,7 +69,7 @@ func TestRegisterAgent(t *testing.T) {
 				},
 			}
 
-			uc := controller.NewCommandUsecase(cmd, nil, query)
+			uc := controller.NewCommandUsecase(cmd, nil, query, nil, nil, nil, nil, nil)
 			resp, err := uc.RegisterAgent(context.Background(), tt.req)
 
 			if tt.wantErr {
@@ -0,0 +1,66 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	controller "github.com/adityawiryaa/api/internal/usecases/controller"
+)
+
+func TestReportAgentStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		saveErr error
+		wantErr error
+	}{
+		{
+			name: "report stored",
+		},
+		{
+			name:    "unknown agent",
+			saveErr: repository.ErrNotFound,
+			wantErr: usecases.ErrAgentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved *entity.AgentReport
+			cmd := &mockAgentCommand{
+				saveReportFunc: func(_ context.Context, _ string, report *entity.AgentReport) error {
+					saved = report
+					return tt.saveErr
+				},
+			}
+
+			uc := controller.NewCommandUsecase(cmd, nil, nil, nil, nil, nil, nil, nil)
+			report, err := uc.ReportAgentStatus(context.Background(), "agent-1", &request.AgentReportRequest{
+				ConfigVersion: 3,
+				Workers: []entity.WorkerStatus{
+					{URL: "http://worker-1:6002", Healthy: true, AppliedVersion: 3, InSync: true},
+				},
+			})
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if saved == nil || saved.ReportedAt.IsZero() {
+				t.Fatal("expected report to be saved with a timestamp")
+			}
+			if report.ConfigVersion != 3 || len(report.Workers) != 1 || !report.Workers[0].InSync {
+				t.Errorf("report = %+v", report)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/repository"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/domain/valueobject"
+	controller "github.com/adityawiryaa/api/internal/usecases/controller"
+	"github.com/adityawiryaa/api/pkg/configsource"
+)
+
+type fakeSource struct {
+	snapshot *configsource.Snapshot
+}
+
+func (f *fakeSource) Load(_ context.Context) (*configsource.Snapshot, error) {
+	return f.snapshot, nil
+}
+
+func TestConfigSyncer(t *testing.T) {
+	snapshot := &configsource.Snapshot{
+		Data:                map[string]string{"url": "https://example.com"},
+		PollIntervalSeconds: 15,
+		Revision:            "abc123",
+	}
+
+	tests := []struct {
+		name        string
+		history     []*entity.Config
+		queryErr    error
+		policy      string
+		wantAction  string
+		wantErr     error
+		wantSaved   bool
+		wantVersion int64
+	}{
+		{
+			name:        "first sync publishes",
+			history:     nil,
+			policy:      valueobject.SyncConflictReject,
+			wantAction:  valueobject.SyncActionApplied,
+			wantSaved:   true,
+			wantVersion: 1,
+		},
+		{
+			name: "unchanged content is a no-op",
+			history: []*entity.Config{
+				{Version: 1, Data: map[string]string{"url": "https://example.com"}, PollIntervalSeconds: 15, Origin: "sync:abc123"},
+			},
+			policy:      valueobject.SyncConflictReject,
+			wantAction:  valueobject.SyncActionUnchanged,
+			wantVersion: 1,
+		},
+		{
+			name: "new revision after sync publishes",
+			history: []*entity.Config{
+				{Version: 1, Data: map[string]string{"url": "https://old"}, PollIntervalSeconds: 15, Origin: "sync:000"},
+			},
+			policy:      valueobject.SyncConflictReject,
+			wantAction:  valueobject.SyncActionApplied,
+			wantSaved:   true,
+			wantVersion: 2,
+		},
+		{
+			name: "manual edit after sync is rejected",
+			history: []*entity.Config{
+				{Version: 1, Data: map[string]string{"url": "https://old"}, PollIntervalSeconds: 15, Origin: "sync:000"},
+				{Version: 2, Data: map[string]string{"url": "https://manual"}, PollIntervalSeconds: 15, Origin: "api"},
+			},
+			policy:     valueobject.SyncConflictReject,
+			wantAction: valueobject.SyncActionConflict,
+			wantErr:    usecases.ErrSyncConflict,
+		},
+		{
+			name: "manual edit after sync is overwritten",
+			history: []*entity.Config{
+				{Version: 1, Data: map[string]string{"url": "https://old"}, PollIntervalSeconds: 15, Origin: "sync:000"},
+				{Version: 2, Data: map[string]string{"url": "https://manual"}, PollIntervalSeconds: 15, Origin: "api"},
+			},
+			policy:      valueobject.SyncConflictOverwrite,
+			wantAction:  valueobject.SyncActionOverwritten,
+			wantSaved:   true,
+			wantVersion: 3,
+		},
+		{
+			name: "query failure publishes nothing",
+			history: []*entity.Config{
+				{Version: 1, Data: map[string]string{"url": "https://old"}, PollIntervalSeconds: 15, Origin: "sync:000"},
+				{Version: 2, Data: map[string]string{"url": "https://manual"}, PollIntervalSeconds: 15, Origin: "api"},
+			},
+			queryErr: errors.New("database is locked"),
+			policy:   valueobject.SyncConflictOverwrite,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saved *entity.Config
+			cmd := &mockConfigCommand{
+				saveFunc: func(_ context.Context, cfg *entity.Config) error {
+					saved = cfg
+					return nil
+				},
+			}
+			query := &mockConfigQuery{
+				getLatestFunc: func(_ context.Context) (*entity.Config, error) {
+					if tt.queryErr != nil {
+						return nil, tt.queryErr
+					}
+					if len(tt.history) == 0 {
+						return nil, repository.ErrNotFound
+					}
+					return tt.history[len(tt.history)-1], nil
+				},
+				listFunc: func(_ context.Context) ([]*entity.Config, error) {
+					return tt.history, nil
+				},
+			}
+
+			commandUC := controller.NewCommandUsecase(nil, cmd, query, nil, nil, nil, nil, nil)
+			syncer, err := controller.NewConfigSyncer(&fakeSource{snapshot: snapshot}, commandUC, query, tt.policy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			result, err := syncer.SyncOnce(context.Background())
+			if tt.queryErr != nil {
+				if !errors.Is(err, tt.queryErr) || result != nil {
+					t.Fatalf("result = %+v, err = %v, want %v", result, err, tt.queryErr)
+				}
+				if saved != nil {
+					t.Fatalf("published version %d despite the query failure", saved.Version)
+				}
+				return
+			}
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Action != tt.wantAction {
+				t.Errorf("action = %s, want %s", result.Action, tt.wantAction)
+			}
+			if (saved != nil) != tt.wantSaved {
+				t.Fatalf("saved = %v, want %v", saved != nil, tt.wantSaved)
+			}
+			if saved != nil {
+				if saved.Version != tt.wantVersion {
+					t.Errorf("version = %d, want %d", saved.Version, tt.wantVersion)
+				}
+				if saved.Origin != "sync:abc123" {
+					t.Errorf("origin = %s, want sync:abc123", saved.Origin)
+				}
+			}
+		})
+	}
+}
+
+func TestNewConfigSyncerInvalidPolicy(t *testing.T) {
+	if _, err := controller.NewConfigSyncer(&fakeSource{}, nil, nil, "merge"); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
@@ -21,6 +21,7 @@ func (m *mockConfigCommand) SaveConfig(ctx context.Context, cfg *entity.Config)
 type mockConfigQuery struct {
 	getLatestFunc    func(ctx context.Context) (*entity.Config, error)
 	getByVersionFunc func(ctx context.Context, version int64) (*entity.Config, error)
+	listFunc         func(ctx context.Context) ([]*entity.Config, error)
 }
 
 func (m *mockConfigQuery) GetLatestConfig(ctx context.Context) (*entity.Config, error) {
@@ -31,6 +32,13 @@ func (m *mockConfigQuery) GetConfigByVersion(ctx context.Context, version int64)
 	return m.getByVersionFunc(ctx, version)
 }
 
+func (m *mockConfigQuery) ListConfigs(ctx context.Context) ([]*entity.Config, error) {
+	if m.listFunc != nil {
+		return m.listFunc(ctx)
+	}
+	return nil, nil
+}
+
 func TestUpdateConfig(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -85,7 +93,7 @@ func TestUpdateConfig(t *testing.T) {
 				},
 			}
 
-			uc := controller.NewCommandUsecase(nil, cmd, query)
+			uc := controller.NewCommandUsecase(nil, cmd, query, nil, nil, nil, nil, nil)
 			cfg, err := uc.UpdateConfig(context.Background(), tt.req)
 
 			if tt.wantErr {
@@ -2,9 +2,15 @@ package worker_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
 	"testing"
 
 	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
 	"github.com/adityawiryaa/api/domain/valueobject"
 	"github.com/adityawiryaa/api/internal/repository/memory"
 	worker "github.com/adityawiryaa/api/internal/usecases/worker"
@@ -12,10 +18,10 @@ import (
 )
 
 type mockExecutor struct {
-	executeFunc func(ctx context.Context, method string, url string, headers map[string]string, body []byte) (int, []byte, error)
+	executeFunc func(ctx context.Context, method string, url string, headers map[string]string, body []byte) (int, string, []byte, error)
 }
 
-func (m *mockExecutor) Execute(ctx context.Context, method string, url string, headers map[string]string, body []byte) (int, []byte, error) {
+func (m *mockExecutor) Execute(ctx context.Context, method string, url string, headers map[string]string, body []byte) (int, string, []byte, error) {
 	return m.executeFunc(ctx, method, url, headers, body)
 }
 
@@ -30,29 +36,162 @@ func (m *mockQueueClient) EnqueueExecuteHit(payload *hitqueue.ExecuteHitPayload)
 	return nil
 }
 
+func (m *mockQueueClient) EnqueueExecuteHits(payloads []*hitqueue.ExecuteHitPayload) (int, error) {
+	for i, payload := range payloads {
+		if err := m.EnqueueExecuteHit(payload); err != nil {
+			return i, err
+		}
+	}
+	return len(payloads), nil
+}
+
 func TestEnqueueHit(t *testing.T) {
 	tests := []struct {
-		name       string
-		config     *entity.Config
-		enqueueErr error
-		wantErr    bool
-		wantStatus string
+		name        string
+		config      *entity.Config
+		request     *request.HitRequest
+		enqueueErr  error
+		wantErr     error
+		wantAnyErr  bool
+		wantStatus  string
+		wantPayload *hitqueue.ExecuteHitPayload
 	}{
 		{
-			name:       "successful enqueue",
-			config:     &entity.Config{Version: 3, Data: map[string]string{"url": "https://example.com/api"}},
-			wantErr:    false,
+			name:        "successful enqueue",
+			config:      &entity.Config{Version: 3, Data: map[string]string{"url": "https://example.com/api"}},
+			wantStatus:  valueobject.TaskStatusQueued,
+			wantPayload: &hitqueue.ExecuteHitPayload{URL: "https://example.com/api", Method: "GET"},
+		},
+		{
+			name:       "no config available",
+			config:     nil,
+			wantAnyErr: true,
+		},
+		{
+			name:       "no url in config",
+			config:     &entity.Config{Version: 1, Data: map[string]string{"other": "value"}},
+			wantAnyErr: true,
+		},
+		{
+			name: "configured method, headers, query and json body",
+			config: &entity.Config{Version: 1, Data: map[string]string{
+				"url":                   "https://example.com/api",
+				"method":                "post",
+				"headers.Authorization": "Bearer abc",
+				"query.page":            "2",
+				"body_type":             "json",
+				"body":                  `{ "name": "edge" }`,
+			}},
+			wantStatus: valueobject.TaskStatusQueued,
+			wantPayload: &hitqueue.ExecuteHitPayload{
+				URL:         "https://example.com/api",
+				Method:      "POST",
+				Headers:     map[string]string{"Authorization": "Bearer abc"},
+				Query:       map[string]string{"page": "2"},
+				Body:        `{"name":"edge"}`,
+				ContentType: "application/json",
+			},
+		},
+		{
+			name: "form body from config",
+			config: &entity.Config{Version: 1, Data: map[string]string{
+				"url":       "https://example.com/login",
+				"method":    "POST",
+				"body_type": "form",
+				"form.user": "admin",
+				"form.next": "/home page",
+			}},
+			wantStatus: valueobject.TaskStatusQueued,
+			wantPayload: &hitqueue.ExecuteHitPayload{
+				URL:         "https://example.com/login",
+				Method:      "POST",
+				Body:        "next=%2Fhome+page&user=admin",
+				ContentType: "application/x-www-form-urlencoded",
+			},
+		},
+		{
+			name: "request overrides merge with config",
+			config: &entity.Config{Version: 1, Data: map[string]string{
+				"url":             "https://example.com/api",
+				"headers.X-Trace": "config",
+				"headers.X-Keep":  "kept",
+				"query.page":      "1",
+				"body":            "from config",
+			}},
+			request: &request.HitRequest{
+				Method:      "PUT",
+				Headers:     map[string]string{"X-Trace": "request"},
+				Query:       map[string]string{"limit": "10"},
+				Body:        json.RawMessage(`"from request"`),
+				ContentType: "text/csv",
+			},
+			wantStatus: valueobject.TaskStatusQueued,
+			wantPayload: &hitqueue.ExecuteHitPayload{
+				URL:         "https://example.com/api",
+				Method:      "PUT",
+				Headers:     map[string]string{"X-Trace": "request", "X-Keep": "kept"},
+				Query:       map[string]string{"page": "1", "limit": "10"},
+				Body:        "from request",
+				ContentType: "text/csv",
+			},
+		},
+		{
+			name: "mixed-case header override replaces the configured header",
+			config: &entity.Config{Version: 1, Data: map[string]string{
+				"url":                  "https://example.com/api",
+				"headers.x-token":      "config",
+				"headers.accept":       "text/html",
+				"headers.CONTENT-type": "text/xml",
+			}},
+			request: &request.HitRequest{
+				Headers: map[string]string{"X-TOKEN": "request", "content-type": "application/xml"},
+			},
+			wantStatus: valueobject.TaskStatusQueued,
+			wantPayload: &hitqueue.ExecuteHitPayload{
+				URL:     "https://example.com/api",
+				Method:  "GET",
+				Headers: map[string]string{"X-Token": "request", "Accept": "text/html", "Content-Type": "application/xml"},
+			},
+		},
+		{
+			name:    "request switches to a json body",
+			config:  &entity.Config{Version: 1, Data: map[string]string{"url": "https://example.com/api", "method": "POST"}},
+			request: &request.HitRequest{BodyType: "json", Body: json.RawMessage(`{"ids": [1, 2]}`)},
+			wantPayload: &hitqueue.ExecuteHitPayload{
+				URL:         "https://example.com/api",
+				Method:      "POST",
+				Body:        `{"ids":[1,2]}`,
+				ContentType: "application/json",
+			},
 			wantStatus: valueobject.TaskStatusQueued,
 		},
 		{
-			name:    "no config available",
-			config:  nil,
-			wantErr: true,
+			name:    "invalid json body",
+			config:  &entity.Config{Version: 1, Data: map[string]string{"url": "https://example.com/api", "body_type": "json", "body": "{oops"}},
+			wantErr: usecases.ErrInvalidHit,
+		},
+		{
+			name:    "form override must be an object",
+			config:  &entity.Config{Version: 1, Data: map[string]string{"url": "https://example.com/api", "body_type": "form"}},
+			request: &request.HitRequest{Body: json.RawMessage(`["a"]`)},
+			wantErr: usecases.ErrInvalidHit,
+		},
+		{
+			name:    "unknown body type",
+			config:  &entity.Config{Version: 1, Data: map[string]string{"url": "https://example.com/api"}},
+			request: &request.HitRequest{BodyType: "xml"},
+			wantErr: usecases.ErrInvalidHit,
+		},
+		{
+			name:    "invalid method",
+			config:  &entity.Config{Version: 1, Data: map[string]string{"url": "https://example.com/api", "method": "GET /"}},
+			wantErr: usecases.ErrInvalidHit,
 		},
 		{
-			name:    "no url in config",
-			config:  &entity.Config{Version: 1, Data: map[string]string{"other": "value"}},
-			wantErr: true,
+			name:    "invalid header name",
+			config:  &entity.Config{Version: 1, Data: map[string]string{"url": "https://example.com/api"}},
+			request: &request.HitRequest{Headers: map[string]string{"Bad Header": "x"}},
+			wantErr: usecases.ErrInvalidHit,
 		},
 	}
 
@@ -64,17 +203,26 @@ func TestEnqueueHit(t *testing.T) {
 			}
 
 			executor := &mockExecutor{
-				executeFunc: func(_ context.Context, _ string, _ string, _ map[string]string, _ []byte) (int, []byte, error) {
-					return 0, nil, nil
+				executeFunc: func(_ context.Context, _ string, _ string, _ map[string]string, _ []byte) (int, string, []byte, error) {
+					return 0, "", nil, nil
 				},
 			}
 
-			uc := worker.NewCommandUsecaseWithEnqueuer(executor, store, &mockQueueClient{})
-			resp, err := uc.EnqueueHit(context.Background())
+			var got *hitqueue.ExecuteHitPayload
+			queue := &mockQueueClient{enqueueFunc: func(payload *hitqueue.ExecuteHitPayload) error {
+				got = payload
+				return tt.enqueueErr
+			}}
 
-			if tt.wantErr {
+			uc := worker.NewCommandUsecaseWithEnqueuer(executor, store, queue, nil, nil)
+			resp, err := uc.EnqueueHit(context.Background(), "default", tt.request)
+
+			if tt.wantErr != nil || tt.wantAnyErr {
 				if err == nil {
-					t.Error("expected error, got nil")
+					t.Fatal("expected error, got nil")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
 				}
 				return
 			}
@@ -85,8 +233,136 @@ func TestEnqueueHit(t *testing.T) {
 			if resp.Status != tt.wantStatus {
 				t.Errorf("status = %s, want %s", resp.Status, tt.wantStatus)
 			}
-			if resp.TaskID == "" {
-				t.Error("expected non-empty task ID")
+			if resp.TaskID == "" || got.TaskID != resp.TaskID {
+				t.Errorf("task ID = %q, payload task ID = %q", resp.TaskID, got.TaskID)
+			}
+			if resp.Job != "default" || got.Job != "default" {
+				t.Errorf("job = %q, payload job = %q, want default", resp.Job, got.Job)
+			}
+
+			want := tt.wantPayload
+			if got.URL != want.URL || got.Method != want.Method || got.Body != want.Body || got.ContentType != want.ContentType {
+				t.Errorf("payload = %+v, want %+v", got, want)
+			}
+			if !maps.Equal(got.Headers, want.Headers) || !maps.Equal(got.Query, want.Query) {
+				t.Errorf("headers/query = %v %v, want %v %v", got.Headers, got.Query, want.Headers, want.Query)
+			}
+		})
+	}
+}
+
+func TestEnqueueHits(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         map[string]string
+		job          string
+		wantErr      error
+		wantJobs     []string
+		wantRequests map[string]string
+	}{
+		{
+			name:         "config without jobs is the default job",
+			data:         map[string]string{"url": "https://example.com/a"},
+			wantJobs:     []string{"default"},
+			wantRequests: map[string]string{"default": "GET https://example.com/a"},
+		},
+		{
+			name: "every job with shared defaults",
+			data: map[string]string{
+				"method":             "POST",
+				"headers.X-Site":     "north",
+				"jobs.status.url":    "https://example.com/status",
+				"jobs.status.method": "GET",
+				"jobs.metrics.url":   "https://example.com/metrics",
+			},
+			wantJobs: []string{"metrics", "status"},
+			wantRequests: map[string]string{
+				"metrics": "POST https://example.com/metrics",
+				"status":  "GET https://example.com/status",
+			},
+		},
+		{
+			name:         "one job by name",
+			data:         map[string]string{"jobs.a.url": "https://example.com/a", "jobs.b.url": "https://example.com/b"},
+			job:          "b",
+			wantJobs:     []string{"b"},
+			wantRequests: map[string]string{"b": "GET https://example.com/b"},
+		},
+		{
+			name:    "unknown job",
+			data:    map[string]string{"jobs.a.url": "https://example.com/a"},
+			job:     "missing",
+			wantErr: usecases.ErrJobNotFound,
+		},
+		{
+			name:    "invalid job key",
+			data:    map[string]string{"jobs.a": "https://example.com/a"},
+			wantErr: usecases.ErrInvalidHit,
+		},
+		{
+			name:    "invalid job name",
+			data:    map[string]string{"jobs.a b.url": "https://example.com/a"},
+			wantErr: usecases.ErrInvalidHit,
+		},
+		{
+			name:    "one invalid job enqueues nothing",
+			data:    map[string]string{"jobs.a.url": "https://example.com/a", "jobs.b.url": "https://example.com/b", "jobs.b.body_type": "xml"},
+			wantErr: usecases.ErrInvalidHit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			store.Set(&entity.Config{Version: 1, Data: tt.data})
+
+			var enqueued []*hitqueue.ExecuteHitPayload
+			queue := &mockQueueClient{enqueueFunc: func(payload *hitqueue.ExecuteHitPayload) error {
+				enqueued = append(enqueued, payload)
+				return nil
+			}}
+			uc := worker.NewCommandUsecaseWithEnqueuer(nil, store, queue, nil, nil)
+
+			var jobs []string
+			var err error
+			if tt.job != "" {
+				var resp *usecases.EnqueueHitResponse
+				resp, err = uc.EnqueueHit(context.Background(), tt.job, nil)
+				if resp != nil {
+					jobs = append(jobs, resp.Job)
+				}
+			} else {
+				var resp *usecases.EnqueueHitsResponse
+				resp, err = uc.EnqueueHits(context.Background(), nil)
+				if resp != nil {
+					for _, task := range resp.Tasks {
+						jobs = append(jobs, task.Job)
+					}
+				}
+			}
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if len(enqueued) != 0 {
+					t.Errorf("enqueued %d task(s) despite the error", len(enqueued))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(jobs, tt.wantJobs) {
+				t.Errorf("jobs = %v, want %v", jobs, tt.wantJobs)
+			}
+			requests := make(map[string]string, len(enqueued))
+			for _, payload := range enqueued {
+				requests[payload.Job] = payload.Method + " " + payload.URL
+			}
+			if !maps.Equal(requests, tt.wantRequests) {
+				t.Errorf("requests = %v, want %v", requests, tt.wantRequests)
 			}
 		})
 	}
@@ -0,0 +1,321 @@
+package worker_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/adityawiryaa/api/domain/request"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	worker "github.com/adityawiryaa/api/internal/usecases/worker"
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+)
+
+type mockBatchStore struct {
+	batches map[string]*hitqueue.HitBatch
+	saves   int
+	results map[string]*hitqueue.HitResult
+}
+
+func newMockBatchStore() *mockBatchStore {
+	return &mockBatchStore{
+		batches: make(map[string]*hitqueue.HitBatch),
+		results: make(map[string]*hitqueue.HitResult),
+	}
+}
+
+func (m *mockBatchStore) SaveBatch(_ context.Context, batch *hitqueue.HitBatch) error {
+	saved := *batch
+	saved.Tasks = append([]hitqueue.HitBatchTask(nil), batch.Tasks...)
+	m.batches[batch.ID] = &saved
+	m.saves++
+	return nil
+}
+
+func (m *mockBatchStore) GetBatch(_ context.Context, id string) (*hitqueue.HitBatch, error) {
+	return m.batches[id], nil
+}
+
+func (m *mockBatchStore) GetResult(_ context.Context, taskID string) (*hitqueue.HitResult, error) {
+	return m.results[taskID], nil
+}
+
+func (m *mockBatchStore) GetLatestResult(_ context.Context, _ string) (*hitqueue.HitResult, error) {
+	return nil, nil
+}
+
+func (m *mockBatchStore) GetResults(_ context.Context, taskIDs []string) ([]*hitqueue.HitResult, error) {
+	results := make([]*hitqueue.HitResult, len(taskIDs))
+	for i, id := range taskIDs {
+		results[i] = m.results[id]
+	}
+	return results, nil
+}
+
+func batchHits(urls ...string) *request.HitBatchRequest {
+	req := &request.HitBatchRequest{}
+	for _, url := range urls {
+		req.Hits = append(req.Hits, request.BatchHitSpec{URL: url})
+	}
+	return req
+}
+
+func TestEnqueueBatch(t *testing.T) {
+	tooMany := batchHits()
+	for range 1001 {
+		tooMany.Hits = append(tooMany.Hits, request.BatchHitSpec{URL: "https://example.com"})
+	}
+
+	tests := []struct {
+		name         string
+		req          *request.HitBatchRequest
+		failAfter    int
+		wantErr      error
+		wantAnyErr   bool
+		wantEnqueued int
+		wantFailed   int
+	}{
+		{
+			name:         "all hits enqueued",
+			req:          batchHits("https://a.test", "https://b.test/path?x=1", "http://c.test"),
+			failAfter:    -1,
+			wantEnqueued: 3,
+		},
+		{
+			name: "per-hit overrides",
+			req: &request.HitBatchRequest{Hits: []request.BatchHitSpec{{
+				URL:        "https://a.test",
+				HitRequest: request.HitRequest{Method: "post", BodyType: "json", Body: json.RawMessage(`{"a": 1}`)},
+			}}},
+			failAfter:    -1,
+			wantEnqueued: 1,
+		},
+		{
+			name:      "empty batch",
+			req:       batchHits(),
+			failAfter: -1,
+			wantErr:   usecases.ErrInvalidBatch,
+		},
+		{
+			name:      "too many hits",
+			req:       tooMany,
+			failAfter: -1,
+			wantErr:   usecases.ErrInvalidBatch,
+		},
+		{
+			name:      "invalid url rejects the whole batch",
+			req:       batchHits("https://a.test", "ftp://b.test"),
+			failAfter: -1,
+			wantErr:   usecases.ErrInvalidHit,
+		},
+		{
+			name: "invalid body rejects the whole batch",
+			req: &request.HitBatchRequest{Hits: []request.BatchHitSpec{{
+				URL:        "https://a.test",
+				HitRequest: request.HitRequest{BodyType: "json", Body: json.RawMessage(`{`)},
+			}}},
+			failAfter: -1,
+			wantErr:   usecases.ErrInvalidHit,
+		},
+		{
+			name:         "partial enqueue failure",
+			req:          batchHits("https://a.test", "https://b.test", "https://c.test"),
+			failAfter:    1,
+			wantEnqueued: 1,
+			wantFailed:   2,
+		},
+		{
+			name:       "nothing enqueued",
+			req:        batchHits("https://a.test"),
+			failAfter:  0,
+			wantAnyErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payloads []*hitqueue.ExecuteHitPayload
+			queue := &mockQueueClient{enqueueFunc: func(payload *hitqueue.ExecuteHitPayload) error {
+				if tt.failAfter >= 0 && len(payloads) >= tt.failAfter {
+					return errors.New("redis unavailable")
+				}
+				payloads = append(payloads, payload)
+				return nil
+			}}
+			batches := newMockBatchStore()
+
+			uc := worker.NewCommandUsecaseWithEnqueuer(nil, memory.NewConfigStore(), queue, nil, batches)
+			resp, err := uc.EnqueueBatch(context.Background(), tt.req)
+
+			if tt.wantErr != nil || tt.wantAnyErr {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if tt.wantErr != nil && (len(payloads) > 0 || batches.saves > 0) {
+					t.Errorf("invalid batch enqueued %d tasks and saved %d times", len(payloads), batches.saves)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Enqueued != tt.wantEnqueued || resp.Failed != tt.wantFailed || resp.Total != len(tt.req.Hits) {
+				t.Errorf("resp = %+v", resp)
+			}
+
+			batch := batches.batches[resp.BatchID]
+			if batch == nil || len(batch.Tasks) != len(tt.req.Hits) {
+				t.Fatalf("saved batch = %+v", batch)
+			}
+			for i, task := range batch.Tasks {
+				failed := task.Error != ""
+				if failed != (i >= tt.wantEnqueued) {
+					t.Errorf("task %d error = %q", i, task.Error)
+				}
+			}
+			for i, payload := range payloads {
+				if payload.Batch != resp.BatchID || payload.TaskID != batch.Tasks[i].TaskID || payload.URL != tt.req.Hits[i].URL {
+					t.Errorf("payload %d = %+v", i, payload)
+				}
+			}
+		})
+	}
+}
+
+func TestGetBatch(t *testing.T) {
+	batches := newMockBatchStore()
+	batches.batches["b1"] = &hitqueue.HitBatch{ID: "b1", Tasks: []hitqueue.HitBatchTask{
+		{TaskID: "t1", Method: "GET", URL: "https://a.test"},
+		{TaskID: "t2", Method: "GET", URL: "https://b.test"},
+		{TaskID: "t3", Method: "POST", URL: "https://c.test"},
+		{TaskID: "t4", Method: "GET", URL: "https://d.test", Error: "not enqueued: redis unavailable"},
+		{TaskID: "t5", Method: "GET", URL: "https://e.test"},
+	}}
+	batches.results["t1"] = &hitqueue.HitResult{TaskID: "t1", Status: "completed", StatusCode: 200}
+	batches.results["t2"] = &hitqueue.HitResult{TaskID: "t2", Status: "failed", Error: "timeout"}
+	batches.results["t3"] = &hitqueue.HitResult{TaskID: "t3", Status: "completed", StatusCode: 201}
+
+	tests := []struct {
+		name      string
+		id        string
+		page      int
+		perPage   int
+		wantErr   error
+		wantTasks []string
+		wantPages int
+	}{
+		{name: "default page", id: "b1", wantTasks: []string{"t1", "t2", "t3", "t4", "t5"}, wantPages: 1},
+		{name: "second page", id: "b1", page: 2, perPage: 2, wantTasks: []string{"t3", "t4"}, wantPages: 3},
+		{name: "page past the end", id: "b1", page: 9, perPage: 2, wantTasks: []string{}, wantPages: 3},
+		{name: "unknown batch", id: "missing", wantErr: usecases.ErrBatchNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := worker.NewQueryUsecase(memory.NewConfigStore(), batches, nil, nil, batches)
+			resp, err := uc.GetBatch(context.Background(), tt.id, tt.page, tt.perPage)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if resp.Total != 5 || resp.Progress != 80 || resp.Pages != tt.wantPages {
+				t.Errorf("total = %d, progress = %v, pages = %d", resp.Total, resp.Progress, resp.Pages)
+			}
+			if resp.Counts["completed"] != 2 || resp.Counts["failed"] != 2 || resp.Counts["pending"] != 1 {
+				t.Errorf("counts = %v", resp.Counts)
+			}
+			if len(resp.Tasks) != len(tt.wantTasks) {
+				t.Fatalf("tasks = %+v, want %v", resp.Tasks, tt.wantTasks)
+			}
+			for i, task := range resp.Tasks {
+				if task.TaskID != tt.wantTasks[i] {
+					t.Errorf("tasks[%d] = %s, want %s", i, task.TaskID, tt.wantTasks[i])
+				}
+				if task.TaskID == "t4" && (task.Status != "failed" || task.Error == "" || task.Index != 3) {
+					t.Errorf("unenqueued task = %+v", task)
+				}
+			}
+		})
+	}
+}
+
+type failingEnqueuer struct {
+	failAt int
+	queued int
+}
+
+func (f *failingEnqueuer) Enqueue(_ *asynq.Task, _ ...asynq.Option) (*asynq.TaskInfo, error) {
+	if f.queued == f.failAt {
+		return nil, errors.New("redis unavailable")
+	}
+	f.queued++
+	return &asynq.TaskInfo{}, nil
+}
+
+func TestEnqueueBatchPartialFailureContract(t *testing.T) {
+	tests := []struct {
+		name         string
+		failAt       int
+		wantErr      bool
+		wantEnqueued int
+	}{
+		{name: "queue fails midway", failAt: 2, wantEnqueued: 2},
+		{name: "queue fails on the last task", failAt: 3, wantEnqueued: 3},
+		{name: "queue fails on the first task", failAt: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enqueuer := &failingEnqueuer{failAt: tt.failAt}
+			batches := newMockBatchStore()
+			uc := worker.NewCommandUsecaseWithEnqueuer(nil, memory.NewConfigStore(), hitqueue.NewClientWithEnqueuer(enqueuer), nil, batches)
+
+			req := batchHits("https://a.test", "https://b.test", "https://c.test", "https://d.test")
+			resp, err := uc.EnqueueBatch(context.Background(), req)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("resp = %+v, want error", resp)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if resp.Total != 4 || resp.Enqueued != tt.wantEnqueued || resp.Failed != 4-tt.wantEnqueued {
+					t.Errorf("resp = %+v, want %d enqueued and %d failed", resp, tt.wantEnqueued, 4-tt.wantEnqueued)
+				}
+			}
+			if enqueuer.queued != tt.wantEnqueued {
+				t.Errorf("queued = %d, want %d", enqueuer.queued, tt.wantEnqueued)
+			}
+
+			if len(batches.batches) != 1 || batches.saves != 2 {
+				t.Fatalf("batches = %d, saves = %d, want one batch saved twice", len(batches.batches), batches.saves)
+			}
+			for _, batch := range batches.batches {
+				failedTask := batch.Tasks[tt.wantEnqueued].TaskID
+				for i, task := range batch.Tasks {
+					if i < tt.wantEnqueued {
+						if task.Error != "" {
+							t.Errorf("enqueued task %d error = %q", i, task.Error)
+						}
+						continue
+					}
+					want := "not enqueued: task " + failedTask + ": enqueuing task: redis unavailable"
+					if task.Error != want {
+						t.Errorf("task %d error = %q, want %q", i, task.Error, want)
+					}
+				}
+			}
+		})
+	}
+}
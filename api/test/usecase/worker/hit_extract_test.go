@@ -0,0 +1,141 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	worker "github.com/adityawiryaa/api/internal/usecases/worker"
+	"github.com/adityawiryaa/api/pkg/hit/extract"
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+)
+
+func TestEnqueueHitExtractRules(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         map[string]string
+		wantRules    []extract.Rule
+		wantDropBody bool
+		wantErr      error
+	}{
+		{
+			name: "no rules",
+			data: map[string]string{"url": "https://example.com"},
+		},
+		{
+			name: "selector shorthand and properties",
+			data: map[string]string{
+				"url":                    "https://example.com",
+				"extract.title":          "h1",
+				"extract.next.selector":  "a.next",
+				"extract.next.attr":      "href",
+				"extract.tags.selector":  ".tag",
+				"extract.tags.list":      "true",
+				"extract.intro.selector": "#intro",
+				"extract.intro.mode":     "HTML",
+			},
+			wantRules: []extract.Rule{
+				{Name: "intro", Selector: "#intro", Mode: "html"},
+				{Name: "next", Selector: "a.next", Mode: "attr", Attr: "href"},
+				{Name: "tags", Selector: ".tag", List: true},
+				{Name: "title", Selector: "h1"},
+			},
+		},
+		{
+			name: "invalid list flag",
+			data: map[string]string{
+				"url":                                   "https://example.com",
+				"store_body":                            "false",
+				"extract.products.selector":             "li.product",
+				"extract.products.list":                 "true",
+				"extract.products.fields.name":          "h2",
+				"extract.products.fields.sku.attr":      "data-sku",
+				"extract.products.fields.tags.selector": ".tag",
+				"extract.products.fields.tags.list":     "yes",
+			},
+			wantErr: usecases.ErrInvalidHit,
+		},
+		{
+			name: "nested item group",
+			data: map[string]string{
+				"url":                                     "https://example.com",
+				"store_body":                              "false",
+				"extract.products.selector":               "li.product",
+				"extract.products.list":                   "true",
+				"extract.products.fields.name":            "h2",
+				"extract.products.fields.sku.attr":        "data-sku",
+				"extract.products.fields.links.selector":  "a",
+				"extract.products.fields.links.attr":      "href",
+				"extract.products.fields.links.list":      "1",
+				"extract.products.fields.fields.selector": ".fields",
+			},
+			wantRules: []extract.Rule{{Name: "products", Selector: "li.product", List: true, Fields: []extract.Rule{
+				{Name: "fields", Selector: ".fields"},
+				{Name: "links", Selector: "a", Mode: "attr", Attr: "href", List: true},
+				{Name: "name", Selector: "h2"},
+				{Name: "sku", Mode: "attr", Attr: "data-sku"},
+			}}},
+			wantDropBody: true,
+		},
+		{
+			name:    "unknown property",
+			data:    map[string]string{"url": "https://example.com", "extract.title.xpath": "//h1"},
+			wantErr: usecases.ErrInvalidHit,
+		},
+		{
+			name:    "too deep without fields",
+			data:    map[string]string{"url": "https://example.com", "extract.a.b.selector": "p"},
+			wantErr: usecases.ErrInvalidHit,
+		},
+		{
+			name:    "invalid selector",
+			data:    map[string]string{"url": "https://example.com", "extract.title": "h1[", "extract.other": "p"},
+			wantErr: usecases.ErrInvalidHit,
+		},
+		{
+			name:    "top-level rule without selector",
+			data:    map[string]string{"url": "https://example.com", "extract.link.attr": "href"},
+			wantErr: usecases.ErrInvalidHit,
+		},
+		{
+			name:    "invalid store_body",
+			data:    map[string]string{"url": "https://example.com", "store_body": "sometimes"},
+			wantErr: usecases.ErrInvalidHit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := memory.NewConfigStore()
+			store.Set(&entity.Config{Version: 1, Data: tt.data})
+
+			var got *hitqueue.ExecuteHitPayload
+			queue := &mockQueueClient{enqueueFunc: func(payload *hitqueue.ExecuteHitPayload) error {
+				got = payload
+				return nil
+			}}
+			uc := worker.NewCommandUsecaseWithEnqueuer(nil, store, queue, nil, nil)
+			_, err := uc.EnqueueHit(context.Background(), "default", nil)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got.Extract, tt.wantRules) {
+				t.Errorf("rules = %+v\nwant    %+v", got.Extract, tt.wantRules)
+			}
+			if got.DropBody != tt.wantDropBody {
+				t.Errorf("drop body = %v, want %v", got.DropBody, tt.wantDropBody)
+			}
+		})
+	}
+}
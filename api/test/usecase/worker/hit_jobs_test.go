@@ -0,0 +1,108 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/domain/usecases"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	worker "github.com/adityawiryaa/api/internal/usecases/worker"
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+)
+
+type mockResultReader struct {
+	latest map[string]*hitqueue.HitResult
+}
+
+func (m *mockResultReader) GetResult(_ context.Context, _ string) (*hitqueue.HitResult, error) {
+	return nil, nil
+}
+
+func (m *mockResultReader) GetResults(_ context.Context, taskIDs []string) ([]*hitqueue.HitResult, error) {
+	return make([]*hitqueue.HitResult, len(taskIDs)), nil
+}
+
+func (m *mockResultReader) GetLatestResult(_ context.Context, job string) (*hitqueue.HitResult, error) {
+	return m.latest[job], nil
+}
+
+func TestListJobs(t *testing.T) {
+	completedAt := time.Now()
+	store := memory.NewConfigStore()
+	store.Set(&entity.Config{Version: 1, Data: map[string]string{
+		"jobs.status.url":       "https://example.com/status",
+		"jobs.broken.url":       "https://example.com/broken",
+		"jobs.broken.body_type": "xml",
+		"jobs.metrics.url":      "https://example.com/metrics",
+		"jobs.metrics.method":   "post",
+	}})
+	reader := &mockResultReader{latest: map[string]*hitqueue.HitResult{
+		"status": {TaskID: "t1", Job: "status", Status: "completed", StatusCode: 200, CompletedAt: completedAt},
+	}}
+
+	uc := worker.NewQueryUsecase(store, reader, nil, nil, nil)
+	jobs, err := uc.ListJobs(context.Background())
+	if err != nil {
+		t.Fatalf("list jobs: %v", err)
+	}
+
+	if len(jobs) != 3 || jobs[0].Name != "broken" || jobs[1].Name != "metrics" || jobs[2].Name != "status" {
+		t.Fatalf("jobs = %+v, want broken, metrics, status", jobs)
+	}
+	if jobs[0].Error == "" || jobs[0].URL != "" {
+		t.Errorf("broken job = %+v, want an error", jobs[0])
+	}
+	if jobs[1].Method != "POST" || jobs[1].URL != "https://example.com/metrics" || jobs[1].Latest != nil {
+		t.Errorf("metrics job = %+v", jobs[1])
+	}
+	latest := jobs[2].Latest
+	if latest == nil || latest.TaskID != "t1" || latest.CompletedAt == nil || !latest.CompletedAt.Equal(completedAt) {
+		t.Errorf("status latest = %+v", latest)
+	}
+}
+
+func TestGetJobResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		job      string
+		wantTask string
+		wantErr  error
+	}{
+		{name: "latest result", job: "status", wantTask: "t1"},
+		{name: "stored result of a removed job", job: "old", wantErr: usecases.ErrJobNotFound},
+		{name: "configured job without results", job: "metrics", wantErr: usecases.ErrNoHitResult},
+		{name: "unknown job", job: "missing", wantErr: usecases.ErrJobNotFound},
+	}
+
+	store := memory.NewConfigStore()
+	store.Set(&entity.Config{Version: 1, Data: map[string]string{
+		"jobs.status.url":  "https://example.com/status",
+		"jobs.metrics.url": "https://example.com/metrics",
+	}})
+	reader := &mockResultReader{latest: map[string]*hitqueue.HitResult{
+		"status": {TaskID: "t1", Job: "status", Status: "completed"},
+		"old":    {TaskID: "t0", Job: "old", Status: "failed"},
+	}}
+	uc := worker.NewQueryUsecase(store, reader, nil, nil, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := uc.GetJobResult(context.Background(), tt.job)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.TaskID != tt.wantTask || result.Job != tt.job {
+				t.Errorf("result = %+v, want task %s for job %s", result, tt.wantTask, tt.job)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package worker_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	worker "github.com/adityawiryaa/api/internal/usecases/worker"
+)
+
+func TestHTTPExecutor(t *testing.T) {
+	tests := []struct {
+		name            string
+		contentType     string
+		wantContentType string
+	}{
+		{name: "html", contentType: "text/html; charset=utf-8", wantContentType: "text/html; charset=utf-8"},
+		{name: "json", contentType: "application/json", wantContentType: "application/json"},
+		{name: "missing header", wantContentType: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				} else {
+					w.Header()["Content-Type"] = nil
+				}
+				w.WriteHeader(http.StatusAccepted)
+				w.Write(append([]byte(r.Method+" "+r.Header.Get("X-Token")+" "), body...))
+			}))
+			defer srv.Close()
+
+			executor := worker.NewHTTPExecutor(time.Second)
+			status, contentType, body, err := executor.Execute(context.Background(), http.MethodPost, srv.URL,
+				map[string]string{"X-Token": "secret"}, []byte("payload"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if status != http.StatusAccepted {
+				t.Errorf("status = %d, want %d", status, http.StatusAccepted)
+			}
+			if contentType != tt.wantContentType {
+				t.Errorf("content type = %q, want %q", contentType, tt.wantContentType)
+			}
+			if string(body) != "POST secret payload" {
+				t.Errorf("body = %q", body)
+			}
+		})
+	}
+}
@@ -32,7 +32,7 @@ func TestReceiveConfig(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			store := memory.NewConfigStore()
-			cmdUc := worker.NewCommandUsecaseWithEnqueuer(nil, store, &mockQueueClient{})
+			cmdUc := worker.NewCommandUsecaseWithEnqueuer(nil, store, &mockQueueClient{}, nil, nil)
 			queryUc := worker.NewQueryUsecaseWithStore(store)
 
 			for _, cfg := range tt.configs {
@@ -0,0 +1,110 @@
+package worker_test
+
+import (
+	"testing"
+
+	"github.com/adityawiryaa/api/domain/entity"
+	"github.com/adityawiryaa/api/internal/repository/memory"
+	worker "github.com/adityawiryaa/api/internal/usecases/worker"
+	hitqueue "github.com/adityawiryaa/api/pkg/hit/queue"
+)
+
+type mockScheduler struct {
+	syncs [][]hitqueue.ScheduleEntry
+}
+
+func (m *mockScheduler) Sync(entries []hitqueue.ScheduleEntry) error {
+	m.syncs = append(m.syncs, entries)
+	return nil
+}
+
+func TestReceiveConfigSyncsSchedules(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      map[string]string
+		wantSpecs map[string]string
+		wantErrs  []string
+	}{
+		{
+			name:      "no schedules",
+			data:      map[string]string{"url": "https://example.com"},
+			wantSpecs: map[string]string{},
+		},
+		{
+			name:      "default job schedule",
+			data:      map[string]string{"url": "https://example.com", "schedule": "*/5 * * * *"},
+			wantSpecs: map[string]string{"default": "*/5 * * * *"},
+		},
+		{
+			name: "interval shorthand and unscheduled jobs",
+			data: map[string]string{
+				"jobs.ping.url":       "https://example.com/ping",
+				"jobs.ping.schedule":  "30s",
+				"jobs.daily.url":      "https://example.com/daily",
+				"jobs.daily.schedule": "@daily",
+				"jobs.manual.url":     "https://example.com/manual",
+			},
+			wantSpecs: map[string]string{"ping": "@every 30s", "daily": "@daily"},
+		},
+		{
+			name: "shared schedule applies to every job",
+			data: map[string]string{
+				"schedule":        "@every 1m30s",
+				"jobs.a.url":      "https://example.com/a",
+				"jobs.b.url":      "https://example.com/b",
+				"jobs.b.method":   "POST",
+				"jobs.c.url":      "https://example.com/c",
+				"jobs.c.schedule": " ",
+			},
+			wantSpecs: map[string]string{"a": "@every 1m30s", "b": "@every 1m30s"},
+		},
+		{
+			name: "invalid job definition is passed along with its error",
+			data: map[string]string{
+				"jobs.bad.schedule":  "@hourly",
+				"jobs.bad.body_type": "xml",
+				"jobs.bad.url":       "https://example.com",
+			},
+			wantSpecs: map[string]string{"bad": "@hourly"},
+			wantErrs:  []string{"bad"},
+		},
+		{
+			name:      "malformed job keys clear schedules",
+			data:      map[string]string{"jobs.url": "https://example.com", "schedule": "@hourly"},
+			wantSpecs: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler := &mockScheduler{}
+			uc := worker.NewCommandUsecaseWithEnqueuer(nil, memory.NewConfigStore(), &mockQueueClient{}, scheduler, nil)
+			uc.ReceiveConfig(&entity.Config{Version: 1, Data: tt.data})
+
+			if len(scheduler.syncs) != 1 {
+				t.Fatalf("syncs = %d, want 1", len(scheduler.syncs))
+			}
+			entries := scheduler.syncs[0]
+			if len(entries) != len(tt.wantSpecs) {
+				t.Fatalf("entries = %+v, want %v", entries, tt.wantSpecs)
+			}
+
+			var gotErrs []string
+			for _, entry := range entries {
+				if entry.Spec != tt.wantSpecs[entry.Job] {
+					t.Errorf("%s spec = %q, want %q", entry.Job, entry.Spec, tt.wantSpecs[entry.Job])
+				}
+				if entry.Err != nil {
+					gotErrs = append(gotErrs, entry.Job)
+					continue
+				}
+				if entry.Payload == nil || entry.Payload.URL == "" {
+					t.Errorf("%s payload = %+v", entry.Job, entry.Payload)
+				}
+			}
+			if len(gotErrs) != len(tt.wantErrs) {
+				t.Errorf("failed jobs = %v, want %v", gotErrs, tt.wantErrs)
+			}
+		})
+	}
+}